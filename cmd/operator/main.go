@@ -4,21 +4,40 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	platformv1alpha1 "github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
 	"github.com/virtual457/orion-platform/pkg/controllers"
+	"github.com/virtual457/orion-platform/pkg/dashboard"
+	"github.com/virtual457/orion-platform/pkg/webhooks"
 )
 
 var (
@@ -36,17 +55,98 @@ func init() {
 	setupLog.Info("Scheme initialized", "groups", scheme.AllKnownTypes())
 }
 
+// operatorOptions collects the operator's command-line configuration
+type operatorOptions struct {
+	metricsAddr          string
+	probeAddr            string
+	enableLeaderElection bool
+	leaderElectionNS     string
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
+	logLevel             string
+	logFormat            string
+	defaultsConfigMap    string
+	enableWebhooks       bool
+	immutableFields      string
+	otelEndpoint         string
+	otelInsecure         bool
+	unhealthyAfter       time.Duration
+	reconcile            controllers.ReconcileConfig
+}
+
 func main() {
-	var metricsAddr string
-	var enableLeaderElection bool
-	var probeAddr string
+	// Subcommands run instead of the operator
+	if len(os.Args) > 1 && os.Args[1] == "dashboard" {
+		runDashboard(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		runRender(os.Args[2:])
+		return
+	}
 
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	opts := operatorOptions{reconcile: controllers.DefaultReconcileConfig()}
+	rc := &opts.reconcile
+
+	flag.StringVar(&opts.metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&opts.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&opts.enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	flag.StringVar(&opts.leaderElectionNS, "leader-election-namespace", "", "Namespace of the leader election lease (defaults to the operator's own namespace in-cluster).")
+	flag.DurationVar(&opts.leaseDuration, "leader-lease-duration", 15*time.Second, "How long non-leaders wait before trying to take over the lease.")
+	flag.DurationVar(&opts.renewDeadline, "leader-renew-deadline", 10*time.Second, "How long the leader keeps retrying to renew before giving up leadership.")
+	flag.DurationVar(&opts.retryPeriod, "leader-retry-period", 2*time.Second, "Interval between leader election attempts.")
+	flag.DurationVar(&rc.InfraRequeue, "infra-requeue", rc.InfraRequeue, "Requeue interval after infrastructure provisioning.")
+	flag.DurationVar(&rc.DeployRequeue, "deploy-requeue", rc.DeployRequeue, "Requeue interval while the application is deploying.")
+	flag.DurationVar(&rc.ReadyRequeue, "ready-requeue", rc.ReadyRequeue, "Periodic health check interval for Ready applications.")
+	flag.DurationVar(&rc.InfraFailureRequeue, "infra-failure-requeue", rc.InfraFailureRequeue, "Retry interval after infrastructure provisioning fails.")
+	flag.DurationVar(&rc.DeployFailureRequeue, "deploy-failure-requeue", rc.DeployFailureRequeue, "Retry interval after creating application resources fails.")
+	flag.DurationVar(&rc.ReadinessErrorRequeue, "readiness-error-requeue", rc.ReadinessErrorRequeue, "Retry interval when the readiness check errors.")
+	flag.DurationVar(&rc.UnknownPhaseRequeue, "unknown-phase-requeue", rc.UnknownPhaseRequeue, "Requeue interval for applications in an unrecognized phase.")
+	flag.DurationVar(&rc.DependencyRequeue, "dependency-requeue", rc.DependencyRequeue, "How often an application waiting on dependsOn rechecks its dependencies.")
+	flag.Var(environmentFlag{&rc.Environment}, "environment", "Environment that \"auto\" infrastructure resolves to: local, aws or auto (detect).")
+	flag.BoolVar(&rc.DefaultZoneSpread, "default-zone-spread", false, "Spread multi-replica applications across zones unless they set topologySpread.")
+	flag.BoolVar(&rc.MountDatabaseCredentials, "mount-db-credentials", false, "Also mount the database credentials Secret as files at "+controllers.DatabaseCredentialsMountPath+".")
+	flag.BoolVar(&rc.InfraEnvPrecedence, "infra-env-precedence", false, "Let generated connection variables (DATABASE_URL, ...) replace application env of the same name instead of the reverse.")
+	flag.StringVar(&rc.SpotNodeLabel, "spot-node-label", "", "Node label (key=value) marking spot/preemptible nodes, e.g. eks.amazonaws.com/capacityType=SPOT; preemptible applications prefer them, infrastructure avoids them.")
+	flag.Var(mapFlag{&rc.InfraNodeSelector}, "infra-node-selector", "Default node selector (key=value,...) for provisioned infrastructure pods.")
+	flag.BoolVar(&rc.StrictValidation, "strict-validation", false, "Reject Applications with spec warnings, such as conflicting storage sizes.")
+	flag.Var(quantityFlag{&rc.Limits.MaxStorage}, "max-storage-per-app", "Maximum total size (e.g. 50Gi) of the volumes one Application may claim (0 is unlimited).")
+	flag.IntVar(&rc.Limits.MaxComponents, "max-components", 0, "Maximum number of infrastructure components per Application (0 is unlimited).")
+	flag.StringVar(&rc.WatchNamespace, "watch-namespace", "", "Only watch and manage this namespace (all namespaces when empty); needs only namespaced RBAC.")
+	flag.BoolVar(&rc.CreateNamespaces, "create-namespaces", false, "Create an Application's targetNamespace if it does not exist.")
+	flag.BoolVar(&rc.AllowRecreate, "allow-recreate", false, "Recreate infrastructure StatefulSets whose immutable fields changed, keeping their volumes.")
+	flag.BoolVar(&rc.SecureDefaults, "secure-defaults", false, "Don't mount the service account token into application pods unless their spec asks for it.")
+	flag.IntVar(&rc.CleanupMaxAttempts, "cleanup-max-attempts", rc.CleanupMaxAttempts, "Failed cleanup attempts of a deleted cross-namespace Application before giving up (0 is unlimited).")
+	flag.DurationVar(&rc.CleanupTimeout, "cleanup-timeout", rc.CleanupTimeout, "How long a deleted cross-namespace Application retries its cleanup before giving up (0 is unlimited).")
+	flag.BoolVar(&rc.OrphanOnCleanupFailure, "orphan-on-cleanup-failure", false, "Remove the cleanup finalizer once cleanup has given up, leaving the remaining resources behind.")
+	flag.StringVar(&rc.ImageRegistry, "image-registry", "", "Mirror registry (host[:port][/path]) to pull infrastructure and sidecar images through, e.g. for air-gapped clusters.")
+	flag.BoolVar(&rc.DisableLocalProvisioning, "disable-local-provisioning", false, "Reject Applications whose infrastructure would be provisioned in the cluster (local); only aws and external are allowed.")
+	flag.Var(listFlag{&rc.DisabledComponents}, "disabled-components", "Comma-separated infrastructure components Applications may not request: postgresql, redis, s3, dynamodb, sqs, kafka.")
+	flag.BoolVar(&rc.Prune, "prune", false, "Delete managed infrastructure whose spec was removed from an Application (PVCs are kept).")
+	flag.Var(listFlag{&rc.WebhookAllowedHosts}, "webhook-allowed-hosts", "Comma-separated hosts (and their subdomains) ready webhooks may notify; \"*\" allows any. Empty sends no notifications.")
+	flag.DurationVar(&rc.MinReconcileInterval, "min-reconcile-interval", rc.MinReconcileInterval, "Minimum interval between rate-limited requeues of the same Application, after an error or Requeue; watch events are not throttled (0 disables).")
+	flag.IntVar(&rc.MaxConcurrentReconciles, "max-concurrent-reconciles", rc.MaxConcurrentReconciles, "Maximum number of Applications reconciled in parallel.")
+	flag.StringVar(&opts.logLevel, "log-level", "info", "Log level: debug, info, warn or error.")
+	flag.StringVar(&opts.logFormat, "log-format", "console", "Log output format: json or console.")
+	flag.StringVar(&opts.defaultsConfigMap, "defaults-configmap", "", "ConfigMap ([namespace/]name) holding a default InfrastructureSpec under the \"infrastructure\" key.")
+	flag.BoolVar(&opts.enableWebhooks, "enable-webhooks", false, "Serve the Application validating admission webhook.")
+	flag.StringVar(&opts.immutableFields, "immutable-fields", strings.Join(platformv1alpha1.DefaultImmutableFields, ","), "Comma-separated spec fields that cannot change after creation (empty allows all changes).")
+	flag.DurationVar(&opts.unhealthyAfter, "unhealthy-reconcile-threshold", 10*time.Minute, "Report not ready once reconciles have failed for this long without a success (0 disables).")
+	flag.StringVar(&opts.otelEndpoint, "otel-endpoint", "", "OTLP/HTTP collector (host:port) to export reconcile traces to; tracing is off when empty.")
+	flag.BoolVar(&opts.otelInsecure, "otel-insecure", false, "Send traces to the OTLP collector over plain HTTP.")
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	logger, err := newLogger(opts.logLevel, opts.logFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid logging flags: %v\n", err)
+		os.Exit(1)
+	}
+	ctrl.SetLogger(logger)
 
 	printBanner()
 
@@ -58,7 +158,40 @@ func main() {
 	}
 
 	// Production mode - real Kubernetes controller
-	runProductionMode(metricsAddr, probeAddr, enableLeaderElection)
+	runProductionMode(opts)
+}
+
+// runDashboard prints a Grafana dashboard for the controller's metrics
+func runDashboard(args []string) {
+	fs := flag.NewFlagSet("dashboard", flag.ExitOnError)
+	title := fs.String("title", dashboard.DefaultTitle, "Dashboard title.")
+	fs.Parse(args)
+
+	out, err := dashboard.Build(*title).JSON()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render dashboard: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+}
+
+// newLogger builds the zap-backed logger from the --log-level and --log-format flags
+func newLogger(level, format string) (logr.Logger, error) {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return logr.Logger{}, err
+	}
+
+	opts := []zap.Opts{zap.Level(lvl)}
+	switch format {
+	case "json":
+		opts = append(opts, zap.JSONEncoder())
+	case "console":
+		opts = append(opts, zap.ConsoleEncoder())
+	default:
+		return logr.Logger{}, fmt.Errorf("unknown log format %q", format)
+	}
+	return zap.New(opts...), nil
 }
 
 func printBanner() {
@@ -112,11 +245,15 @@ func runDevelopmentMode() {
 	}
 
 	// Simulate reconciliation
-	simulateReconciliation(app)
+	simulateReconciliation(os.Stdout, app)
 }
 
-// simulateReconciliation shows what the real controller would do
-func simulateReconciliation(app *platformv1alpha1.Application) {
+// simulationStepDelay paces the simulated provisioning and deployment steps
+var simulationStepDelay = 2 * time.Second
+
+// simulateReconciliation shows what the real controller would do, writing the
+// final status and connection environment to out
+func simulateReconciliation(out io.Writer, app *platformv1alpha1.Application) {
 	setupLog.Info("Starting reconciliation simulation", "app", app.Name)
 
 	// Validation
@@ -131,16 +268,16 @@ func simulateReconciliation(app *platformv1alpha1.Application) {
 	setupLog.Info("Smart infrastructure provisioning")
 	app.UpdateStatus(platformv1alpha1.PhaseProvisioningInfra, "Provisioning infrastructure")
 
-	time.Sleep(2 * time.Second)
+	time.Sleep(simulationStepDelay)
 
 	// Simulate infrastructure ready
 	app.Status.InfrastructureReady = true
 	if app.IsLocalDatabase() {
-		app.Status.DatabaseEndpoint = fmt.Sprintf("%s-postgres:5432", app.Name)
+		app.Status.DatabaseEndpoint = fmt.Sprintf("%s:5432", app.GetPostgresName())
 		app.Status.DatabaseEnvironment = platformv1alpha1.EnvironmentLocal
 	}
 	if app.IsLocalRedis() {
-		app.Status.RedisEndpoint = fmt.Sprintf("%s-redis:6379", app.Name)
+		app.Status.RedisEndpoint = fmt.Sprintf("%s:6379", app.GetRedisName())
 		app.Status.RedisEnvironment = platformv1alpha1.EnvironmentLocal
 	}
 
@@ -152,7 +289,7 @@ func simulateReconciliation(app *platformv1alpha1.Application) {
 	setupLog.Info("Creating application deployment")
 	app.UpdateStatus(platformv1alpha1.PhaseDeploying, "Creating Kubernetes resources")
 
-	time.Sleep(2 * time.Second)
+	time.Sleep(simulationStepDelay)
 
 	setupLog.Info("Kubernetes resources created", "replicas", app.GetReplicas())
 
@@ -165,40 +302,131 @@ func simulateReconciliation(app *platformv1alpha1.Application) {
 		"ready", app.IsReady())
 
 	// Final status
-	fmt.Println("\n📊 FINAL STATUS:")
-	fmt.Printf("   Name: %s\n", app.Name)
-	fmt.Printf("   Phase: %s\n", app.Status.Phase)
-	fmt.Printf("   Ready: %t\n", app.IsReady())
-	fmt.Printf("   Database: %s (%s)\n", app.Status.DatabaseEndpoint, app.Status.DatabaseEnvironment)
-	fmt.Printf("   Cache: %s (%s)\n", app.Status.RedisEndpoint, app.Status.RedisEnvironment)
+	fmt.Fprintln(out, "\n📊 FINAL STATUS:")
+	fmt.Fprintf(out, "   Name: %s\n", app.Name)
+	fmt.Fprintf(out, "   Phase: %s\n", app.Status.Phase)
+	fmt.Fprintf(out, "   Ready: %t\n", app.IsReady())
+	fmt.Fprintf(out, "   Database: %s (%s)\n", app.Status.DatabaseEndpoint, app.Status.DatabaseEnvironment)
+	fmt.Fprintf(out, "   Cache: %s (%s)\n", app.Status.RedisEndpoint, app.Status.RedisEnvironment)
+
+	// What the container would see, ready to paste into a local shell
+	fmt.Fprintln(out, "\n🔌 ENVIRONMENT:")
+	for _, env := range controllers.EnvironmentVariables(app) {
+		switch {
+		case env.ValueFrom == nil:
+			fmt.Fprintf(out, "   %s=%s\n", env.Name, env.Value)
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			fmt.Fprintf(out, "   %s  (from Secret %s, key %s)\n", env.Name, ref.Name, ref.Key)
+		case env.ValueFrom.FieldRef != nil:
+			fmt.Fprintf(out, "   %s  (from pod field %s)\n", env.Name, env.ValueFrom.FieldRef.FieldPath)
+		}
+	}
 
-	fmt.Println("\n🚀 Ready to work with real Kubernetes cluster!")
+	fmt.Fprintln(out, "\n🚀 Ready to work with real Kubernetes cluster!")
 }
 
 // runProductionMode runs the real Kubernetes controller
-func runProductionMode(metricsAddr, probeAddr string, enableLeaderElection bool) {
+func runProductionMode(opts operatorOptions) {
 	setupLog.Info("PRODUCTION MODE - Starting Kubernetes Controller Manager")
 
+	if err := validateLeaderElection(opts); err != nil {
+		setupLog.Error(err, "Invalid leader election flags")
+		os.Exit(1)
+	}
+	if err := platformv1alpha1.ValidateImageRegistry(opts.reconcile.ImageRegistry); err != nil {
+		setupLog.Error(err, "Invalid --image-registry")
+		os.Exit(1)
+	}
+	if opts.reconcile.SpotNodeLabel != "" {
+		if _, _, err := controllers.ParseSpotNodeLabel(opts.reconcile.SpotNodeLabel); err != nil {
+			setupLog.Error(err, "Invalid --spot-node-label")
+			os.Exit(1)
+		}
+	}
+	if err := validateWatchNamespace(opts); err != nil {
+		setupLog.Error(err, "Invalid --watch-namespace")
+		os.Exit(1)
+	}
+	if err := controllers.ValidateDisabledComponents(opts.reconcile.DisabledComponents); err != nil {
+		setupLog.Error(err, "Invalid --disabled-components")
+		os.Exit(1)
+	}
+
+	// Export reconcile traces when a collector is configured
+	if opts.otelEndpoint != "" {
+		shutdown, err := setupTracing(context.Background(), opts.otelEndpoint, opts.otelInsecure)
+		if err != nil {
+			setupLog.Error(err, "Unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				setupLog.Error(err, "Failed to flush traces")
+			}
+		}()
+		setupLog.Info("Exporting traces", "endpoint", opts.otelEndpoint)
+	}
+
 	// Create manager with proper scheme
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:           scheme,
-		LeaderElection:   enableLeaderElection,
-		LeaderElectionID: "orion-platform-controller",
-	})
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions(opts))
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager")
 		os.Exit(1)
 	}
 
+	// Load platform-wide infrastructure defaults and keep them in sync
+	var defaults *controllers.InfrastructureDefaults
+	if opts.defaultsConfigMap != "" {
+		key := parseNamespacedName(opts.defaultsConfigMap, operatorNamespace())
+		defaults = &controllers.InfrastructureDefaults{}
+		if err := defaults.Load(context.Background(), mgr.GetAPIReader(), key); err != nil {
+			setupLog.Error(err, "Unable to load infrastructure defaults, using built-in defaults")
+		}
+		if err := (&controllers.DefaultsReconciler{
+			Client:    mgr.GetClient(),
+			Defaults:  defaults,
+			ConfigMap: key,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "InfrastructureDefaults")
+			os.Exit(1)
+		}
+	}
+
 	// Setup the Application controller with proper client
+	health := controllers.NewReconcileHealth()
 	if err = (&controllers.ApplicationController{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   opts.reconcile,
+		Recorder: mgr.GetEventRecorderFor("orion-controller"),
+		Defaults: defaults,
+		Health:   health,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
 
+	// Setup the validating webhook
+	if opts.enableWebhooks {
+		fields := splitList(opts.immutableFields)
+		if err := platformv1alpha1.ValidateImmutableFields(fields); err != nil {
+			setupLog.Error(err, "Invalid --immutable-fields")
+			os.Exit(1)
+		}
+		if err := (&webhooks.ApplicationValidator{
+			ImmutableFields: fields,
+			Strict:          opts.reconcile.StrictValidation,
+			Limits:          opts.reconcile.Limits,
+			Environment:     controllers.DetectEnvironment(opts.reconcile.Environment),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "Application")
+			os.Exit(1)
+		}
+	}
+
 	// Setup health checks
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "Unable to set up health check")
@@ -208,10 +436,223 @@ func runProductionMode(metricsAddr, probeAddr string, enableLeaderElection bool)
 		setupLog.Error(err, "Unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("cache-sync", controllers.CacheSyncChecker(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "Unable to set up cache sync check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("reconcile", health.Checker(opts.unhealthyAfter)); err != nil {
+		setupLog.Error(err, "Unable to set up reconcile check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("Starting controller manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "Problem running manager")
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
+
+// setupTracing installs a global tracer provider batching spans to an OTLP/HTTP
+// collector and returns the function that flushes and stops it
+func setupTracing(ctx context.Context, endpoint string, insecure bool) (func(context.Context) error, error) {
+	clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(semconv.ServiceName("orion-controller"))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// managerOptions builds the controller manager options from the flags
+func managerOptions(opts operatorOptions) ctrl.Options {
+	leaseDuration, renewDeadline, retryPeriod := opts.leaseDuration, opts.renewDeadline, opts.retryPeriod
+	return ctrl.Options{
+		Scheme:                  scheme,
+		Metrics:                 metricsserver.Options{BindAddress: opts.metricsAddr},
+		HealthProbeBindAddress:  opts.probeAddr,
+		LeaderElection:          opts.enableLeaderElection,
+		LeaderElectionID:        "orion-platform-controller",
+		LeaderElectionNamespace: opts.leaderElectionNS,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		Cache:                   cacheOptions(opts),
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: controllers.UncachedObjects()},
+		},
+	}
+}
+
+// cacheOptions restricts the manager's informers to the watched namespace, if
+// any, and ConfigMaps to the defaults ConfigMap; cluster scoped objects such as
+// Namespaces are still cached
+func cacheOptions(opts operatorOptions) cache.Options {
+	options := cache.Options{}
+	if ns := opts.reconcile.WatchNamespace; ns != "" {
+		options.DefaultNamespaces = map[string]cache.Config{ns: {}}
+	}
+	if opts.defaultsConfigMap != "" {
+		key := parseNamespacedName(opts.defaultsConfigMap, operatorNamespace())
+		options.ByObject = map[client.Object]cache.ByObject{&corev1.ConfigMap{}: controllers.DefaultsCacheConfig(key)}
+	}
+	return options
+}
+
+// validateWatchNamespace checks that a namespace-scoped controller can still
+// see the objects it reads through the cache
+func validateWatchNamespace(opts operatorOptions) error {
+	ns := opts.reconcile.WatchNamespace
+	if ns == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+		return fmt.Errorf("%q is not a namespace name: %s", ns, strings.Join(errs, "; "))
+	}
+	if opts.defaultsConfigMap != "" {
+		if key := parseNamespacedName(opts.defaultsConfigMap, operatorNamespace()); key.Namespace != ns {
+			return fmt.Errorf("--defaults-configmap %s is outside the watched namespace %s", key, ns)
+		}
+	}
+	return nil
+}
+
+// validateLeaderElection checks the lease timings are consistent; client-go
+// requires lease duration > renew deadline > retry period
+func validateLeaderElection(opts operatorOptions) error {
+	if opts.retryPeriod <= 0 {
+		return fmt.Errorf("--leader-retry-period must be positive")
+	}
+	if opts.renewDeadline <= opts.retryPeriod {
+		return fmt.Errorf("--leader-renew-deadline (%s) must be greater than --leader-retry-period (%s)", opts.renewDeadline, opts.retryPeriod)
+	}
+	if opts.leaseDuration <= opts.renewDeadline {
+		return fmt.Errorf("--leader-lease-duration (%s) must be greater than --leader-renew-deadline (%s)", opts.leaseDuration, opts.renewDeadline)
+	}
+	return nil
+}
+
+// operatorNamespace is the namespace the operator runs in, used for its own config objects
+func operatorNamespace() string {
+	if ns := os.Getenv("ORION_NAMESPACE"); ns != "" {
+		return ns
+	}
+	return "orion-system"
+}
+
+// parseNamespacedName splits "namespace/name", defaulting the namespace
+func parseNamespacedName(value, defaultNamespace string) types.NamespacedName {
+	if ns, name, ok := strings.Cut(value, "/"); ok {
+		return types.NamespacedName{Namespace: ns, Name: name}
+	}
+	return types.NamespacedName{Namespace: defaultNamespace, Name: value}
+}
+
+// splitList parses a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// environmentFlag accepts the environments the controller can resolve "auto" to
+type environmentFlag struct {
+	env *platformv1alpha1.Environment
+}
+
+func (f environmentFlag) String() string {
+	if f.env == nil {
+		return ""
+	}
+	return string(*f.env)
+}
+
+func (f environmentFlag) Set(value string) error {
+	switch env := platformv1alpha1.Environment(value); env {
+	case platformv1alpha1.EnvironmentLocal, platformv1alpha1.EnvironmentAWS, platformv1alpha1.EnvironmentAuto:
+		*f.env = env
+		return nil
+	}
+	return fmt.Errorf("must be local, aws or auto")
+}
+
+// quantityFlag parses a resource quantity such as 50Gi
+type quantityFlag struct {
+	q *resource.Quantity
+}
+
+func (f quantityFlag) String() string {
+	if f.q == nil {
+		return ""
+	}
+	return f.q.String()
+}
+
+func (f quantityFlag) Set(value string) error {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return err
+	}
+	if q.Sign() < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	*f.q = q
+	return nil
+}
+
+// mapFlag parses key=value,key2=value2 into a map
+type mapFlag struct {
+	m *map[string]string
+}
+
+func (f mapFlag) String() string {
+	if f.m == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*f.m))
+	for k, v := range *f.m {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f mapFlag) Set(value string) error {
+	m := map[string]string{}
+	for _, pair := range splitList(value) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("expected key=value, got %q", pair)
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	*f.m = m
+	return nil
+}
+
+// listFlag parses a comma-separated list
+type listFlag struct {
+	items *[]string
+}
+
+func (f listFlag) String() string {
+	if f.items == nil {
+		return ""
+	}
+	return strings.Join(*f.items, ",")
+}
+
+func (f listFlag) Set(value string) error {
+	*f.items = splitList(value)
+	return nil
+}
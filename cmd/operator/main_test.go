@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/virtual457/orion-platform/pkg/controllers"
+)
+
+func TestManagerOptionsLeaderElection(t *testing.T) {
+	opts := operatorOptions{
+		enableLeaderElection: true,
+		leaderElectionNS:     "orion-system",
+		leaseDuration:        30 * time.Second,
+		renewDeadline:        20 * time.Second,
+		retryPeriod:          5 * time.Second,
+		reconcile:            controllers.DefaultReconcileConfig(),
+	}
+	options := managerOptions(opts)
+	if !options.LeaderElection || options.LeaderElectionID != "orion-platform-controller" || options.LeaderElectionNamespace != "orion-system" {
+		t.Errorf("leader election = %t, id %q, namespace %q; want enabled with the lease in orion-system",
+			options.LeaderElection, options.LeaderElectionID, options.LeaderElectionNamespace)
+	}
+	if options.LeaseDuration == nil || *options.LeaseDuration != 30*time.Second ||
+		options.RenewDeadline == nil || *options.RenewDeadline != 20*time.Second ||
+		options.RetryPeriod == nil || *options.RetryPeriod != 5*time.Second {
+		t.Errorf("lease timings = %v/%v/%v, want 30s/20s/5s", options.LeaseDuration, options.RenewDeadline, options.RetryPeriod)
+	}
+
+	// The options hold their own copies of the timings
+	opts.leaseDuration = time.Minute
+	if *options.LeaseDuration != 30*time.Second {
+		t.Error("lease duration shared with the flag value")
+	}
+}
+
+func TestValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name                string
+		lease, renew, retry time.Duration
+		wantErr             bool
+	}{
+		{"defaults", 15 * time.Second, 10 * time.Second, 2 * time.Second, false},
+		{"renew not below lease", 10 * time.Second, 10 * time.Second, 2 * time.Second, true},
+		{"retry not below renew", 15 * time.Second, 2 * time.Second, 2 * time.Second, true},
+		{"zero retry", 15 * time.Second, 10 * time.Second, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := operatorOptions{leaseDuration: tt.lease, renewDeadline: tt.renew, retryPeriod: tt.retry}
+			if err := validateLeaderElection(opts); (err != nil) != tt.wantErr {
+				t.Errorf("validateLeaderElection = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManagerOptionsWatchNamespace(t *testing.T) {
+	opts := operatorOptions{reconcile: controllers.DefaultReconcileConfig()}
+	if ns := managerOptions(opts).Cache.DefaultNamespaces; ns != nil {
+		t.Errorf("default namespaces = %v without --watch-namespace, want all namespaces", ns)
+	}
+
+	opts.reconcile.WatchNamespace = "team-a"
+	namespaces := managerOptions(opts).Cache.DefaultNamespaces
+	if _, ok := namespaces["team-a"]; !ok || len(namespaces) != 1 {
+		t.Errorf("default namespaces = %v, want only team-a", namespaces)
+	}
+}
+
+func TestValidateWatchNamespace(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespace         string
+		defaultsConfigMap string
+		wantErr           bool
+	}{
+		{"all namespaces", "", "orion-system/defaults", false},
+		{"namespace", "team-a", "", false},
+		{"not a namespace name", "Team_A", "", true},
+		{"defaults inside the namespace", "team-a", "team-a/defaults", false},
+		{"defaults outside the namespace", "team-a", "orion-system/defaults", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := operatorOptions{defaultsConfigMap: tt.defaultsConfigMap}
+			opts.reconcile.WatchNamespace = tt.namespace
+			if err := validateWatchNamespace(opts); (err != nil) != tt.wantErr {
+				t.Errorf("validateWatchNamespace = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
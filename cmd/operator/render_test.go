@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	platformv1alpha1 "github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const sampleApplication = `apiVersion: platform.orion.dev/v1alpha1
+kind: Application
+metadata:
+  name: shop
+spec:
+  image: example/shop:1.0
+  port: 8080
+  infrastructure:
+    postgresql:
+      enabled: true
+      environment: local
+`
+
+func TestRenderApplication(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(path, []byte(sampleApplication), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	apps, err := readApplications(path)
+	if err != nil {
+		t.Fatalf("readApplications: %v", err)
+	}
+	if len(apps) != 1 {
+		t.Fatalf("read %d Applications, want 1", len(apps))
+	}
+	app := apps[0]
+
+	objects, err := renderApplication(context.Background(), app, platformv1alpha1.EnvironmentLocal)
+	if err != nil {
+		t.Fatalf("renderApplication: %v", err)
+	}
+	rendered := map[string]bool{}
+	for _, obj := range objects {
+		rendered[obj.GetKind()+"/"+obj.GetName()] = true
+		if len(obj.GetOwnerReferences()) != 0 || obj.GetResourceVersion() != "" {
+			t.Errorf("%s %s keeps server-set metadata", obj.GetKind(), obj.GetName())
+		}
+	}
+	for _, want := range []string{
+		"Deployment/" + app.Name,
+		"Service/" + app.Name,
+		"StatefulSet/" + app.GetPostgresName(),
+	} {
+		if !rendered[want] {
+			t.Errorf("rendered objects lack %s; got %v", want, rendered)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := writeManifests(objects, dir); err != nil {
+		t.Fatalf("writeManifests: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "default-statefulset-"+app.GetPostgresName()+".yaml"))
+	if err != nil {
+		t.Fatalf("PostgreSQL StatefulSet manifest not written: %v", err)
+	}
+	if !strings.Contains(string(data), "kind: StatefulSet") {
+		t.Errorf("manifest does not declare its kind:\n%s", data)
+	}
+}
@@ -0,0 +1,114 @@
+// cmd/operator/validate.go
+// Offline validation of Application manifests for CI
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	platformv1alpha1 "github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+	"github.com/virtual457/orion-platform/pkg/controllers"
+	"github.com/virtual457/orion-platform/pkg/webhooks"
+)
+
+// runValidate checks Application manifests with the admission webhook's
+// validation and exits non-zero when any of them is invalid
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	strict := fs.Bool("strict", false, "Treat spec warnings as errors, like --strict-validation.")
+	environment := platformv1alpha1.EnvironmentAuto
+	fs.Var(environmentFlag{&environment}, "environment", "Environment that \"auto\" infrastructure resolves to: local, aws or auto (detect).")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s validate [--strict] [--environment env] <file>... (- reads stdin)\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	validator := &webhooks.ApplicationValidator{Strict: *strict, Environment: controllers.DetectEnvironment(environment)}
+	failed := false
+	for _, path := range fs.Args() {
+		if err := validateFile(validator, path); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// validateFile validates every Application document in a (multi-document)
+// YAML or JSON file; other kinds are skipped. Applications are decoded
+// strictly, so a misspelled or unknown field makes the document invalid
+// rather than being dropped silently.
+func validateFile(validator *webhooks.ApplicationValidator, path string) error {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	invalid := 0
+	for doc := 1; ; doc++ {
+		data, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("document %d: failed to read: %w", doc, err)
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var header struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata,omitempty"`
+		}
+		if err := yaml.Unmarshal(data, &header); err != nil {
+			return fmt.Errorf("document %d: failed to decode: %w", doc, err)
+		}
+		if header.Kind != "Application" {
+			continue
+		}
+		app := &platformv1alpha1.Application{}
+		if err := yaml.UnmarshalStrict(data, app); err != nil {
+			fmt.Printf("%s: %s: invalid: %v\n", path, header.Name, err)
+			invalid++
+			continue
+		}
+		warnings, err := validator.ValidateCreate(context.Background(), app)
+		for _, warning := range warnings {
+			fmt.Printf("%s: %s: warning: %s\n", path, app.Name, warning)
+		}
+		if err != nil {
+			fmt.Printf("%s: %s: invalid: %v\n", path, app.Name, err)
+			invalid++
+			continue
+		}
+		fmt.Printf("%s: %s: valid\n", path, app.Name)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid Application(s)", invalid)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/webhooks"
+)
+
+func TestValidateFileRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  bool
+	}{
+		{"valid", "kind: Application\nmetadata:\n  name: web\nspec:\n  image: nginx:1.25\n", false},
+		{"unknown field", "kind: Application\nmetadata:\n  name: web\nspec:\n  image: nginx:1.25\n  replica: 3\n", true},
+		{"other kinds skipped", "kind: ConfigMap\nmetadata:\n  name: web\nunknown: true\n", false},
+		{"JSON", `{"kind": "Application", "metadata": {"name": "web"}, "spec": {"image": "nginx:1.25", "port": 8080}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.yaml")
+			if err := os.WriteFile(path, []byte(tt.manifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			err := validateFile(&webhooks.ApplicationValidator{}, path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFile = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// pkg/apis/platform/v1alpha1/annotations.go
+// Annotations users can set on an Application to steer the controller
+
+package v1alpha1
+
+const (
+	// PausedAnnotation set to "true" freezes reconciliation of the Application
+	PausedAnnotation = "platform.orion.dev/paused"
+
+	// ConfigHashAnnotation is set by the controller on pod templates; it changes
+	// whenever the rendered env or referenced Secret data changes
+	ConfigHashAnnotation = "platform.orion.dev/config-hash"
+
+	// TemplateHashAnnotation is set by the controller on blue/green Deployments
+	// to tell whether a color runs the current pod template
+	TemplateHashAnnotation = "platform.orion.dev/template-hash"
+
+	// CanaryWeightAnnotation raises the weight of a running canary to the given
+	// percentage; "100" promotes it. Remove it before the next rollout.
+	CanaryWeightAnnotation = "platform.orion.dev/canary-weight"
+
+	// AdoptAnnotation set to "true" lets the controller take over workloads and
+	// Services that already exist under the application's name
+	AdoptAnnotation = "platform.orion.dev/adopt"
+
+	// IdentityAnnotation names an Application independently of its name. A new
+	// Application with the identity of a deleted one takes over its volumes,
+	// so renames keep their data.
+	IdentityAnnotation = "platform.orion.dev/identity"
+)
+
+// IsPaused reports whether reconciliation is paused by annotation
+func (app *Application) IsPaused() bool {
+	return app.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// AdoptsExisting reports whether pre-existing resources may be adopted
+func (app *Application) AdoptsExisting() bool {
+	return app.GetAnnotations()[AdoptAnnotation] == "true"
+}
+
+// GetIdentity returns the stable identity from the annotation, or ""
+func (app *Application) GetIdentity() string {
+	return app.GetAnnotations()[IdentityAnnotation]
+}
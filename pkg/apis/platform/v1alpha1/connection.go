@@ -0,0 +1,248 @@
+// pkg/apis/platform/v1alpha1/connection.go
+// Structured view of the infrastructure endpoints an Application connects to
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Credentials used by the locally provisioned PostgreSQL and MinIO instances
+	LocalDatabaseUser     = "appuser"
+	LocalDatabasePassword = "localpassword"
+	LocalS3AccessKey      = "minioadmin"
+	LocalS3SecretKey      = "minioadmin"
+
+	// DefaultDatabaseName is used when the PostgreSQL spec does not name a database
+	DefaultDatabaseName = "webapp"
+
+	// DefaultPoolSize is the PgBouncer pool size when the spec does not set one
+	DefaultPoolSize = 20
+)
+
+// ConnectionInfo collects the provisioned endpoints for an Application.
+// A nil component means it has not been provisioned (yet).
+type ConnectionInfo struct {
+	Database *DatabaseConnection `json:"database,omitempty"`
+	Cache    *CacheConnection    `json:"cache,omitempty"`
+	Storage  *StorageConnection  `json:"storage,omitempty"`
+	DynamoDB *DynamoDBConnection `json:"dynamodb,omitempty"`
+	Queue    *QueueConnection    `json:"queue,omitempty"`
+	Kafka    *KafkaConnection    `json:"kafka,omitempty"`
+}
+
+// DatabaseConnection describes how to reach the PostgreSQL database
+type DatabaseConnection struct {
+	Endpoint     string      `json:"endpoint"`
+	Environment  Environment `json:"environment,omitempty"`
+	DatabaseName string      `json:"databaseName"`
+	URL          string      `json:"url"`
+	// ReadEndpoint and ReadURL point at read replicas; empty for a single instance
+	ReadEndpoint string `json:"readEndpoint,omitempty"`
+	ReadURL      string `json:"readURL,omitempty"`
+	// PoolerEndpoint is set when URL goes through PgBouncer instead of Endpoint
+	PoolerEndpoint string `json:"poolerEndpoint,omitempty"`
+	// CredentialsSecretName is set for external databases; URL then references
+	// the $(DATABASE_USER) and $(DATABASE_PASSWORD) container variables
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// CacheConnection describes how to reach Redis
+type CacheConnection struct {
+	Endpoint    string      `json:"endpoint"`
+	Environment Environment `json:"environment,omitempty"`
+	URL         string      `json:"url"`
+	// Databases maps each named logical database to its URL
+	Databases []NamedCacheDatabase `json:"databases,omitempty"`
+}
+
+// NamedCacheDatabase is one of the named logical Redis databases
+type NamedCacheDatabase struct {
+	Name  string `json:"name"`
+	Index int32  `json:"index"`
+	URL   string `json:"url"`
+}
+
+// EnvName is the variable the database's URL is exposed as
+func (db NamedCacheDatabase) EnvName() string {
+	return "REDIS_URL_" + strings.ToUpper(db.Name)
+}
+
+// StorageConnection describes how to reach the S3 bucket
+type StorageConnection struct {
+	BucketName  string      `json:"bucketName"`
+	Endpoint    string      `json:"endpoint,omitempty"`
+	Environment Environment `json:"environment,omitempty"`
+	URL         string      `json:"url,omitempty"`
+	AccessKey   string      `json:"accessKey,omitempty"`
+	SecretKey   string      `json:"secretKey,omitempty"`
+}
+
+// DynamoDBConnection describes how to reach the DynamoDB table. URL is empty
+// when the SDK's default regional endpoint should be used.
+type DynamoDBConnection struct {
+	TableName   string      `json:"tableName"`
+	Endpoint    string      `json:"endpoint,omitempty"`
+	Environment Environment `json:"environment,omitempty"`
+	URL         string      `json:"url,omitempty"`
+}
+
+// QueueConnection describes how to reach the SQS queue. Endpoint is only set
+// for local queues, whose SDK clients must override the service endpoint.
+type QueueConnection struct {
+	QueueName   string      `json:"queueName"`
+	URL         string      `json:"url,omitempty"`
+	Endpoint    string      `json:"endpoint,omitempty"`
+	Environment Environment `json:"environment,omitempty"`
+}
+
+// KafkaConnection describes how to reach the Kafka brokers
+type KafkaConnection struct {
+	Brokers     string      `json:"brokers"`
+	Environment Environment `json:"environment,omitempty"`
+}
+
+// GetDatabaseName returns the configured database name or the default
+func (app *Application) GetDatabaseName() string {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.DatabaseName != "" {
+		return app.Spec.Infrastructure.PostgreSQL.DatabaseName
+	}
+	return DefaultDatabaseName
+}
+
+// GetConnectionInfo builds the connection details from the current status
+func (app *Application) GetConnectionInfo() ConnectionInfo {
+	info := ConnectionInfo{}
+
+	if app.Status.DatabaseEndpoint != "" {
+		dbName := app.GetDatabaseName()
+		user, password := "user", "password"
+		secretName := ""
+		switch app.Status.DatabaseEnvironment {
+		case EnvironmentLocal:
+			user, password = LocalDatabaseUser, LocalDatabasePassword
+		case EnvironmentExternal:
+			user, password = "$(DATABASE_USER)", "$(DATABASE_PASSWORD)"
+			if app.IsExternalDatabase() {
+				secretName = app.Spec.Infrastructure.PostgreSQL.External.CredentialsSecretName
+			}
+		}
+		host := app.Status.DatabaseEndpoint
+		pooler := ""
+		if app.Status.DatabaseEnvironment == EnvironmentLocal && app.Status.DatabasePoolerEndpoint != "" {
+			pooler = app.Status.DatabasePoolerEndpoint
+			host = pooler
+		}
+		info.Database = &DatabaseConnection{
+			Endpoint:              app.Status.DatabaseEndpoint,
+			Environment:           app.Status.DatabaseEnvironment,
+			DatabaseName:          dbName,
+			URL:                   fmt.Sprintf("postgres://%s:%s@%s/%s", user, password, host, dbName),
+			PoolerEndpoint:        pooler,
+			CredentialsSecretName: secretName,
+		}
+		if app.Status.DatabaseReadEndpoint != "" {
+			info.Database.ReadEndpoint = app.Status.DatabaseReadEndpoint
+			info.Database.ReadURL = fmt.Sprintf("postgres://%s:%s@%s/%s", user, password, app.Status.DatabaseReadEndpoint, dbName)
+		}
+	}
+
+	if app.Status.RedisEndpoint != "" {
+		info.Cache = &CacheConnection{
+			Endpoint:    app.Status.RedisEndpoint,
+			Environment: app.Status.RedisEnvironment,
+			URL:         fmt.Sprintf("redis://%s", app.Status.RedisEndpoint),
+		}
+		if redis := app.Spec.Infrastructure.Redis; redis != nil {
+			for i, name := range redis.Databases {
+				index := int32(i + 1)
+				info.Cache.Databases = append(info.Cache.Databases, NamedCacheDatabase{
+					Name:  name,
+					Index: index,
+					URL:   fmt.Sprintf("redis://%s/%d", app.Status.RedisEndpoint, index),
+				})
+			}
+		}
+	}
+
+	if app.Status.S3BucketName != "" {
+		info.Storage = &StorageConnection{
+			BucketName:  app.Status.S3BucketName,
+			Endpoint:    app.Status.S3Endpoint,
+			Environment: app.Status.S3Environment,
+		}
+		switch app.Status.S3Environment {
+		case EnvironmentLocal:
+			info.Storage.URL = fmt.Sprintf("http://%s", app.Status.S3Endpoint)
+			info.Storage.AccessKey = LocalS3AccessKey
+			info.Storage.SecretKey = LocalS3SecretKey
+		case EnvironmentExternal:
+			info.Storage.URL = app.Status.S3Endpoint
+		}
+	}
+
+	if app.Status.DynamoDBTableName != "" {
+		info.DynamoDB = &DynamoDBConnection{
+			TableName:   app.Status.DynamoDBTableName,
+			Endpoint:    app.Status.DynamoDBEndpoint,
+			Environment: app.Status.DynamoDBEnvironment,
+		}
+		switch {
+		case app.Status.DynamoDBEndpoint == "":
+		case app.Status.DynamoDBEnvironment == EnvironmentLocal:
+			info.DynamoDB.URL = fmt.Sprintf("http://%s", app.Status.DynamoDBEndpoint)
+		default:
+			info.DynamoDB.URL = fmt.Sprintf("https://%s", app.Status.DynamoDBEndpoint)
+		}
+	}
+
+	if app.Status.SQSQueueName != "" {
+		info.Queue = &QueueConnection{
+			QueueName:   app.Status.SQSQueueName,
+			URL:         app.Status.SQSQueueURL,
+			Environment: app.Status.SQSEnvironment,
+		}
+		if app.Status.SQSEnvironment == EnvironmentLocal {
+			info.Queue.Endpoint = fmt.Sprintf("http://%s:9324", app.GetSQSName())
+		}
+	}
+
+	if app.Status.KafkaBrokers != "" {
+		info.Kafka = &KafkaConnection{
+			Brokers:     app.Status.KafkaBrokers,
+			Environment: app.Status.KafkaEnvironment,
+		}
+	}
+
+	return info
+}
+
+// ConnectionEnvNames lists every variable the connection details of the
+// requested components can be exposed as, whether or not they are provisioned yet
+func (app *Application) ConnectionEnvNames() []string {
+	var names []string
+	if app.NeedsDatabase() {
+		names = append(names, "DATABASE_USER", "DATABASE_PASSWORD", "DATABASE_URL", "DATABASE_READ_URL")
+	}
+	if app.NeedsCache() {
+		names = append(names, "REDIS_URL")
+		for _, name := range app.Spec.Infrastructure.Redis.Databases {
+			names = append(names, NamedCacheDatabase{Name: name}.EnvName())
+		}
+	}
+	if app.NeedsStorage() {
+		names = append(names, "S3_BUCKET", "S3_ENDPOINT", "S3_ACCESS_KEY", "S3_SECRET_KEY")
+	}
+	if app.NeedsDynamoDB() {
+		names = append(names, "DYNAMODB_TABLE", "DYNAMODB_ENDPOINT")
+	}
+	if app.NeedsQueue() {
+		names = append(names, "SQS_QUEUE_NAME", "SQS_QUEUE_URL", "SQS_ENDPOINT")
+	}
+	if app.NeedsStreaming() {
+		names = append(names, "KAFKA_BROKERS")
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+// pkg/apis/platform/v1alpha1/defaults.go
+// Merging platform-wide infrastructure defaults into an Application
+
+package v1alpha1
+
+// ApplyDefaults fills unset fields from the platform defaults. Values set on the
+// Application always win, and defaults never add a component the Application
+// did not ask for.
+func (infra *InfrastructureSpec) ApplyDefaults(defaults InfrastructureSpec) {
+	if infra.Environment == "" {
+		infra.Environment = defaults.Environment
+	}
+	if infra.PostgreSQL != nil && defaults.PostgreSQL != nil {
+		infra.PostgreSQL.applyDefaults(defaults.PostgreSQL)
+	}
+	if infra.Redis != nil && defaults.Redis != nil {
+		infra.Redis.applyDefaults(defaults.Redis)
+	}
+	if infra.S3 != nil && defaults.S3 != nil {
+		infra.S3.applyDefaults(defaults.S3)
+	}
+}
+
+func (pg *PostgreSQLSpec) applyDefaults(d *PostgreSQLSpec) {
+	defaultString(&pg.Environment, d.Environment)
+	defaultString(&pg.Version, d.Version)
+	defaultString(&pg.InstanceType, d.InstanceType)
+	defaultString(&pg.DatabaseName, d.DatabaseName)
+	defaultString(&pg.LocalStorage, d.LocalStorage)
+	if pg.Storage == 0 {
+		pg.Storage = d.Storage
+	}
+}
+
+func (redis *RedisSpec) applyDefaults(d *RedisSpec) {
+	defaultString(&redis.Environment, d.Environment)
+	defaultString(&redis.Version, d.Version)
+	defaultString(&redis.NodeType, d.NodeType)
+	defaultString(&redis.Memory, d.Memory)
+}
+
+func (s3 *S3Spec) applyDefaults(d *S3Spec) {
+	defaultString(&s3.Environment, d.Environment)
+	defaultString(&s3.LocalStorage, d.LocalStorage)
+	defaultString(&s3.Version, d.Version)
+}
+
+func defaultString[T ~string](field *T, value T) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// ResolveAutoEnvironment replaces "auto" (and an unset top-level environment)
+// with the concrete environment the controller detected, so every component
+// resolves the same way
+func (infra *InfrastructureSpec) ResolveAutoEnvironment(env Environment) {
+	resolve := func(field *Environment) {
+		if *field == EnvironmentAuto {
+			*field = env
+		}
+	}
+
+	if infra.Environment == "" {
+		infra.Environment = env
+	}
+	resolve(&infra.Environment)
+	if infra.PostgreSQL != nil {
+		resolve(&infra.PostgreSQL.Environment)
+	}
+	if infra.Redis != nil {
+		resolve(&infra.Redis.Environment)
+	}
+	if infra.S3 != nil {
+		resolve(&infra.S3.Environment)
+	}
+	if infra.DynamoDB != nil {
+		resolve(&infra.DynamoDB.Environment)
+	}
+	if infra.SQS != nil {
+		resolve(&infra.SQS.Environment)
+	}
+	if infra.Kafka != nil {
+		resolve(&infra.Kafka.Environment)
+	}
+}
@@ -0,0 +1,30 @@
+package v1alpha1
+
+import "testing"
+
+func TestDownwardEnvNameCollisions(t *testing.T) {
+	base := func() *Application {
+		app := &Application{}
+		app.Name = "web"
+		app.Spec.Image = "nginx:1.25"
+		app.Spec.Infrastructure.PostgreSQL = &PostgreSQLSpec{Environment: EnvironmentLocal}
+		app.Spec.DownwardEnv = []DownwardEnvVar{{Name: "POD_NAME", FieldPath: "metadata.name"}}
+		return app
+	}
+
+	if err := base().ValidateSpec(); err != nil {
+		t.Fatalf("distinct downward env name rejected: %v", err)
+	}
+
+	app := base()
+	app.Spec.Env = map[string]string{"POD_NAME": "fixed"}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("downward env name also set in env accepted")
+	}
+
+	app = base()
+	app.Spec.DownwardEnv[0].Name = "DATABASE_URL"
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("downward env name shadowing DATABASE_URL accepted")
+	}
+}
@@ -0,0 +1,60 @@
+// pkg/apis/platform/v1alpha1/env_references.go
+// ${token} references to infrastructure status in spec.env values
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// EnvReferencePattern matches ${name} references in spec.env values.
+// Kubernetes' own $(VAR) expansion uses parentheses, so the two do not collide.
+var EnvReferencePattern = regexp.MustCompile(`\$\{([A-Za-z][A-Za-z0-9]*)\}`)
+
+// envReferences maps each supported reference to the component that provides it
+var envReferences = map[string]struct {
+	component string
+	needed    func(*Application) bool
+}{
+	"databaseEndpoint": {"postgresql", (*Application).NeedsDatabase},
+	"redisEndpoint":    {"redis", (*Application).NeedsCache},
+	"s3Bucket":         {"s3", (*Application).NeedsStorage},
+}
+
+// validateEnvReferences rejects references that are unknown or name a
+// component the Application does not request, which could never resolve
+func (app *Application) validateEnvReferences() error {
+	for _, name := range sortedEnvNames(app.Spec.Env) {
+		for _, match := range EnvReferencePattern.FindAllStringSubmatch(app.Spec.Env[name], -1) {
+			ref, ok := envReferences[match[1]]
+			switch {
+			case !ok:
+				return fmt.Errorf("env %s: unknown reference ${%s} (%s)", name, match[1], supportedEnvReferences())
+			case !ref.needed(app):
+				return fmt.Errorf("env %s: ${%s} needs infrastructure.%s", name, match[1], ref.component)
+			}
+		}
+	}
+	return nil
+}
+
+func supportedEnvReferences() string {
+	names := make([]string, 0, len(envReferences))
+	for name := range envReferences {
+		names = append(names, "${"+name+"}")
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func sortedEnvNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
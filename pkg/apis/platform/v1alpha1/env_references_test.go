@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateEnvReferences(t *testing.T) {
+	withDatabase := InfrastructureSpec{PostgreSQL: &PostgreSQLSpec{}}
+	tests := []struct {
+		name    string
+		infra   InfrastructureSpec
+		env     map[string]string
+		wantErr string
+	}{
+		{"requested component", withDatabase, map[string]string{"DB_HOST": "${databaseEndpoint}"}, ""},
+		{"kubernetes expansion", InfrastructureSpec{}, map[string]string{"URL": "http://$(HOST)"}, ""},
+		{"unknown reference", withDatabase, map[string]string{"DB_HOST": "${databaseHost}"}, "unknown reference ${databaseHost}"},
+		{"component not requested", withDatabase, map[string]string{"CACHE": "${redisEndpoint}"}, "needs infrastructure.redis"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Env: tt.env, Infrastructure: tt.infra}}
+			err := app.validateEnvReferences()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
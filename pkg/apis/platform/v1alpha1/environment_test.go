@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveAutoEnvironment(t *testing.T) {
+	app := &Application{Spec: ApplicationSpec{Infrastructure: InfrastructureSpec{
+		PostgreSQL: &PostgreSQLSpec{},
+		Redis:      &RedisSpec{Environment: EnvironmentAuto},
+		S3:         &S3Spec{Environment: EnvironmentLocal},
+	}}}
+	if app.IsLocalDatabase() || app.IsLocalRedis() {
+		t.Error("unresolved auto reported as local")
+	}
+	if !strings.Contains(app.GetInfrastructureSummary(), "PostgreSQL (auto)") {
+		t.Errorf("summary = %q, want PostgreSQL shown as auto", app.GetInfrastructureSummary())
+	}
+
+	app.Spec.Infrastructure.ResolveAutoEnvironment(EnvironmentAWS)
+	if app.GetDatabaseEnvironment() != EnvironmentAWS || app.GetRedisEnvironment() != EnvironmentAWS {
+		t.Errorf("database %s, redis %s; want both resolved to aws", app.GetDatabaseEnvironment(), app.GetRedisEnvironment())
+	}
+	if !app.IsLocalS3() {
+		t.Error("explicit local S3 overridden by the resolved environment")
+	}
+}
+
+func TestGetInfrastructureSummary(t *testing.T) {
+	tests := []struct {
+		name  string
+		infra InfrastructureSpec
+		want  string
+	}{
+		{"none", InfrastructureSpec{}, "No external infrastructure"},
+		{"local", InfrastructureSpec{Redis: &RedisSpec{Environment: EnvironmentLocal}}, "Infrastructure: [Redis (local:local)]"},
+		{"aws", InfrastructureSpec{S3: &S3Spec{Environment: EnvironmentAWS}}, "Infrastructure: [S3 (AWS:aws)]"},
+		{
+			"external",
+			InfrastructureSpec{
+				PostgreSQL: &PostgreSQLSpec{External: &ExternalDatabaseSpec{Endpoint: "db:5432", CredentialsSecretName: "db"}},
+				Redis:      &RedisSpec{Environment: EnvironmentExternal, Endpoint: "cache:6379"},
+				S3:         &S3Spec{Environment: EnvironmentExternal, BucketName: "assets"},
+			},
+			"Infrastructure: [PostgreSQL (external) Redis (external) S3 (external)]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Infrastructure: tt.infra}}
+			if got := app.GetInfrastructureSummary(); got != tt.want {
+				t.Errorf("GetInfrastructureSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsExternal(t *testing.T) {
+	app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", Infrastructure: InfrastructureSpec{
+		PostgreSQL: &PostgreSQLSpec{External: &ExternalDatabaseSpec{Endpoint: "db:5432", CredentialsSecretName: "db"}},
+		Redis:      &RedisSpec{Environment: EnvironmentExternal, Endpoint: "cache:6379"},
+		S3:         &S3Spec{Environment: EnvironmentLocal},
+	}}}
+	if err := app.ValidateSpec(); err != nil {
+		t.Fatalf("ValidateSpec() = %v", err)
+	}
+	if !app.IsExternalDatabase() || !app.IsExternalRedis() {
+		t.Error("external database or Redis not reported as external")
+	}
+	if app.IsLocalDatabase() || app.IsLocalRedis() {
+		t.Error("external database or Redis reported as local")
+	}
+	if app.IsExternalS3() || app.IsExternalKafka() {
+		t.Error("local or absent components reported as external")
+	}
+
+	app.Spec.Infrastructure.Redis.Endpoint = ""
+	if err := app.ValidateSpec(); err == nil || !strings.Contains(err.Error(), "redis.endpoint") {
+		t.Errorf("ValidateSpec() = %v, want external Redis without an endpoint rejected", err)
+	}
+}
@@ -0,0 +1,68 @@
+// pkg/apis/platform/v1alpha1/image_profiles.go
+// Well-known ports and health paths of common images
+
+package v1alpha1
+
+import "strings"
+
+// ImageProfile holds the defaults of a recognized image
+type ImageProfile struct {
+	// Port the image listens on
+	Port int32 `json:"port,omitempty"`
+	// HealthCheckPath answers HTTP readiness checks; empty for non-HTTP images
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+}
+
+// BuiltinImageProfiles are keyed by image repository, without registry or tag
+// ("nginx", "grafana/grafana"). The platform defaults ConfigMap can add to and
+// override them.
+var BuiltinImageProfiles = map[string]ImageProfile{
+	"nginx":                       {Port: 80, HealthCheckPath: "/"},
+	"nginxinc/nginx-unprivileged": {Port: 8080, HealthCheckPath: "/"},
+	"httpd":                       {Port: 80, HealthCheckPath: "/"},
+	"caddy":                       {Port: 80, HealthCheckPath: "/"},
+	"redis":                       {Port: 6379},
+	"memcached":                   {Port: 11211},
+	"postgres":                    {Port: 5432},
+	"grafana/grafana":             {Port: 3000, HealthCheckPath: "/api/health"},
+	"prom/prometheus":             {Port: 9090, HealthCheckPath: "/-/ready"},
+	"traefik/whoami":              {Port: 80, HealthCheckPath: "/health"},
+}
+
+// imageRepository strips the registry host, tag and digest from an image, and
+// the implicit "library/" of Docker Hub official images
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	if i := strings.Index(image, "/"); i > 0 {
+		host := image[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			image = image[i+1:]
+		}
+	}
+	return strings.TrimPrefix(image, "library/")
+}
+
+// ApplyImageProfile fills the port and health check path from the profile of
+// a recognized image when the Application opted in with useImageProfile.
+// Values set in the spec are never changed, and images without a profile are
+// left alone.
+func (app *Application) ApplyImageProfile(profiles map[string]ImageProfile) {
+	if !app.Spec.UseImageProfile {
+		return
+	}
+	profile, ok := profiles[imageRepository(app.Spec.Image)]
+	if !ok {
+		return
+	}
+	if app.Spec.Port == 0 {
+		app.Spec.Port = profile.Port
+	}
+	if app.Spec.HealthCheckPath == "" {
+		app.Spec.HealthCheckPath = profile.HealthCheckPath
+	}
+}
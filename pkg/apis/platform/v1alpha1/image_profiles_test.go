@@ -0,0 +1,27 @@
+package v1alpha1
+
+import "testing"
+
+func TestApplyImageProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     ApplicationSpec
+		wantPort int32
+		wantPath string
+	}{
+		{"known image", ApplicationSpec{Image: "nginx:1.25", UseImageProfile: true}, 80, "/"},
+		{"registry and digest", ApplicationSpec{Image: "registry.example.com:5000/grafana/grafana@sha256:abc", UseImageProfile: true}, 3000, "/api/health"},
+		{"spec values kept", ApplicationSpec{Image: "nginx", Port: 8081, HealthCheckPath: "/ready", UseImageProfile: true}, 8081, "/ready"},
+		{"unknown image", ApplicationSpec{Image: "example/shop:1.0", UseImageProfile: true}, 0, ""},
+		{"not opted in", ApplicationSpec{Image: "nginx:1.25"}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: tt.spec}
+			app.ApplyImageProfile(BuiltinImageProfiles)
+			if app.Spec.Port != tt.wantPort || app.Spec.HealthCheckPath != tt.wantPath {
+				t.Errorf("port %d path %q, want %d %q", app.Spec.Port, app.Spec.HealthCheckPath, tt.wantPort, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// pkg/apis/platform/v1alpha1/images.go
+// Pulling images through a mirror registry
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateImageRegistry checks a mirror is a bare registry host, optionally
+// with a port and path prefix, e.g. myregistry.internal:5000/mirror
+func ValidateImageRegistry(registry string) error {
+	if registry == "" {
+		return nil
+	}
+	if strings.Contains(registry, "://") {
+		return fmt.Errorf("image registry %q must not include a scheme", registry)
+	}
+	if strings.HasSuffix(registry, "/") || strings.ContainsAny(registry, " @") {
+		return fmt.Errorf("image registry %q must be a host with an optional path, e.g. registry.internal/mirror", registry)
+	}
+	return nil
+}
+
+// MirrorImage rewrites image to be pulled from registry, keeping its
+// repository path. Docker Hub references are expanded first, so
+// "postgres:15" becomes "<registry>/library/postgres:15"; other registries'
+// hosts are replaced. An empty registry leaves the image unchanged.
+func MirrorImage(registry, image string) string {
+	if registry == "" || image == "" {
+		return image
+	}
+	path := image
+	if i := strings.Index(image, "/"); i > 0 {
+		host := image[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			path = image[i+1:]
+		}
+	}
+	if path == image && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+	return registry + "/" + path
+}
+
+// InfraImage returns image as it should be pulled for the infrastructure and
+// sidecars provisioned for the Application
+func (app *Application) InfraImage(image string) string {
+	return MirrorImage(app.Spec.ImageRegistry, image)
+}
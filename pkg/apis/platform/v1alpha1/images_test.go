@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetImagePullPolicy(t *testing.T) {
+	tests := []struct {
+		image  string
+		policy string
+		want   corev1.PullPolicy
+	}{
+		{"nginx:1.25", "Always", corev1.PullAlways},
+		{"nginx:latest", "IfNotPresent", corev1.PullIfNotPresent},
+		{"nginx:1.25", "Never", corev1.PullNever},
+		{"nginx:1.25", "", corev1.PullIfNotPresent},
+		{"nginx:latest", "", corev1.PullAlways},
+		{"nginx", "", corev1.PullAlways},
+		{"nginx@sha256:abc", "", corev1.PullIfNotPresent},
+		{"registry.example.com:5000/team/shop", "", corev1.PullAlways},
+		{"registry.example.com:5000/team/shop:v2", "", corev1.PullIfNotPresent},
+		{"registry.example.com:5000/team/shop:latest", "", corev1.PullAlways},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image+"/"+tt.policy, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Image: tt.image, ImagePullPolicy: tt.policy}}
+			if got := app.GetImagePullPolicy(); got != tt.want {
+				t.Errorf("GetImagePullPolicy() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", ImagePullPolicy: "Sometimes"}}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("unknown imagePullPolicy accepted")
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	tests := []struct {
+		registry string
+		image    string
+		want     string
+	}{
+		{"", "postgres:15", "postgres:15"},
+		{"mirror.internal", "postgres:15", "mirror.internal/library/postgres:15"},
+		{"mirror.internal", "bitnami/redis:7", "mirror.internal/bitnami/redis:7"},
+		{"mirror.internal:5000/hub", "quay.io/minio/minio:RELEASE.2024-01-01T00-00-00Z", "mirror.internal:5000/hub/minio/minio:RELEASE.2024-01-01T00-00-00Z"},
+		{"mirror.internal", "localhost/tools/mc", "mirror.internal/tools/mc"},
+		{"mirror.internal", "registry.example.com:5000/team/shop@sha256:abc", "mirror.internal/team/shop@sha256:abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry+"/"+tt.image, func(t *testing.T) {
+			if got := MirrorImage(tt.registry, tt.image); got != tt.want {
+				t.Errorf("MirrorImage(%q, %q) = %q, want %q", tt.registry, tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateImageRegistry(t *testing.T) {
+	for _, registry := range []string{"", "mirror.internal", "mirror.internal:5000/hub"} {
+		if err := ValidateImageRegistry(registry); err != nil {
+			t.Errorf("ValidateImageRegistry(%q) = %v", registry, err)
+		}
+	}
+	for _, registry := range []string{"https://mirror.internal", "mirror.internal/", "mirror internal"} {
+		if err := ValidateImageRegistry(registry); err == nil {
+			t.Errorf("ValidateImageRegistry(%q) accepted", registry)
+		}
+	}
+}
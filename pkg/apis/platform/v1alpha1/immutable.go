@@ -0,0 +1,144 @@
+// pkg/apis/platform/v1alpha1/immutable.go
+// Fields that must not change once an Application has been created
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// immutableFieldGetters maps a spec field path to an accessor for its value.
+// Changing any of these after creation would orphan data or running resources.
+var immutableFieldGetters = map[string]func(*ApplicationSpec) string{
+	"kind": func(s *ApplicationSpec) string { return string(s.Kind) },
+	"appStorage": func(s *ApplicationSpec) string { return s.AppStorage },
+	"targetNamespace": func(s *ApplicationSpec) string { return s.TargetNamespace },
+	"infrastructure.postgresql.databaseName": func(s *ApplicationSpec) string {
+		if s.Infrastructure.PostgreSQL == nil {
+			return ""
+		}
+		return s.Infrastructure.PostgreSQL.DatabaseName
+	},
+	"infrastructure.postgresql.environment": func(s *ApplicationSpec) string {
+		if s.Infrastructure.PostgreSQL == nil {
+			return ""
+		}
+		return string(s.Infrastructure.PostgreSQL.Environment)
+	},
+	"infrastructure.postgresql.version": func(s *ApplicationSpec) string {
+		if s.Infrastructure.PostgreSQL == nil {
+			return ""
+		}
+		return s.Infrastructure.PostgreSQL.Version
+	},
+	"infrastructure.postgresql.accessMode": func(s *ApplicationSpec) string {
+		if s.Infrastructure.PostgreSQL == nil {
+			return ""
+		}
+		return s.Infrastructure.PostgreSQL.AccessMode
+	},
+	"infrastructure.postgresql.storageClass": func(s *ApplicationSpec) string {
+		if s.Infrastructure.PostgreSQL == nil {
+			return ""
+		}
+		return s.Infrastructure.PostgreSQL.StorageClass
+	},
+	"infrastructure.kafka.accessMode": func(s *ApplicationSpec) string {
+		if s.Infrastructure.Kafka == nil {
+			return ""
+		}
+		return s.Infrastructure.Kafka.AccessMode
+	},
+	"infrastructure.s3.bucketName": func(s *ApplicationSpec) string {
+		if s.Infrastructure.S3 == nil {
+			return ""
+		}
+		return s.Infrastructure.S3.BucketName
+	},
+}
+
+// DefaultImmutableFields are protected unless the operator is configured otherwise
+var DefaultImmutableFields = []string{
+	"infrastructure.postgresql.databaseName",
+	"infrastructure.postgresql.accessMode",
+	"infrastructure.postgresql.storageClass",
+	"infrastructure.kafka.accessMode",
+	"infrastructure.s3.bucketName",
+	"targetNamespace",
+}
+
+// KnownImmutableFields lists every field path that can be marked immutable
+func KnownImmutableFields() []string {
+	fields := make([]string, 0, len(immutableFieldGetters))
+	for field := range immutableFieldGetters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ValidateImmutableFields checks that a field list only names known fields
+func ValidateImmutableFields(fields []string) error {
+	for _, field := range fields {
+		if _, ok := immutableFieldGetters[field]; !ok {
+			return fmt.Errorf("unknown immutable field %q (known: %s)", field, strings.Join(KnownImmutableFields(), ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateUpdate rejects changes to the given immutable fields, and any storage
+// decrease. A field that was unset on the old object may still be set for the
+// first time.
+func (app *Application) ValidateUpdate(old *Application, immutableFields []string) error {
+	if err := app.validateStorageGrowth(old); err != nil {
+		return err
+	}
+	var changed []string
+	for _, field := range immutableFields {
+		get, ok := immutableFieldGetters[field]
+		if !ok {
+			continue
+		}
+		before, after := get(&old.Spec), get(&app.Spec)
+		if before != "" && before != after {
+			changed = append(changed, fmt.Sprintf("%s (%q -> %q)", field, before, after))
+		}
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("immutable fields cannot be changed after creation: %s", strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// validateStorageGrowth rejects smaller storage requests: Kubernetes can grow a
+// volume but never shrink one, so a decrease could only fail later
+func (app *Application) validateStorageGrowth(old *Application) error {
+	var shrunk []string
+	shrinks := func(field, before, after string) {
+		b, errB := resource.ParseQuantity(before)
+		a, errA := resource.ParseQuantity(after)
+		if errB == nil && errA == nil && a.Cmp(b) < 0 {
+			shrunk = append(shrunk, fmt.Sprintf("%s (%s -> %s)", field, before, after))
+		}
+	}
+
+	if old.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL != nil {
+		shrinks("infrastructure.postgresql.localStorage", old.GetDatabaseStorage(), app.GetDatabaseStorage())
+		if before, after := old.Spec.Infrastructure.PostgreSQL.Storage, app.Spec.Infrastructure.PostgreSQL.Storage; before > 0 && after > 0 {
+			shrinks("infrastructure.postgresql.storage", fmt.Sprintf("%dGi", before), fmt.Sprintf("%dGi", after))
+		}
+	}
+	if old.GetKind() == WorkloadStatefulSet && app.GetKind() == WorkloadStatefulSet {
+		shrinks("appStorage", old.GetAppStorage(), app.GetAppStorage())
+	}
+
+	if len(shrunk) > 0 {
+		return fmt.Errorf("storage can only grow, volumes cannot be shrunk: %s", strings.Join(shrunk, ", "))
+	}
+	return nil
+}
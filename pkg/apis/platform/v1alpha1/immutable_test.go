@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateUpdateImmutableFields(t *testing.T) {
+	old := &Application{Spec: ApplicationSpec{
+		Image:    "nginx:1.25",
+		Replicas: 1,
+		Infrastructure: InfrastructureSpec{PostgreSQL: &PostgreSQLSpec{
+			DatabaseName: "orders",
+			StorageClass: "fast",
+		}},
+	}}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Application)
+		wantErr string
+	}{
+		{"replicas", func(a *Application) { a.Spec.Replicas = 3 }, ""},
+		{"image", func(a *Application) { a.Spec.Image = "nginx:1.26" }, ""},
+		{"databaseName", func(a *Application) { a.Spec.Infrastructure.PostgreSQL.DatabaseName = "sales" }, "infrastructure.postgresql.databaseName"},
+		{"storageClass", func(a *Application) { a.Spec.Infrastructure.PostgreSQL.StorageClass = "slow" }, "infrastructure.postgresql.storageClass"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := old.DeepCopy()
+			tt.mutate(app)
+			err := app.ValidateUpdate(old, DefaultImmutableFields)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("err = %v, want one naming %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateAllowsFirstSet(t *testing.T) {
+	old := &Application{Spec: ApplicationSpec{Infrastructure: InfrastructureSpec{PostgreSQL: &PostgreSQLSpec{}}}}
+	app := old.DeepCopy()
+	app.Spec.Infrastructure.PostgreSQL.StorageClass = "fast"
+	if err := app.ValidateUpdate(old, DefaultImmutableFields); err != nil {
+		t.Errorf("setting an unset field: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// pkg/apis/platform/v1alpha1/limits.go
+// Platform-wide caps on the infrastructure a single Application may request
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// InfrastructureLimits caps what one Application may request. Zero values mean
+// no limit.
+type InfrastructureLimits struct {
+	// MaxStorage caps the summed sizes of the volumes the Application claims
+	MaxStorage resource.Quantity
+	// MaxComponents caps the number of infrastructure components
+	MaxComponents int
+}
+
+// RequestedComponents counts the infrastructure components in the spec
+func (app *Application) RequestedComponents() int {
+	count := 0
+	for _, needed := range []bool{
+		app.NeedsDatabase(), app.NeedsCache(), app.NeedsStorage(),
+		app.NeedsDynamoDB(), app.NeedsQueue(), app.NeedsStreaming(),
+	} {
+		if needed {
+			count++
+		}
+	}
+	return count
+}
+
+// RequestedStorage sums the PersistentVolumeClaims the Application would get:
+// the local PostgreSQL, MinIO and Kafka volumes and, for StatefulSet
+// applications, one app volume per replica. Redis memory and AWS storage are
+// not volumes and are not counted; unparseable sizes are left to ValidateSpec.
+func (app *Application) RequestedStorage() resource.Quantity {
+	total := resource.Quantity{}
+	add := func(size string, count int32) {
+		if q, err := resource.ParseQuantity(size); err == nil {
+			for i := int32(0); i < count; i++ {
+				total.Add(q)
+			}
+		}
+	}
+
+	if app.NeedsDatabase() && app.IsLocalDatabase() {
+		add(app.GetDatabaseStorage(), 1)
+	}
+	if s3 := app.Spec.Infrastructure.S3; s3 != nil && app.IsLocalS3() && s3.LocalStorage != "" {
+		add(s3.LocalStorage, 1)
+	}
+	if app.NeedsStreaming() && app.IsLocalKafka() {
+		add(DefaultKafkaStorage, 1)
+	}
+	if app.GetKind() == WorkloadStatefulSet {
+		add(app.GetAppStorage(), app.GetReplicas())
+	}
+	return total
+}
+
+// ValidateLimits rejects specs that exceed the platform limits, naming the limit
+func (app *Application) ValidateLimits(limits InfrastructureLimits) error {
+	if limits.MaxComponents > 0 {
+		if n := app.RequestedComponents(); n > limits.MaxComponents {
+			return fmt.Errorf("requests %d infrastructure components, exceeding the limit of %d (max-components)", n, limits.MaxComponents)
+		}
+	}
+	if !limits.MaxStorage.IsZero() {
+		if total := app.RequestedStorage(); total.Cmp(limits.MaxStorage) > 0 {
+			return fmt.Errorf("requests %s of storage, exceeding the limit of %s (max-storage-per-app)", total.String(), limits.MaxStorage.String())
+		}
+	}
+	return nil
+}
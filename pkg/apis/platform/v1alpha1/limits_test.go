@@ -0,0 +1,29 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRequestedStorageCountsVolumes(t *testing.T) {
+	app := &Application{}
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.Infrastructure.PostgreSQL = &PostgreSQLSpec{Environment: EnvironmentLocal, LocalStorage: "5Gi"}
+	app.Spec.Infrastructure.Redis = &RedisSpec{Environment: EnvironmentLocal, Memory: "4Gi"}
+	app.Spec.Kind = WorkloadStatefulSet
+	app.Spec.Replicas = 3
+	app.Spec.AppStorage = "2Gi"
+
+	// 5Gi database + 3 x 2Gi app volumes; Redis memory is not a volume
+	if got, want := app.RequestedStorage(), resource.MustParse("11Gi"); got.Cmp(want) != 0 {
+		t.Errorf("RequestedStorage() = %s, want %s", got.String(), want.String())
+	}
+
+	if err := app.ValidateLimits(InfrastructureLimits{MaxStorage: resource.MustParse("11Gi")}); err != nil {
+		t.Errorf("spec at the limit rejected: %v", err)
+	}
+	if err := app.ValidateLimits(InfrastructureLimits{MaxStorage: resource.MustParse("10Gi")}); err == nil {
+		t.Error("spec over the storage limit accepted")
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/apis/platform/v1alpha1/maintenance.go
+// Maintenance windows for disruptive infrastructure changes
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is a recurring UTC time range in which disruptive
+// infrastructure changes (volume resizes, restarts) may be applied
+type MaintenanceWindow struct {
+	// Days limits the window to these weekdays (Mon, Tue, ... Sun); empty means every day
+	Days []string `json:"days,omitempty"`
+	// Start is the UTC time of day the window opens, as HH:MM
+	Start string `json:"start"`
+	// Duration is how long the window stays open, e.g. 2h (at most 24h)
+	Duration string `json:"duration"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Validate checks the window's start, duration and days
+func (w *MaintenanceWindow) Validate() error {
+	if _, err := time.Parse("15:04", w.Start); err != nil {
+		return fmt.Errorf("maintenanceWindow.start %q must be HH:MM", w.Start)
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return fmt.Errorf("maintenanceWindow.duration %q: %w", w.Duration, err)
+	}
+	if d <= 0 || d > 24*time.Hour {
+		return fmt.Errorf("maintenanceWindow.duration must be between 0 and 24h")
+	}
+	for _, day := range w.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("maintenanceWindow.days: unknown day %q (Mon, Tue, Wed, Thu, Fri, Sat or Sun)", day)
+		}
+	}
+	return nil
+}
+
+// allowsDay reports whether the window may open on the given weekday
+func (w *MaintenanceWindow) allowsDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// openingOn returns when the window opens on the UTC date of t
+func (w *MaintenanceWindow) openingOn(t time.Time) time.Time {
+	start, _ := time.Parse("15:04", w.Start)
+	return time.Date(t.Year(), t.Month(), t.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+}
+
+// IsOpen reports whether t falls inside the window. A window that runs past
+// midnight belongs to the day it opened on.
+func (w *MaintenanceWindow) IsOpen(t time.Time) bool {
+	t = t.UTC()
+	duration, _ := time.ParseDuration(w.Duration)
+	for _, day := range []time.Time{t, t.AddDate(0, 0, -1)} {
+		opens := w.openingOn(day)
+		if w.allowsDay(opens.Weekday()) && !t.Before(opens) && t.Before(opens.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns the next time at or after t that the window is open
+func (w *MaintenanceWindow) NextOpen(t time.Time) time.Time {
+	if w.IsOpen(t) {
+		return t
+	}
+	t = t.UTC()
+	for i := 0; i <= 7; i++ {
+		opens := w.openingOn(t.AddDate(0, 0, i))
+		if opens.After(t) && w.allowsDay(opens.Weekday()) {
+			return opens
+		}
+	}
+	return t
+}
+
+// InMaintenanceWindow reports whether disruptive infrastructure changes may be
+// applied at t; without a window they always may
+func (app *Application) InMaintenanceWindow(t time.Time) bool {
+	w := app.Spec.Infrastructure.MaintenanceWindow
+	return w == nil || w.IsOpen(t)
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowIsOpen(t *testing.T) {
+	// 2024-01-01 was a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		window MaintenanceWindow
+		at     time.Time
+		want   bool
+	}{
+		{"inside", MaintenanceWindow{Start: "02:00", Duration: "2h"}, monday(3, 0), true},
+		{"at opening", MaintenanceWindow{Start: "02:00", Duration: "2h"}, monday(2, 0), true},
+		{"at closing", MaintenanceWindow{Start: "02:00", Duration: "2h"}, monday(4, 0), false},
+		{"before", MaintenanceWindow{Start: "02:00", Duration: "2h"}, monday(1, 59), false},
+		{"past midnight", MaintenanceWindow{Start: "23:00", Duration: "2h"}, monday(0, 30), true},
+		{"allowed day", MaintenanceWindow{Days: []string{"Mon"}, Start: "02:00", Duration: "2h"}, monday(3, 0), true},
+		{"other day", MaintenanceWindow{Days: []string{"Sat", "Sun"}, Start: "02:00", Duration: "2h"}, monday(3, 0), false},
+		{"past midnight from an allowed day", MaintenanceWindow{Days: []string{"Sun"}, Start: "23:00", Duration: "2h"}, monday(0, 30), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.IsOpen(tt.at); got != tt.want {
+				t.Errorf("IsOpen(%s) = %t, want %t", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowNextOpen(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := &MaintenanceWindow{Days: []string{"Wed"}, Start: "02:00", Duration: "1h"}
+	if got, want := w.NextOpen(now), time.Date(2024, 1, 3, 2, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextOpen = %s, want %s", got, want)
+	}
+	inside := time.Date(2024, 1, 3, 2, 30, 0, 0, time.UTC)
+	if got := w.NextOpen(inside); !got.Equal(inside) {
+		t.Errorf("NextOpen inside the window = %s, want %s", got, inside)
+	}
+}
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	for _, w := range []MaintenanceWindow{
+		{Start: "2am", Duration: "1h"},
+		{Start: "02:00", Duration: "25h"},
+		{Start: "02:00", Duration: "1h", Days: []string{"Funday"}},
+	} {
+		if err := w.Validate(); err == nil {
+			t.Errorf("window %+v accepted", w)
+		}
+	}
+	if err := (&MaintenanceWindow{Start: "02:00", Duration: "1h", Days: []string{"mon"}}).Validate(); err != nil {
+		t.Errorf("valid window rejected: %v", err)
+	}
+}
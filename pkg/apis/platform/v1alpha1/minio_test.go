@@ -0,0 +1,51 @@
+package v1alpha1
+
+import "testing"
+
+func TestGetMinIOImage(t *testing.T) {
+	tests := []struct {
+		name string
+		s3   *S3Spec
+		want string
+	}{
+		{"no S3", nil, "minio/minio:" + DefaultMinIOVersion},
+		{"default pin", &S3Spec{}, "minio/minio:" + DefaultMinIOVersion},
+		{"custom release", &S3Spec{Version: "RELEASE.2023-12-02T10-51-33Z"}, "minio/minio:RELEASE.2023-12-02T10-51-33Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Infrastructure: InfrastructureSpec{S3: tt.s3}}}
+			if got := app.GetMinIOImage(); got != tt.want {
+				t.Errorf("GetMinIOImage() = %q, want %q", got, tt.want)
+			}
+			// Bucket setup always uses the pinned client
+			if got := app.GetMinIOClientImage(); got != "minio/mc:"+DefaultMinIOClientVersion {
+				t.Errorf("GetMinIOClientImage() = %q, want the pinned mc release", got)
+			}
+		})
+	}
+}
+
+func TestValidateMinIOVersion(t *testing.T) {
+	for _, version := range []string{DefaultMinIOVersion, DefaultMinIOClientVersion} {
+		if !minioReleasePattern.MatchString(version) {
+			t.Errorf("default %q does not match the release pattern", version)
+		}
+	}
+	for _, tt := range []struct {
+		version string
+		valid   bool
+	}{
+		{"RELEASE.2023-12-02T10-51-33Z", true},
+		{"latest", false},
+		{"RELEASE.2023-12-02", false},
+	} {
+		app := &Application{Spec: ApplicationSpec{
+			Image:          "nginx:1.25",
+			Infrastructure: InfrastructureSpec{S3: &S3Spec{Environment: EnvironmentLocal, Version: tt.version}},
+		}}
+		if err := app.ValidateSpec(); (err == nil) != tt.valid {
+			t.Errorf("ValidateSpec() with version %q = %v, want valid %t", tt.version, err, tt.valid)
+		}
+	}
+}
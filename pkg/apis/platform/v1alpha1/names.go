@@ -0,0 +1,187 @@
+// pkg/apis/platform/v1alpha1/names.go
+// Names of the Kubernetes objects created for an Application
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxResourceNameLength is the DNS label limit that Service names, and the
+// label values carrying resource names, must stay within
+const MaxResourceNameLength = 63
+
+// MaxStatefulSetNameLength leaves room for the controller-revision-hash label
+// ("<name>-" plus a 10 character hash) that StatefulSet pods carry
+const MaxStatefulSetNameLength = 52
+
+// nameHashLength is how many hex characters of the name hash are kept when a
+// name has to be shortened
+const nameHashLength = 8
+
+// ChildName returns "<app>-<suffix>". Names that would exceed
+// MaxResourceNameLength keep as much of the Application name as fits plus a
+// short hash of the full name, so they stay unique and deterministic.
+func (app *Application) ChildName(suffix string) string {
+	return app.childName(suffix, MaxResourceNameLength)
+}
+
+// childName is ChildName with an explicit length limit
+func (app *Application) childName(suffix string, limit int) string {
+	name := app.Name + "-" + suffix
+	if len(name) <= limit {
+		return name
+	}
+	sum := sha256.Sum256([]byte(app.Name))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	keep := limit - len(suffix) - len(hash) - 2
+	if keep < 1 {
+		keep = 1
+	}
+	// A cut at a dash would leave "--" in the name
+	prefix := strings.TrimRight(app.Name[:min(keep, len(app.Name))], "-.")
+	return prefix + "-" + hash + "-" + suffix
+}
+
+// ValidateName checks that the Application name can be used for the objects
+// named after it: its own Service, the "app" label and, for StatefulSet
+// applications, the StatefulSet. Infrastructure names are shortened as needed.
+func (app *Application) ValidateName() error {
+	if app.Name == "" {
+		return nil
+	}
+	limit := MaxResourceNameLength
+	if app.GetKind() == WorkloadStatefulSet {
+		limit = MaxStatefulSetNameLength
+	}
+	if len(app.Name) > limit {
+		return fmt.Errorf("name %q is %d characters; %s Applications are limited to %d because the name is used for their Service, pods and labels",
+			app.Name, len(app.Name), app.GetKind(), limit)
+	}
+	if errs := validation.IsDNS1035Label(app.Name); len(errs) > 0 {
+		return fmt.Errorf("name %q cannot be used as a Service name: %s", app.Name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// GetPostgresName is the local PostgreSQL StatefulSet and Service
+func (app *Application) GetPostgresName() string {
+	return app.childName("postgres", MaxStatefulSetNameLength)
+}
+
+// GetPostgresReadName is the local PostgreSQL read Service
+func (app *Application) GetPostgresReadName() string {
+	return app.ChildName("postgres-read")
+}
+
+// GetPostgresClaimName is the default local PostgreSQL data volume claim
+func (app *Application) GetPostgresClaimName() string {
+	return app.ChildName("postgres-pvc")
+}
+
+// GetPostgresInitName is the ConfigMap holding the first-boot SQL
+func (app *Application) GetPostgresInitName() string {
+	return app.ChildName("postgres-init")
+}
+
+// GetPostgresConfigName is the ConfigMap that held the tuned postgresql.conf
+// before parameters were passed as server arguments
+func (app *Application) GetPostgresConfigName() string {
+	return app.ChildName("postgres-config")
+}
+
+// GetPgBouncerName is the PgBouncer Deployment and Service
+func (app *Application) GetPgBouncerName() string {
+	return app.ChildName("pgbouncer")
+}
+
+// GetRedisName is the local Redis Deployment and Service
+func (app *Application) GetRedisName() string {
+	return app.ChildName("redis")
+}
+
+// GetS3Name is the local MinIO Deployment and Service
+func (app *Application) GetS3Name() string {
+	return app.ChildName("s3")
+}
+
+// GetS3ClaimName is the local MinIO data volume claim
+func (app *Application) GetS3ClaimName() string {
+	return app.ChildName("s3-pvc")
+}
+
+// GetS3BucketJobName is the Job creating the local bucket
+func (app *Application) GetS3BucketJobName() string {
+	return app.ChildName("s3-bucket")
+}
+
+// GetDynamoDBName is the local DynamoDB Deployment and Service
+func (app *Application) GetDynamoDBName() string {
+	return app.ChildName("dynamodb")
+}
+
+// GetDynamoDBTableJobName is the Job creating the local table
+func (app *Application) GetDynamoDBTableJobName() string {
+	return app.ChildName("dynamodb-table")
+}
+
+// GetSQSName is the local SQS (ElasticMQ) Deployment and Service
+func (app *Application) GetSQSName() string {
+	return app.ChildName("sqs")
+}
+
+// GetSQSQueueJobName is the Job creating the local queue
+func (app *Application) GetSQSQueueJobName() string {
+	return app.ChildName("sqs-queue")
+}
+
+// GetKafkaName is the local Kafka StatefulSet and Service
+func (app *Application) GetKafkaName() string {
+	return app.childName("kafka", MaxStatefulSetNameLength)
+}
+
+// GetKafkaTopicsJobName is the Job creating the Kafka topics
+func (app *Application) GetKafkaTopicsJobName() string {
+	return app.ChildName("kafka-topics")
+}
+
+// GetHeadlessServiceName is the headless Service of StatefulSet applications
+func (app *Application) GetHeadlessServiceName() string {
+	return app.ChildName("headless")
+}
+
+// GetCanaryName is the canary Deployment
+func (app *Application) GetCanaryName() string {
+	return app.ChildName("canary")
+}
+
+// GetConnectionSecretName is the Secret holding the connection details
+func (app *Application) GetConnectionSecretName() string {
+	return app.ChildName("connections")
+}
+
+// GetStatusConfigMapName is the ConfigMap mirroring the status
+func (app *Application) GetStatusConfigMapName() string {
+	return app.ChildName("status")
+}
+
+// GetTLSProxyName is the ConfigMap holding the TLS sidecar's nginx config
+func (app *Application) GetTLSProxyName() string {
+	return app.ChildName("tls-proxy")
+}
+
+// GetQuotaName is the ResourceQuota in the target namespace
+func (app *Application) GetQuotaName() string {
+	return app.ChildName("quota")
+}
+
+// GetLimitRangeName is the LimitRange giving containers default resources
+// under a compute quota
+func (app *Application) GetLimitRangeName() string {
+	return app.ChildName("limits")
+}
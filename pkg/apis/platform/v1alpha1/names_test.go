@@ -0,0 +1,124 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChildNames(t *testing.T) {
+	app := &Application{ObjectMeta: metav1.ObjectMeta{Name: "shop"}}
+	for _, tt := range []struct{ got, want string }{
+		{app.GetPostgresName(), "shop-postgres"},
+		{app.GetPostgresClaimName(), "shop-postgres-pvc"},
+		{app.GetRedisName(), "shop-redis"},
+		{app.GetS3Name(), "shop-s3"},
+		{app.GetKafkaName(), "shop-kafka"},
+		{app.GetConnectionSecretName(), "shop-connections"},
+		{app.ChildName("custom-suffix"), "shop-custom-suffix"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("name = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestChildNameOverlong(t *testing.T) {
+	long := strings.Repeat("a", 40) + "-" + strings.Repeat("b", 22)
+	app := &Application{ObjectMeta: metav1.ObjectMeta{Name: long}}
+	other := &Application{ObjectMeta: metav1.ObjectMeta{Name: long[:len(long)-1] + "c"}}
+
+	name := app.ChildName("postgres-pvc")
+	if len(name) > MaxResourceNameLength {
+		t.Errorf("%q is %d characters, want at most %d", name, len(name), MaxResourceNameLength)
+	}
+	if !strings.HasSuffix(name, "-postgres-pvc") || !strings.HasPrefix(name, "aaaa") {
+		t.Errorf("%q does not keep the name prefix and the suffix", name)
+	}
+	if strings.Contains(name, "--") {
+		t.Errorf("%q contains an empty segment", name)
+	}
+	if again := app.ChildName("postgres-pvc"); again != name {
+		t.Errorf("ChildName is not deterministic: %q then %q", name, again)
+	}
+	if clash := other.ChildName("postgres-pvc"); clash == name {
+		t.Errorf("names differing only past the cut both map to %q", name)
+	}
+	if sts := app.GetPostgresName(); len(sts) > MaxStatefulSetNameLength {
+		t.Errorf("StatefulSet name %q is %d characters, want at most %d", sts, len(sts), MaxStatefulSetNameLength)
+	}
+}
+
+func TestGeneratedNamesWithinLimits(t *testing.T) {
+	app := &Application{ObjectMeta: metav1.ObjectMeta{Name: "orders-" + strings.Repeat("x", 53)}}
+	if len(app.Name) != 60 {
+		t.Fatalf("test name is %d characters, want 60", len(app.Name))
+	}
+	if err := app.ValidateName(); err != nil {
+		t.Fatalf("ValidateName: %v", err)
+	}
+
+	names := map[string]string{
+		"postgres-read":   app.GetPostgresReadName(),
+		"postgres-pvc":    app.GetPostgresClaimName(),
+		"postgres-init":   app.GetPostgresInitName(),
+		"postgres-config": app.GetPostgresConfigName(),
+		"pgbouncer":       app.GetPgBouncerName(),
+		"redis":           app.GetRedisName(),
+		"s3":              app.GetS3Name(),
+		"s3-pvc":          app.GetS3ClaimName(),
+		"s3-bucket":       app.GetS3BucketJobName(),
+		"dynamodb":        app.GetDynamoDBName(),
+		"dynamodb-table":  app.GetDynamoDBTableJobName(),
+		"sqs":             app.GetSQSName(),
+		"sqs-queue":       app.GetSQSQueueJobName(),
+		"kafka-topics":    app.GetKafkaTopicsJobName(),
+		"headless":        app.GetHeadlessServiceName(),
+		"canary":          app.GetCanaryName(),
+		"connections":     app.GetConnectionSecretName(),
+		"status":          app.GetStatusConfigMapName(),
+		"tls-proxy":       app.GetTLSProxyName(),
+		"quota":           app.GetQuotaName(),
+		"limits":          app.GetLimitRangeName(),
+	}
+	seen := map[string]bool{}
+	for suffix, name := range names {
+		if len(name) > MaxResourceNameLength {
+			t.Errorf("%s name %q is %d characters, want at most %d", suffix, name, len(name), MaxResourceNameLength)
+		}
+		if seen[name] {
+			t.Errorf("%s name %q is used twice", suffix, name)
+		}
+		seen[name] = true
+	}
+	for suffix, name := range map[string]string{"postgres": app.GetPostgresName(), "kafka": app.GetKafkaName()} {
+		if len(name) > MaxStatefulSetNameLength {
+			t.Errorf("%s StatefulSet name %q is %d characters, want at most %d", suffix, name, len(name), MaxStatefulSetNameLength)
+		}
+	}
+}
+
+func TestValidateNameLength(t *testing.T) {
+	sixty := "orders-" + strings.Repeat("x", 53)
+	tests := []struct {
+		name    string
+		appName string
+		kind    WorkloadKind
+		wantErr bool
+	}{
+		{"deployment at 60", sixty, "", false},
+		{"deployment over 63", sixty + "xxxx", "", true},
+		{"statefulset at 60", sixty, WorkloadStatefulSet, true},
+		{"statefulset at 52", sixty[:52], WorkloadStatefulSet, false},
+		{"not a DNS label", "Orders_1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{ObjectMeta: metav1.ObjectMeta{Name: tt.appName}, Spec: ApplicationSpec{Kind: tt.kind}}
+			if err := app.ValidateName(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateName() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// pkg/apis/platform/v1alpha1/postgres_params.go
+// PostgreSQL server settings users may tune
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AllowedPostgresParameters are the postgresql.conf settings that can be set
+// through postgresql.parameters. Settings that move files, change the listen
+// address or authentication, or could keep the server from starting are left
+// out.
+var AllowedPostgresParameters = map[string]bool{
+	"autovacuum":                          true,
+	"checkpoint_completion_target":        true,
+	"default_statistics_target":           true,
+	"effective_cache_size":                true,
+	"effective_io_concurrency":            true,
+	"idle_in_transaction_session_timeout": true,
+	"lock_timeout":                        true,
+	"log_min_duration_statement":          true,
+	"log_statement":                       true,
+	"maintenance_work_mem":                true,
+	"max_connections":                     true,
+	"max_parallel_maintenance_workers":    true,
+	"max_parallel_workers":                true,
+	"max_parallel_workers_per_gather":     true,
+	"max_wal_size":                        true,
+	"max_worker_processes":                true,
+	"min_wal_size":                        true,
+	"random_page_cost":                    true,
+	"shared_buffers":                      true,
+	"statement_timeout":                   true,
+	"timezone":                            true,
+	"wal_buffers":                         true,
+	"work_mem":                            true,
+}
+
+// validatePostgresParameters rejects settings outside the allowlist and values
+// that are empty or span lines
+func validatePostgresParameters(params map[string]string) error {
+	var disallowed []string
+	for key, value := range params {
+		if !AllowedPostgresParameters[key] {
+			disallowed = append(disallowed, key)
+			continue
+		}
+		if value == "" || strings.ContainsAny(value, "\n\r") {
+			return fmt.Errorf("postgresql.parameters.%s must be a single-line, non-empty value", key)
+		}
+	}
+	if len(disallowed) > 0 {
+		sort.Strings(disallowed)
+		return fmt.Errorf("postgresql.parameters does not allow %s", strings.Join(disallowed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// pkg/apis/platform/v1alpha1/schedule.go
+// Cron expression validation for scheduled workloads
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the allowed values for one position of a cron expression
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "day of week", min: 0, max: 6, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+var cronDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// ValidateCronSchedule checks a standard five-field cron expression (or a
+// descriptor such as @daily) the same way the CronJob controller parses it
+func ValidateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "@") {
+		if cronDescriptors[schedule] {
+			return nil
+		}
+		if every := strings.TrimPrefix(schedule, "@every "); every != schedule {
+			if d, err := time.ParseDuration(every); err != nil || d <= 0 {
+				return fmt.Errorf("invalid @every duration %q", every)
+			}
+			return nil
+		}
+		return fmt.Errorf("unknown descriptor %q", schedule)
+	}
+
+	parts := strings.Fields(schedule)
+	if len(parts) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, found %d", len(cronFields), len(parts))
+	}
+	for i, part := range parts {
+		if err := cronFields[i].validate(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f cronField) validate(expr string) error {
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, step := item, ""
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			rangeExpr, step = item[:idx], item[idx+1:]
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return fmt.Errorf("%s: invalid step %q", f.name, step)
+			}
+		}
+
+		if rangeExpr == "*" || (rangeExpr == "?" && (f.name == "day of month" || f.name == "day of week")) {
+			continue
+		}
+
+		bounds := strings.SplitN(rangeExpr, "-", 2)
+		low, err := f.value(bounds[0])
+		if err != nil {
+			return err
+		}
+		if len(bounds) == 2 {
+			high, err := f.value(bounds[1])
+			if err != nil {
+				return err
+			}
+			if low > high {
+				return fmt.Errorf("%s: range %q is backwards", f.name, rangeExpr)
+			}
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", f.name, s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s: %d out of range %d-%d", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
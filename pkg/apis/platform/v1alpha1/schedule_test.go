@@ -0,0 +1,54 @@
+package v1alpha1
+
+import "testing"
+
+func TestValidateCronSchedule(t *testing.T) {
+	tests := []struct {
+		schedule string
+		wantErr  bool
+	}{
+		{"*/15 * * * *", false},
+		{"0 2 * * 1-5", false},
+		{"30 6 1,15 * *", false},
+		{"0 0 * JAN,JUL SUN", false},
+		{"0 9 ? * mon-fri", false},
+		{"@daily", false},
+		{"@every 90m", false},
+		{"0 0 * * 6", false},
+		{"0 0 * * 7", true},
+		{"60 * * * *", true},
+		{"0 24 * * *", true},
+		{"0 0 0 * *", true},
+		{"0 0 * 13 *", true},
+		{"0 0 * * FUN", true},
+		{"0 5-2 * * *", true},
+		{"*/0 * * * *", true},
+		{"* * * *", true},
+		{"* * * * * *", true},
+		{"@fortnightly", true},
+		{"@every soon", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			err := ValidateCronSchedule(tt.schedule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCronSchedule(%q) = %v, want error %t", tt.schedule, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSpecCronJob(t *testing.T) {
+	app := &Application{Spec: ApplicationSpec{Image: "example/report:1.0", Kind: WorkloadCronJob}}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("CronJob without a schedule accepted")
+	}
+	app.Spec.Schedule = "0 0 * * 7"
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("day of week 7 accepted, which the CronJob controller rejects")
+	}
+	app.Spec.Schedule = "0 0 * * 0"
+	if err := app.ValidateSpec(); err != nil {
+		t.Errorf("ValidateSpec: %v", err)
+	}
+}
@@ -5,29 +5,385 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Environment types
 type Environment string
 
 const (
-	EnvironmentLocal Environment = "local"
-	EnvironmentAWS   Environment = "aws"
-	EnvironmentAuto  Environment = "auto"
+	EnvironmentLocal    Environment = "local"
+	EnvironmentAWS      Environment = "aws"
+	EnvironmentAuto     Environment = "auto"
+	// EnvironmentExternal marks a component managed outside the platform
+	EnvironmentExternal Environment = "external"
 )
 
+// WorkloadKind selects the Kubernetes workload created for the application
+type WorkloadKind string
+
+const (
+	WorkloadDeployment  WorkloadKind = "Deployment"
+	WorkloadCronJob     WorkloadKind = "CronJob"
+	WorkloadJob         WorkloadKind = "Job"
+	WorkloadStatefulSet WorkloadKind = "StatefulSet"
+)
+
+// DeploymentStrategy selects how a Deployment application rolls out new versions
+type DeploymentStrategy string
+
+const (
+	StrategyRollingUpdate DeploymentStrategy = "RollingUpdate"
+	StrategyBlueGreen     DeploymentStrategy = "BlueGreen"
+	StrategyCanary        DeploymentStrategy = "Canary"
+)
+
+// DefaultCanaryWeight is the share of replicas a new version starts with
+const DefaultCanaryWeight int32 = 10
+
+// MinCanaryReplicas is the fewest replicas a canary rollout can split: with one
+// the canary and the stable track would each take half the traffic
+const MinCanaryReplicas int32 = 2
+
+// Blue/green colors; each has its own Deployment named <app>-<color>
+const (
+	ColorBlue  = "blue"
+	ColorGreen = "green"
+)
+
+// Default Kubernetes node port range
+const (
+	MinNodePort int32 = 30000
+	MaxNodePort int32 = 32767
+)
+
+// TLSProxyPort is where the TLS sidecar listens, on the pod and the Service
+const TLSProxyPort int32 = 443
+
+// DatabaseWaitContainer is the init container that holds the application until
+// its database accepts connections; user init containers cannot take the name
+const DatabaseWaitContainer = "wait-for-database"
+
+// DefaultAppStorage is the per-replica volume size for StatefulSet applications
+const DefaultAppStorage = "1Gi"
+
 // ApplicationSpec defines what the developer wants to deploy
 type ApplicationSpec struct {
 	Image    string            `json:"image"`
 	Port     int32             `json:"port,omitempty"`
 	Replicas int32             `json:"replicas,omitempty"`
 	Env      map[string]string `json:"env,omitempty"`
+	// DownwardEnv exposes pod fields (name, namespace, node, IP...) as env vars;
+	// the names cannot repeat Env or the generated connection variables
+	DownwardEnv []DownwardEnvVar `json:"downwardEnv,omitempty"`
+	// DependsOn names Applications in the same namespace that must be Ready
+	// before this one is provisioned
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// HealthCheckPath adds an HTTP readiness probe on a TCP application port;
+	// with UseImageProfile, recognized images get their well-known path when
+	// it is unset
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+	// UseImageProfile defaults the port and health check path of recognized
+	// images (nginx, grafana...) when the spec leaves them unset. Off by
+	// default, so existing Applications keep the ports they were deployed with.
+	UseImageProfile bool `json:"useImageProfile,omitempty"`
 	Infrastructure InfrastructureSpec `json:"infrastructure,omitempty"`
+
+	// ResolveDigest pins the image tag to its current digest at deploy time.
+	// Private registries are queried with the image pull secrets of the
+	// target namespace's default service account; when the lookup fails the
+	// tag is deployed unpinned and a DigestResolutionFailed event says so.
+	ResolveDigest bool `json:"resolveDigest,omitempty"`
+	// ImageRegistry pulls infrastructure and sidecar images through this mirror
+	// (host[:port][/path]) instead of their public registries; it overrides
+	// the controller's --image-registry
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	// MirrorImage also pulls the application image through ImageRegistry
+	MirrorImage bool `json:"mirrorImage,omitempty"`
+	// ImagePullPolicy is Always, IfNotPresent or Never; when unset it is inferred
+	// from the image tag the same way Kubernetes does
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Kind is the workload type to run the image as (Deployment by default)
+	Kind WorkloadKind `json:"kind,omitempty"`
+	// Schedule is the cron expression used when Kind is CronJob
+	Schedule string `json:"schedule,omitempty"`
+	// BackoffLimit is the number of retries before a Job is marked failed
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// RevisionHistoryLimit is how many old ReplicaSets a Deployment keeps for
+	// rollback (DefaultRevisionHistoryLimit when unset)
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// AppStorage is the per-replica volume size (mounted at /data) when Kind is StatefulSet
+	AppStorage string `json:"appStorage,omitempty"`
+	// TopologySpread spreads app pods across topology domains such as zones
+	TopologySpread []TopologySpreadConstraint `json:"topologySpread,omitempty"`
+	// InitContainers run in order before the application container starts,
+	// ahead of the platform's wait-for-database container
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// SecretVolumes mounts Secrets as read-only files in the application container
+	SecretVolumes []SecretVolumeMount `json:"secretVolumes,omitempty"`
+	// PriorityClassName gives the application pods scheduling priority
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// DNSPolicy is ClusterFirst, ClusterFirstWithHostNet, Default or None
+	DNSPolicy string `json:"dnsPolicy,omitempty"`
+	// DNSConfig adds resolver settings; required when DNSPolicy is None
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// HeadlessService also creates <name>-headless (ClusterIP: None) for direct
+	// pod addressing; StatefulSets always get one
+	HeadlessService bool `json:"headlessService,omitempty"`
+	// TerminationGracePeriodSeconds overrides the pod's shutdown grace period
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// AutomountServiceAccountToken controls the service account token mount;
+	// unset follows the controller default (off with --secure-defaults)
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+	// RolloutOnConfigChange rolls the pods when a Secret or ConfigMap they
+	// reference changes (default true). When false the referenced data is
+	// still updated, and running pods must pick it up themselves, e.g. from
+	// mounted files; changes to the pod spec itself always roll the pods.
+	RolloutOnConfigChange *bool `json:"rolloutOnConfigChange,omitempty"`
+	// PreStop runs in the application container before it is sent SIGTERM
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+	// PostStart runs in the application container right after it starts;
+	// exactly one of exec or httpGet must be set
+	PostStart *corev1.LifecycleHandler `json:"postStart,omitempty"`
+	// Strategy is RollingUpdate (default), BlueGreen or Canary; only applies to Deployments
+	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+	// BlueGreen tunes the BlueGreen strategy
+	BlueGreen *BlueGreenSpec `json:"blueGreen,omitempty"`
+	// CanaryWeight is the percentage of replicas (0-100) a new version starts
+	// on under the Canary strategy
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+	// CanaryStep advances the weight by this many points on each reconcile
+	// where the canary is healthy; 0 leaves advancing to the canary-weight annotation
+	CanaryStep int32 `json:"canaryStep,omitempty"`
+	// SessionAffinity is None (default) or ClientIP for sticky sessions on the Service
+	SessionAffinity string `json:"sessionAffinity,omitempty"`
+	// SessionAffinityTimeoutSeconds bounds ClientIP stickiness (Kubernetes default 10800)
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+	// Metrics marks the application for Prometheus scraping
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+	// ServiceType is ClusterIP (default), NodePort or LoadBalancer; the exposed
+	// types require an explicit port
+	ServiceType string `json:"serviceType,omitempty"`
+	// NodePort pins the node port of the application Service (30000-32767);
+	// left unset, Kubernetes allocates one
+	NodePort int32 `json:"nodePort,omitempty"`
+	// Protocol of the application port, TCP (default) or UDP
+	Protocol string `json:"protocol,omitempty"`
+	// AdditionalPorts are further ports the application listens on, exposed on
+	// the container and the Service; TCP and UDP may be mixed
+	AdditionalPorts []AppPort `json:"additionalPorts,omitempty"`
+	// TargetNamespace deploys the workload and its infrastructure into another
+	// namespace than the Application's own
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// ConnectionSecret writes the infrastructure connection details to an
+	// <app>-connections Secret loaded with envFrom instead of inline env vars
+	ConnectionSecret bool `json:"connectionSecret,omitempty"`
+	// InfrastructureOnly provisions the infrastructure and publishes its
+	// endpoints (status, status ConfigMap and connection Secret) for an
+	// application deployed by other tooling; no workload or Service is created
+	InfrastructureOnly bool `json:"infrastructureOnly,omitempty"`
+	// WorkingDir overrides the image's working directory
+	WorkingDir string `json:"workingDir,omitempty"`
+	// RunAsUser and RunAsGroup run the application container as this UID/GID
+	RunAsUser  *int64 `json:"runAsUser,omitempty"`
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// FSGroup owns the pod's volumes so the container user can write to them;
+	// it takes precedence over podSecurityContext.fsGroup
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+	// PodSecurityContext is applied to the application pods as is, apart
+	// from fsGroup
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Tolerations let the application pods schedule onto tainted nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Preemptible marks a stateless application that can run on spot nodes;
+	// its pods prefer and tolerate nodes carrying the controller's spot label
+	Preemptible bool `json:"preemptible,omitempty"`
+	// StatusConfigMap mirrors the phase and endpoints into an <app>-status
+	// ConfigMap for consumers that cannot read the Application
+	StatusConfigMap bool `json:"statusConfigMap,omitempty"`
+	// TLS terminates HTTPS in front of an application that only serves HTTP
+	TLS *AppTLSSpec `json:"tls,omitempty"`
+	// Quota is the Application's budget in its target namespace, applied as a
+	// ResourceQuota (e.g. requests.cpu, limits.memory, pods); requires
+	// targetNamespace. A quota on cpu or memory also adds a LimitRange giving
+	// containers without resources a default request or limit.
+	Quota corev1.ResourceList `json:"quota,omitempty"`
+	// ReadyWebhook is notified when the Application becomes Ready or Failed
+	ReadyWebhook *WebhookNotification `json:"readyWebhook,omitempty"`
+	// SmokeTest is an HTTP check against the Service that must pass before the
+	// Application is declared Ready
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+}
+
+// SmokeTestSpec is a GET request sent to the application Service once its
+// replicas are ready
+type SmokeTestSpec struct {
+	// Path is requested on the Service (default "/")
+	Path string `json:"path,omitempty"`
+	// ExpectedStatus is the HTTP status code that passes the test (default 200)
+	ExpectedStatus int32 `json:"expectedStatus,omitempty"`
+	// FailureThreshold is how many consecutive failures mark the Application
+	// Failed (default 3)
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// GetPath returns the requested path, defaulting to "/"
+func (s *SmokeTestSpec) GetPath() string {
+	if s.Path == "" {
+		return "/"
+	}
+	return s.Path
+}
+
+// GetExpectedStatus returns the passing status code, defaulting to 200
+func (s *SmokeTestSpec) GetExpectedStatus() int {
+	if s.ExpectedStatus == 0 {
+		return 200
+	}
+	return int(s.ExpectedStatus)
+}
+
+// GetFailureThreshold returns the failures tolerated, defaulting to 3
+func (s *SmokeTestSpec) GetFailureThreshold() int32 {
+	if s.FailureThreshold <= 0 {
+		return 3
+	}
+	return s.FailureThreshold
+}
+
+// WebhookNotification is an HTTP endpoint that receives a JSON POST on phase
+// transitions
+type WebhookNotification struct {
+	// URL is the http or https endpoint to POST to
+	URL string `json:"url"`
+	// Headers are added to the request; credentials belong in HeadersSecretName
+	Headers map[string]string `json:"headers,omitempty"`
+	// HeadersSecretName names a Secret in the Application's namespace whose
+	// keys and values are added as headers, e.g. an Authorization token
+	HeadersSecretName string `json:"headersSecretName,omitempty"`
+}
+
+// TLSMode selects where HTTPS is terminated for the application
+type TLSMode string
+
+const (
+	// TLSModeIngress creates an Ingress serving the certificate
+	TLSModeIngress TLSMode = "Ingress"
+	// TLSModeSidecar runs an nginx sidecar forwarding 443 to the application port
+	TLSModeSidecar TLSMode = "Sidecar"
+)
+
+// AppTLSSpec terminates HTTPS for the application Service
+type AppTLSSpec struct {
+	// Mode is Ingress (default) or Sidecar
+	Mode TLSMode `json:"mode,omitempty"`
+	// SecretName is a kubernetes.io/tls Secret in the target namespace holding
+	// tls.crt and tls.key
+	SecretName string `json:"secretName"`
+	// Host is the hostname the Ingress serves; required in Ingress mode
+	Host string `json:"host,omitempty"`
+	// IngressClassName selects the ingress controller; the cluster default when unset
+	IngressClassName string `json:"ingressClassName,omitempty"`
+}
+
+// BlueGreenSpec tunes blue/green rollouts
+type BlueGreenSpec struct {
+	// KeepPrevious leaves the previous color running after the Service switches,
+	// for an instant rollback; by default it is scaled to zero
+	KeepPrevious bool `json:"keepPrevious,omitempty"`
+}
+
+// MetricsSpec describes the application's Prometheus endpoint
+type MetricsSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Path defaults to /metrics
+	Path string `json:"path,omitempty"`
+	// Port defaults to the application port; a different port is added to the
+	// container and the Service
+	Port int32 `json:"port,omitempty"`
+}
+
+// DefaultMetricsPath is scraped when the metrics spec does not set a path
+const DefaultMetricsPath = "/metrics"
+
+// DownwardEnvVar sets Name from a pod field through the downward API
+type DownwardEnvVar struct {
+	Name string `json:"name"`
+	// FieldPath is e.g. metadata.name, metadata.namespace, spec.nodeName or status.podIP
+	FieldPath string `json:"fieldPath"`
+}
+
+// downwardFieldPaths are the pod fields Kubernetes allows in env fieldRefs
+var downwardFieldPaths = map[string]bool{
+	"metadata.name":           true,
+	"metadata.namespace":      true,
+	"metadata.uid":            true,
+	"spec.nodeName":           true,
+	"spec.serviceAccountName": true,
+	"status.hostIP":           true,
+	"status.hostIPs":          true,
+	"status.podIP":            true,
+	"status.podIPs":           true,
+}
+
+// downwardMetadataPattern matches a single label or annotation reference
+var downwardMetadataPattern = regexp.MustCompile(`^metadata\.(labels|annotations)\['[^']+'\]$`)
+
+// ValidDownwardFieldPath reports whether path can be used in an env fieldRef
+func ValidDownwardFieldPath(path string) bool {
+	return downwardFieldPaths[path] || downwardMetadataPattern.MatchString(path)
+}
+
+// AppPort is an extra named port of the application container
+type AppPort struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+	// Protocol is TCP (default) or UDP
+	Protocol string `json:"protocol,omitempty"`
+	// ServicePort is the port on the Service, defaulting to Port
+	ServicePort int32 `json:"servicePort,omitempty"`
+}
+
+// GetProtocol returns the port's protocol, defaulting to TCP
+func (p AppPort) GetProtocol() corev1.Protocol {
+	if p.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return corev1.Protocol(p.Protocol)
+}
+
+// GetServicePort returns the Service port, defaulting to the container port
+func (p AppPort) GetServicePort() int32 {
+	if p.ServicePort == 0 {
+		return p.Port
+	}
+	return p.ServicePort
+}
+
+// SecretVolumeMount mounts every key of a Secret as a file under MountPath
+type SecretVolumeMount struct {
+	SecretName string `json:"secretName"`
+	MountPath  string `json:"mountPath"`
+}
+
+// TopologySpreadConstraint maps to corev1.TopologySpreadConstraint; the label
+// selector is always the application's own pods
+type TopologySpreadConstraint struct {
+	MaxSkew           int32  `json:"maxSkew,omitempty"`
+	TopologyKey       string `json:"topologyKey"`
+	WhenUnsatisfiable string `json:"whenUnsatisfiable,omitempty"`
 }
 
 // InfrastructureSpec defines external AWS resources needed
@@ -36,6 +392,16 @@ type InfrastructureSpec struct {
 	PostgreSQL  *PostgreSQLSpec `json:"postgresql,omitempty"`
 	Redis       *RedisSpec      `json:"redis,omitempty"`
 	S3          *S3Spec         `json:"s3,omitempty"`
+	DynamoDB    *DynamoDBSpec   `json:"dynamodb,omitempty"`
+	SQS         *SQSSpec        `json:"sqs,omitempty"`
+	Kafka       *KafkaSpec      `json:"kafka,omitempty"`
+	// DevTools adds pgAdmin and Redis Commander for local PostgreSQL and Redis
+	DevTools bool `json:"devTools,omitempty"`
+	// MaintenanceWindow defers disruptive changes (volume resizes, restarts) of
+	// running infrastructure until the window opens
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Tolerations are added to every locally provisioned infrastructure pod
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 type PostgreSQLSpec struct {
@@ -45,6 +411,60 @@ type PostgreSQLSpec struct {
 	Storage      int32       `json:"storage,omitempty"`
 	DatabaseName string      `json:"databaseName,omitempty"`
 	LocalStorage string      `json:"localStorage,omitempty"`
+	// InitSQL runs once when the local database is first initialized
+	InitSQL string `json:"initSQL,omitempty"`
+	// InitSQLConfigMap names an existing ConfigMap whose *.sql/*.sh keys run on
+	// first initialization instead of InitSQL
+	InitSQLConfigMap string `json:"initSQLConfigMap,omitempty"`
+	// ReadReplicas adds read replicas behind a reader endpoint on AWS. Local
+	// databases have no replication; there it adds a read Service served by the
+	// primary, so read/write splitting runs unchanged
+	ReadReplicas int32 `json:"readReplicas,omitempty"`
+	// Replicas of the local database StatefulSet (default 1). Local pods have
+	// no streaming replication, so the StatefulSet runs a single primary and
+	// larger values are capped; StatefulSets scaled up before the cap are
+	// brought down one replica at a time and never below one.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Pooler runs PgBouncer in front of the local database; DATABASE_URL then
+	// points at the pooler
+	Pooler bool `json:"pooler,omitempty"`
+	// PoolSize is the PgBouncer server connections per database/user pair
+	PoolSize int32 `json:"poolSize,omitempty"`
+	// Parameters tune the local server (e.g. shared_buffers, work_mem); only
+	// AllowedPostgresParameters may be set. They are passed to the server as
+	// -c arguments and take effect when the database restarts.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// Exporter adds a postgres_exporter sidecar to the local database and
+	// exposes its metrics port on the database Service
+	Exporter bool `json:"exporter,omitempty"`
+	// AccessMode of the local data volume (default ReadWriteOnce)
+	AccessMode string `json:"accessMode,omitempty"`
+	// StorageClass of the local data volume (the cluster default when empty)
+	StorageClass string `json:"storageClass,omitempty"`
+	// UpdateStrategy of the local database StatefulSet: RollingUpdate (the
+	// default) or OnDelete, where template changes only reach a pod once it
+	// is deleted by hand
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+	// DeletionPolicy decides what happens to the local data volume when the
+	// Application is deleted: Retain (the default) keeps the claim, which a
+	// new Application with the same identity can adopt, while Delete removes it
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+	// External points at a user-managed database instead of provisioning one
+	External *ExternalDatabaseSpec `json:"external,omitempty"`
+	// Preemptible lets the local pods run on spot nodes; by default they avoid
+	// nodes carrying the controller's spot label
+	Preemptible bool `json:"preemptible,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// ExternalDatabaseSpec references an existing database. The credentials Secret
+// must contain "username" and "password" keys.
+type ExternalDatabaseSpec struct {
+	Endpoint              string `json:"endpoint"`
+	CredentialsSecretName string `json:"credentialsSecretName"`
+	// ReadEndpoint is an optional read replica (host:port) for read-only traffic
+	ReadEndpoint string `json:"readEndpoint,omitempty"`
 }
 
 type RedisSpec struct {
@@ -52,15 +472,171 @@ type RedisSpec struct {
 	Version     string      `json:"version,omitempty"`
 	NodeType    string      `json:"nodeType,omitempty"`
 	Memory      string      `json:"memory,omitempty"`
+	// Endpoint is the host:port of a user-managed Redis when Environment is external
+	Endpoint string `json:"endpoint,omitempty"`
+	// Databases names logical databases for separate purposes (e.g. cache,
+	// sessions). Each gets REDIS_URL_<NAME> pointing at its own index on the
+	// same instance, starting at 1; REDIS_URL keeps index 0.
+	Databases []string `json:"databases,omitempty"`
+	// Exporter adds a redis_exporter sidecar to the local Redis and exposes its
+	// metrics port on the Redis Service
+	Exporter bool `json:"exporter,omitempty"`
+	// Preemptible lets the local pods run on spot nodes; by default they avoid
+	// nodes carrying the controller's spot label
+	Preemptible bool `json:"preemptible,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
+const (
+	// PostgresExporterImage is the metrics sidecar of the local database
+	PostgresExporterImage = "quay.io/prometheuscommunity/postgres-exporter:v0.15.0"
+	// RedisExporterImage is the metrics sidecar of the local Redis
+	RedisExporterImage = "oliver006/redis_exporter:v1.58.0"
+	// PostgresExporterPort and RedisExporterPort are the exporters' default
+	// metrics ports
+	PostgresExporterPort int32 = 9187
+	RedisExporterPort    int32 = 9121
+)
+
 type S3Spec struct {
 	Environment  Environment `json:"environment,omitempty"`
 	BucketName   string      `json:"bucketName,omitempty"`
 	Versioning   bool        `json:"versioning,omitempty"`
 	LocalStorage string      `json:"localStorage,omitempty"`
+	// Version pins the local MinIO image tag (RELEASE.YYYY-MM-DDTHH-MM-SSZ)
+	Version string `json:"version,omitempty"`
+	// Endpoint is the URL of a user-managed S3-compatible service when Environment is external
+	Endpoint string `json:"endpoint,omitempty"`
+	// DeletionPolicy decides what happens to the bucket when the Application
+	// is deleted: Retain (the default) keeps it, and the local MinIO volume,
+	// while Delete removes them. External buckets are never touched.
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+	// Preemptible lets the local pods run on spot nodes; by default they avoid
+	// nodes carrying the controller's spot label
+	Preemptible bool `json:"preemptible,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// Deletion policies for infrastructure data when its Application is deleted
+const (
+	DeletionPolicyRetain = "Retain"
+	DeletionPolicyDelete = "Delete"
+)
+
+// DynamoDBSpec describes a DynamoDB table. Key attributes are strings.
+type DynamoDBSpec struct {
+	Environment Environment `json:"environment,omitempty"`
+	// TableName defaults to the Application name
+	TableName string `json:"tableName,omitempty"`
+	HashKey   string `json:"hashKey"`
+	RangeKey  string `json:"rangeKey,omitempty"`
+	// BillingMode is PAY_PER_REQUEST (default) or PROVISIONED
+	BillingMode string `json:"billingMode,omitempty"`
+	// ReadCapacityUnits and WriteCapacityUnits apply to PROVISIONED tables
+	ReadCapacityUnits  int64 `json:"readCapacityUnits,omitempty"`
+	WriteCapacityUnits int64 `json:"writeCapacityUnits,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+const (
+	DynamoDBBillingPayPerRequest = "PAY_PER_REQUEST"
+	DynamoDBBillingProvisioned   = "PROVISIONED"
+
+	// DefaultDynamoDBCapacityUnits is used for PROVISIONED tables without explicit capacity
+	DefaultDynamoDBCapacityUnits int64 = 5
+
+	// DynamoDBLocalImage runs the local DynamoDB fallback
+	DynamoDBLocalImage = "amazon/dynamodb-local:2.2.1"
+)
+
+// SQSSpec describes an SQS queue (ElasticMQ when running locally)
+type SQSSpec struct {
+	Environment Environment `json:"environment,omitempty"`
+	// QueueName defaults to the Application name
+	QueueName string `json:"queueName,omitempty"`
+	// VisibilityTimeoutSeconds hides received messages from other consumers (0-43200)
+	VisibilityTimeoutSeconds int32 `json:"visibilityTimeoutSeconds,omitempty"`
+	// MessageRetentionSeconds is how long unconsumed messages are kept (60-1209600)
+	MessageRetentionSeconds int32 `json:"messageRetentionSeconds,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+const (
+	// ElasticMQImage runs the local SQS fallback
+	ElasticMQImage = "softwaremill/elasticmq-native:1.5.7"
+	// LocalSQSAccountID is the account segment ElasticMQ uses in queue URLs
+	LocalSQSAccountID = "000000000000"
+)
+
+// KafkaSpec describes a Kafka cluster for streaming workloads. Locally this is a
+// single KRaft broker; there is no managed (MSK) provisioning.
+type KafkaSpec struct {
+	Environment Environment `json:"environment,omitempty"`
+	// Version is the apache/kafka image tag
+	Version string `json:"version,omitempty"`
+	// Partitions is used for every topic in Topics (default 1)
+	Partitions int32 `json:"partitions,omitempty"`
+	// Topics are created once the broker is up
+	Topics []string `json:"topics,omitempty"`
+	// Brokers is the bootstrap server list of a user-managed cluster when Environment is external
+	Brokers string `json:"brokers,omitempty"`
+	// AccessMode of the local broker's log volume (default ReadWriteOnce)
+	AccessMode string `json:"accessMode,omitempty"`
+	// Preemptible lets the local pods run on spot nodes; by default they avoid
+	// nodes carrying the controller's spot label
+	Preemptible bool `json:"preemptible,omitempty"`
+	// NodeSelector pins the local pods, merged over the controller default
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
+const (
+	// DefaultKafkaVersion is the apache/kafka release used for the local broker
+	DefaultKafkaVersion = "3.7.0"
+	// DefaultKafkaStorage is the local broker's log volume size
+	DefaultKafkaStorage = "1Gi"
+)
+
+var kafkaTopicPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,249}$`)
+
+var sqsQueueNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,80}$`)
+
+// s3BucketNamePattern follows the S3 bucket naming rules; consecutive dots and
+// IP-address names are rejected separately
+var s3BucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+var ipAddressPattern = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+
+// dynamoDBTableNamePattern follows the DynamoDB table naming rules
+var dynamoDBTableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,255}$`)
+
+// dynamoDBKeyPattern limits key attribute names to the table name characters;
+// DynamoDB accepts more, but these also read safely in the aws CLI shorthand
+var dynamoDBKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,255}$`)
+
+// redisDatabaseNamePattern keeps REDIS_URL_<NAME> a valid env var name
+var redisDatabaseNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// MaxRedisDatabases is how many named databases fit next to database 0 in
+// Redis's default of 16
+const MaxRedisDatabases = 15
+
+// DefaultDatabaseStorage is the local PostgreSQL volume size when none is requested
+const DefaultDatabaseStorage = "2Gi"
+
+const (
+	// DefaultMinIOVersion is the known-good MinIO server release used for local S3
+	DefaultMinIOVersion = "RELEASE.2024-01-16T16-07-38Z"
+	// DefaultMinIOClientVersion is the mc release used to create local buckets
+	DefaultMinIOClientVersion = "RELEASE.2024-01-13T08-44-48Z"
+)
+
+// minioReleasePattern matches MinIO's date-based release tags
+var minioReleasePattern = regexp.MustCompile(`^RELEASE\.\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}Z$`)
+
 // ApplicationStatus shows current state
 type ApplicationStatus struct {
 	Phase               ApplicationPhase `json:"phase,omitempty"`
@@ -75,6 +651,88 @@ type ApplicationStatus struct {
 	S3BucketName        string           `json:"s3BucketName,omitempty"`
 	S3Endpoint          string           `json:"s3Endpoint,omitempty"`
 	S3Environment       Environment      `json:"s3Environment,omitempty"`
+	DynamoDBTableName   string           `json:"dynamodbTableName,omitempty"`
+	DynamoDBEndpoint    string           `json:"dynamodbEndpoint,omitempty"`
+	DynamoDBEnvironment Environment      `json:"dynamodbEnvironment,omitempty"`
+	SQSQueueName        string           `json:"sqsQueueName,omitempty"`
+	SQSQueueURL         string           `json:"sqsQueueURL,omitempty"`
+	SQSEnvironment      Environment      `json:"sqsEnvironment,omitempty"`
+	KafkaBrokers        string           `json:"kafkaBrokers,omitempty"`
+	KafkaEnvironment    Environment      `json:"kafkaEnvironment,omitempty"`
+
+	// DatabaseReadEndpoint is set only when read replicas exist
+	DatabaseReadEndpoint string `json:"databaseReadEndpoint,omitempty"`
+	// DatabasePoolerEndpoint is the PgBouncer endpoint when the pooler is enabled
+	DatabasePoolerEndpoint string `json:"databasePoolerEndpoint,omitempty"`
+	// DatabaseReplicas is the replica count of the local database StatefulSet;
+	// it trails spec.infrastructure.postgresql.replicas while scaling down
+	DatabaseReplicas int32 `json:"databaseReplicas,omitempty"`
+
+	// ResolvedImage is the digest-pinned image deployed when resolveDigest is set;
+	// ResolvedImageSource is the spec image it was resolved from
+	ResolvedImage       string `json:"resolvedImage,omitempty"`
+	ResolvedImageSource string `json:"resolvedImageSource,omitempty"`
+
+	// ActiveColor is the blue/green color the Service routes to; PreviewColor is
+	// set while a new version rolls out on the other color
+	ActiveColor  string `json:"activeColor,omitempty"`
+	PreviewColor string `json:"previewColor,omitempty"`
+	// CanaryWeight is the current canary share in percent; 0 when no canary runs
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+	// DeferredChanges lists disruptive infrastructure changes waiting for the
+	// maintenance window
+	DeferredChanges []string `json:"deferredChanges,omitempty"`
+	// ExpansionError is why the last attempt to grow the application volumes
+	// failed; empty once they are at the requested size
+	ExpansionError string `json:"expansionError,omitempty"`
+	// SpecWarningGeneration is the spec generation the last SpecWarning event
+	// was recorded for, so periodic reconciles do not repeat it
+	SpecWarningGeneration int64 `json:"specWarningGeneration,omitempty"`
+	// Components records the provisioning state of each infrastructure component
+	Components []ComponentStatus `json:"components,omitempty"`
+	// CleanupAttempts counts failed attempts to clean up the target namespace
+	// after the Application was deleted
+	CleanupAttempts int32 `json:"cleanupAttempts,omitempty"`
+	// SmokeTestFailures counts consecutive failed smoke tests of the current
+	// rollout
+	SmokeTestFailures int32 `json:"smokeTestFailures,omitempty"`
+	// DeployRetry is set while Deploying when the deploy step stopped on a
+	// transient error and has to run again
+	DeployRetry bool `json:"deployRetry,omitempty"`
+	// PausedPhase is the phase the Application was in when it was paused; it
+	// resumes there
+	PausedPhase ApplicationPhase `json:"pausedPhase,omitempty"`
+	// AWSResources tracks AWS resources that were requested but are not yet
+	// available; an entry is dropped once its resource is available
+	AWSResources []AWSResourceStatus `json:"awsResources,omitempty"`
+
+	// ManagedResources lists the Kubernetes objects the controller created for
+	// this Application
+	ManagedResources []ManagedResourceRef `json:"managedResources,omitempty"`
+}
+
+// ComponentStatus is the provisioning state of one infrastructure component
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"`
+}
+
+// AWSResourceStatus is the progress of one AWS resource being created
+type AWSResourceStatus struct {
+	Component  string `json:"component"`
+	ResourceID string `json:"resourceID"`
+	// State is the last state AWS reported, e.g. creating or available
+	State        string      `json:"state,omitempty"`
+	StartedAt    metav1.Time `json:"startedAt"`
+	PollAttempts int32       `json:"pollAttempts,omitempty"`
+}
+
+// ManagedResourceRef identifies one object created by the controller
+type ManagedResourceRef struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type ApplicationPhase string
@@ -85,6 +743,9 @@ const (
 	PhaseDeploying         ApplicationPhase = "Deploying"
 	PhaseReady             ApplicationPhase = "Ready"
 	PhaseFailed            ApplicationPhase = "Failed"
+	PhasePaused            ApplicationPhase = "Paused"
+	// PhaseInfrastructureReady is the steady state of infrastructureOnly Applications
+	PhaseInfrastructureReady ApplicationPhase = "InfrastructureReady"
 )
 
 // +kubebuilder:object:root=true
@@ -187,6 +848,140 @@ func (spec *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 		}
 	}
 	spec.Infrastructure.DeepCopyInto(&out.Infrastructure)
+	if spec.TopologySpread != nil {
+		in, out := &spec.TopologySpread, &out.TopologySpread
+		*out = make([]TopologySpreadConstraint, len(*in))
+		copy(*out, *in)
+	}
+	if spec.BackoffLimit != nil {
+		in, out := &spec.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if spec.RevisionHistoryLimit != nil {
+		in, out := &spec.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if spec.TerminationGracePeriodSeconds != nil {
+		in, out := &spec.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if spec.RolloutOnConfigChange != nil {
+		in, out := &spec.RolloutOnConfigChange, &out.RolloutOnConfigChange
+		*out = new(bool)
+		**out = **in
+	}
+	if spec.AutomountServiceAccountToken != nil {
+		in, out := &spec.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if spec.RunAsUser != nil {
+		in, out := &spec.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if spec.RunAsGroup != nil {
+		in, out := &spec.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if spec.FSGroup != nil {
+		in, out := &spec.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if spec.PodSecurityContext != nil {
+		in, out := &spec.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if spec.PreStop != nil {
+		in, out := &spec.PreStop, &out.PreStop
+		*out = new(corev1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if spec.PostStart != nil {
+		in, out := &spec.PostStart, &out.PostStart
+		*out = new(corev1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if spec.SessionAffinityTimeoutSeconds != nil {
+		in, out := &spec.SessionAffinityTimeoutSeconds, &out.SessionAffinityTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if spec.BlueGreen != nil {
+		in, out := &spec.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenSpec)
+		**out = **in
+	}
+	if spec.Quota != nil {
+		out.Quota = spec.Quota.DeepCopy()
+	}
+	if spec.SmokeTest != nil {
+		in, out := &spec.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestSpec)
+		**out = **in
+	}
+	if spec.ReadyWebhook != nil {
+		in, out := &spec.ReadyWebhook, &out.ReadyWebhook
+		*out = new(WebhookNotification)
+		(*out).URL = (*in).URL
+		(*out).Headers = copyStringMap((*in).Headers)
+		(*out).HeadersSecretName = (*in).HeadersSecretName
+	}
+	if spec.TLS != nil {
+		in, out := &spec.TLS, &out.TLS
+		*out = new(AppTLSSpec)
+		**out = **in
+	}
+	if spec.Metrics != nil {
+		in, out := &spec.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		**out = **in
+	}
+	if spec.DNSConfig != nil {
+		in, out := &spec.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if spec.DependsOn != nil {
+		in, out := &spec.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if spec.DownwardEnv != nil {
+		in, out := &spec.DownwardEnv, &out.DownwardEnv
+		*out = make([]DownwardEnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if spec.SecretVolumes != nil {
+		in, out := &spec.SecretVolumes, &out.SecretVolumes
+		*out = make([]SecretVolumeMount, len(*in))
+		copy(*out, *in)
+	}
+	if spec.AdditionalPorts != nil {
+		in, out := &spec.AdditionalPorts, &out.AdditionalPorts
+		*out = make([]AppPort, len(*in))
+		copy(*out, *in)
+	}
+	if spec.InitContainers != nil {
+		in, out := &spec.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if spec.Tolerations != nil {
+		in, out := &spec.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopyInto for InfrastructureSpec
@@ -195,110 +990,1148 @@ func (infra *InfrastructureSpec) DeepCopyInto(out *InfrastructureSpec) {
 	if infra.PostgreSQL != nil {
 		in, out := &infra.PostgreSQL, &out.PostgreSQL
 		*out = new(PostgreSQLSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if infra.Redis != nil {
 		in, out := &infra.Redis, &out.Redis
 		*out = new(RedisSpec)
 		**out = **in
+		(*out).NodeSelector = copyStringMap((*in).NodeSelector)
+		if (*in).Databases != nil {
+			(*out).Databases = make([]string, len((*in).Databases))
+			copy((*out).Databases, (*in).Databases)
+		}
 	}
 	if infra.S3 != nil {
 		in, out := &infra.S3, &out.S3
 		*out = new(S3Spec)
 		**out = **in
+		(*out).NodeSelector = copyStringMap((*in).NodeSelector)
+	}
+	if infra.DynamoDB != nil {
+		in, out := &infra.DynamoDB, &out.DynamoDB
+		*out = new(DynamoDBSpec)
+		**out = **in
+		(*out).NodeSelector = copyStringMap((*in).NodeSelector)
+	}
+	if infra.SQS != nil {
+		in, out := &infra.SQS, &out.SQS
+		*out = new(SQSSpec)
+		**out = **in
+		(*out).NodeSelector = copyStringMap((*in).NodeSelector)
+	}
+	if infra.Kafka != nil {
+		in, out := &infra.Kafka, &out.Kafka
+		*out = new(KafkaSpec)
+		**out = **in
+		(*out).NodeSelector = copyStringMap((*in).NodeSelector)
+		if (*in).Topics != nil {
+			(*out).Topics = make([]string, len((*in).Topics))
+			copy((*out).Topics, (*in).Topics)
+		}
+	}
+	if infra.MaintenanceWindow != nil {
+		in, out := &infra.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+		if (*in).Days != nil {
+			(*out).Days = make([]string, len((*in).Days))
+			copy((*out).Days, (*in).Days)
+		}
+	}
+	if infra.Tolerations != nil {
+		in, out := &infra.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopyInto for PostgreSQLSpec
+func (pg *PostgreSQLSpec) DeepCopyInto(out *PostgreSQLSpec) {
+	*out = *pg
+	out.NodeSelector = copyStringMap(pg.NodeSelector)
+	out.Parameters = copyStringMap(pg.Parameters)
+	if pg.External != nil {
+		in, out := &pg.External, &out.External
+		*out = new(ExternalDatabaseSpec)
+		**out = **in
+	}
+	if pg.Replicas != nil {
+		in, out := &pg.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// copyStringMap returns a copy of m, preserving nil
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
 	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
 }
 
 // DeepCopyInto for ApplicationStatus
 func (status *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 	*out = *status
+	status.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	if status.ManagedResources != nil {
+		in, out := &status.ManagedResources, &out.ManagedResources
+		*out = make([]ManagedResourceRef, len(*in))
+		copy(*out, *in)
+	}
+	if status.DeferredChanges != nil {
+		in, out := &status.DeferredChanges, &out.DeferredChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if status.Components != nil {
+		in, out := &status.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if status.AWSResources != nil {
+		in, out := &status.AWSResources, &out.AWSResources
+		*out = make([]AWSResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// Business logic methods with Kubernetes-compatible time handling
-func (app *Application) UpdateStatus(phase ApplicationPhase, message string) {
-	app.Status.Phase = phase
-	app.Status.Message = message
-	app.Status.LastUpdated = metav1.NewTime(time.Now())
+// DeepCopyInto for AWSResourceStatus
+func (in *AWSResourceStatus) DeepCopyInto(out *AWSResourceStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
 }
 
-func (app *Application) IsReady() bool {
-	return app.Status.Phase == PhaseReady && app.Status.ReadyReplicas > 0
+// AWSResource returns the tracked AWS resource of a component, or nil
+func (status *ApplicationStatus) AWSResource(component string) *AWSResourceStatus {
+	for i := range status.AWSResources {
+		if status.AWSResources[i].Component == component {
+			return &status.AWSResources[i]
+		}
+	}
+	return nil
 }
 
-func (app *Application) NeedsDatabase() bool {
-	return app.Spec.Infrastructure.PostgreSQL != nil
+// RemoveAWSResource stops tracking a component's AWS resource
+func (status *ApplicationStatus) RemoveAWSResource(component string) {
+	for i, r := range status.AWSResources {
+		if r.Component == component {
+			status.AWSResources = append(status.AWSResources[:i], status.AWSResources[i+1:]...)
+			return
+		}
+	}
 }
 
-func (app *Application) NeedsCache() bool {
-	return app.Spec.Infrastructure.Redis != nil
+// SetComponentStatus records the state of an infrastructure component
+func (status *ApplicationStatus) SetComponentStatus(name string, ready bool, message string) {
+	for i := range status.Components {
+		if status.Components[i].Name == name {
+			status.Components[i].Ready = ready
+			status.Components[i].Message = message
+			return
+		}
+	}
+	status.Components = append(status.Components, ComponentStatus{Name: name, Ready: ready, Message: message})
 }
 
-func (app *Application) NeedsStorage() bool {
-	return app.Spec.Infrastructure.S3 != nil
+// ComponentReady reports whether a component was last recorded as ready
+func (status *ApplicationStatus) ComponentReady(name string) bool {
+	for _, c := range status.Components {
+		if c.Name == name {
+			return c.Ready
+		}
+	}
+	return false
 }
 
-func (app *Application) GetDatabaseEnvironment() Environment {
-	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.Environment != "" {
-		return app.Spec.Infrastructure.PostgreSQL.Environment
-	}
-	if app.Spec.Infrastructure.Environment != "" {
-		return app.Spec.Infrastructure.Environment
+// RemoveComponentStatus drops a component that is no longer in the spec
+func (status *ApplicationStatus) RemoveComponentStatus(name string) {
+	for i, c := range status.Components {
+		if c.Name == name {
+			status.Components = append(status.Components[:i], status.Components[i+1:]...)
+			return
+		}
 	}
-	return EnvironmentAuto
 }
 
-func (app *Application) GetRedisEnvironment() Environment {
-	if app.Spec.Infrastructure.Redis != nil && app.Spec.Infrastructure.Redis.Environment != "" {
-		return app.Spec.Infrastructure.Redis.Environment
-	}
-	if app.Spec.Infrastructure.Environment != "" {
-		return app.Spec.Infrastructure.Environment
+// AddManagedResource records ref in the inventory unless it is already listed
+func (status *ApplicationStatus) AddManagedResource(ref ManagedResourceRef) {
+	for _, existing := range status.ManagedResources {
+		if existing == ref {
+			return
+		}
 	}
-	return EnvironmentAuto
+	status.ManagedResources = append(status.ManagedResources, ref)
 }
 
-func (app *Application) GetS3Environment() Environment {
-	if app.Spec.Infrastructure.S3 != nil && app.Spec.Infrastructure.S3.Environment != "" {
-		return app.Spec.Infrastructure.S3.Environment
-	}
-	if app.Spec.Infrastructure.Environment != "" {
-		return app.Spec.Infrastructure.Environment
+// RemoveManagedResource drops ref from the inventory
+func (status *ApplicationStatus) RemoveManagedResource(ref ManagedResourceRef) {
+	kept := status.ManagedResources[:0]
+	for _, existing := range status.ManagedResources {
+		if existing != ref {
+			kept = append(kept, existing)
+		}
 	}
-	return EnvironmentAuto
+	status.ManagedResources = kept
 }
 
-func (app *Application) IsLocalDatabase() bool {
-	env := app.GetDatabaseEnvironment()
-	return env == EnvironmentLocal || (env == EnvironmentAuto && app.isLocalEnvironment())
+// Business logic methods with Kubernetes-compatible time handling
+func (app *Application) UpdateStatus(phase ApplicationPhase, message string) {
+	app.Status.Phase = phase
+	app.Status.Message = message
+	app.Status.LastUpdated = metav1.NewTime(time.Now())
 }
 
-func (app *Application) IsLocalRedis() bool {
-	env := app.GetRedisEnvironment()
-	return env == EnvironmentLocal || (env == EnvironmentAuto && app.isLocalEnvironment())
+// RollsOutOnConfigChange reports whether changed Secret or ConfigMap data
+// rolls the application pods
+func (app *Application) RollsOutOnConfigChange() bool {
+	return app.Spec.RolloutOnConfigChange == nil || *app.Spec.RolloutOnConfigChange
 }
 
-func (app *Application) IsLocalS3() bool {
-	env := app.GetS3Environment()
-	return env == EnvironmentLocal || (env == EnvironmentAuto && app.isLocalEnvironment())
+func (app *Application) IsReady() bool {
+	if app.Spec.InfrastructureOnly {
+		return app.Status.Phase == PhaseInfrastructureReady
+	}
+	switch app.GetKind() {
+	case WorkloadCronJob, WorkloadJob:
+		return app.Status.Phase == PhaseReady
+	}
+	return app.Status.Phase == PhaseReady && app.Status.ReadyReplicas > 0
 }
 
-func (app *Application) isLocalEnvironment() bool {
-	return true // For now, default to local
+// UsesPooler reports whether PgBouncer should run in front of the database
+func (app *Application) UsesPooler() bool {
+	return app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.Pooler
+}
+
+// GetPoolSize returns the PgBouncer pool size or the default
+func (app *Application) GetPoolSize() int32 {
+	if app.UsesPooler() && app.Spec.Infrastructure.PostgreSQL.PoolSize > 0 {
+		return app.Spec.Infrastructure.PostgreSQL.PoolSize
+	}
+	return DefaultPoolSize
+}
+
+// GetDatabaseStorage returns the requested local PostgreSQL volume size
+func (app *Application) GetDatabaseStorage() string {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.LocalStorage != "" {
+		return app.Spec.Infrastructure.PostgreSQL.LocalStorage
+	}
+	return DefaultDatabaseStorage
+}
+
+// GetDatabaseReplicas returns the requested replica count of the local database
+func (app *Application) GetDatabaseReplicas() int32 {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.Replicas != nil {
+		return *app.Spec.Infrastructure.PostgreSQL.Replicas
+	}
+	return 1
+}
+
+// GetDatabaseReadReplicas returns the requested read replicas of the database
+func (app *Application) GetDatabaseReadReplicas() int32 {
+	if app.Spec.Infrastructure.PostgreSQL != nil {
+		return app.Spec.Infrastructure.PostgreSQL.ReadReplicas
+	}
+	return 0
+}
+
+// MaxLocalDatabaseReplicas caps the local PostgreSQL StatefulSet: its pods
+// would share one data volume without replication
+const MaxLocalDatabaseReplicas int32 = 1
+
+// GetLocalDatabaseReplicas returns the replica count the local database
+// StatefulSet actually runs
+func (app *Application) GetLocalDatabaseReplicas() int32 {
+	if replicas := app.GetDatabaseReplicas(); replicas < MaxLocalDatabaseReplicas {
+		return replicas
+	}
+	return MaxLocalDatabaseReplicas
+}
+
+// GetDatabaseAccessMode returns the local PostgreSQL volume access mode
+func (app *Application) GetDatabaseAccessMode() corev1.PersistentVolumeAccessMode {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.AccessMode != "" {
+		return corev1.PersistentVolumeAccessMode(app.Spec.Infrastructure.PostgreSQL.AccessMode)
+	}
+	return corev1.ReadWriteOnce
+}
+
+// GetDatabaseUpdateStrategy returns the local PostgreSQL StatefulSet update strategy
+func (app *Application) GetDatabaseUpdateStrategy() appsv1.StatefulSetUpdateStrategyType {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.UpdateStrategy != "" {
+		return appsv1.StatefulSetUpdateStrategyType(app.Spec.Infrastructure.PostgreSQL.UpdateStrategy)
+	}
+	return appsv1.RollingUpdateStatefulSetStrategyType
+}
+
+// GetKafkaAccessMode returns the local Kafka log volume access mode
+func (app *Application) GetKafkaAccessMode() corev1.PersistentVolumeAccessMode {
+	if app.Spec.Infrastructure.Kafka != nil && app.Spec.Infrastructure.Kafka.AccessMode != "" {
+		return corev1.PersistentVolumeAccessMode(app.Spec.Infrastructure.Kafka.AccessMode)
+	}
+	return corev1.ReadWriteOnce
+}
+
+// validateTolerations checks operators and effects the way the API server would
+func validateTolerations(field string, tolerations []corev1.Toleration) error {
+	for i, t := range tolerations {
+		switch t.Operator {
+		case "", corev1.TolerationOpEqual:
+			if t.Key == "" {
+				return fmt.Errorf("%s[%d]: key is required unless operator is Exists", field, i)
+			}
+		case corev1.TolerationOpExists:
+			if t.Value != "" {
+				return fmt.Errorf("%s[%d]: value must be empty when operator is Exists", field, i)
+			}
+		default:
+			return fmt.Errorf("%s[%d]: unsupported operator %q (Equal or Exists)", field, i, t.Operator)
+		}
+		switch t.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("%s[%d]: unsupported effect %q (NoSchedule, PreferNoSchedule or NoExecute)", field, i, t.Effect)
+		}
+		if t.TolerationSeconds != nil && t.Effect != corev1.TaintEffectNoExecute {
+			return fmt.Errorf("%s[%d]: tolerationSeconds requires effect NoExecute", field, i)
+		}
+	}
+	return nil
+}
+
+// validateAccessMode accepts the writable PVC access modes; the data volumes
+// are written to, so ReadOnlyMany is rejected
+func validateAccessMode(field, mode string) error {
+	switch corev1.PersistentVolumeAccessMode(mode) {
+	case "", corev1.ReadWriteOnce, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+		return nil
+	}
+	return fmt.Errorf("unsupported %s %q (ReadWriteOnce, ReadWriteMany or ReadWriteOncePod)", field, mode)
+}
+
+// GetS3DeletionPolicy returns what happens to the bucket on deletion
+func (app *Application) GetS3DeletionPolicy() string {
+	if app.Spec.Infrastructure.S3 != nil && app.Spec.Infrastructure.S3.DeletionPolicy != "" {
+		return app.Spec.Infrastructure.S3.DeletionPolicy
+	}
+	return DeletionPolicyRetain
+}
+
+// GetDatabaseDeletionPolicy returns what happens to the database volume on deletion
+func (app *Application) GetDatabaseDeletionPolicy() string {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.DeletionPolicy != "" {
+		return app.Spec.Infrastructure.PostgreSQL.DeletionPolicy
+	}
+	return DeletionPolicyRetain
+}
+
+// validateDeletionPolicy accepts Retain, Delete or unset
+func validateDeletionPolicy(field, policy string) error {
+	switch policy {
+	case "", DeletionPolicyRetain, DeletionPolicyDelete:
+		return nil
+	}
+	return fmt.Errorf("unsupported %s %q (Retain or Delete)", field, policy)
+}
+
+// GetMinIOImage returns the pinned MinIO server image for local S3
+func (app *Application) GetMinIOImage() string {
+	version := DefaultMinIOVersion
+	if app.Spec.Infrastructure.S3 != nil && app.Spec.Infrastructure.S3.Version != "" {
+		version = app.Spec.Infrastructure.S3.Version
+	}
+	return fmt.Sprintf("minio/minio:%s", version)
+}
+
+// GetMinIOClientImage returns the pinned mc image used for bucket setup
+func (app *Application) GetMinIOClientImage() string {
+	return fmt.Sprintf("minio/mc:%s", DefaultMinIOClientVersion)
+}
+
+// GetAppStorage returns the per-replica volume size for StatefulSet applications
+func (app *Application) GetAppStorage() string {
+	if app.Spec.AppStorage == "" {
+		return DefaultAppStorage
+	}
+	return app.Spec.AppStorage
+}
+
+// GetDeployImage returns the image to run: the resolved digest when one was
+// recorded for the current spec image, otherwise the image as written, pulled
+// through the mirror when mirrorImage is set
+func (app *Application) GetDeployImage() string {
+	image := app.Spec.Image
+	if app.Spec.ResolveDigest && app.Status.ResolvedImage != "" && app.Status.ResolvedImageSource == app.Spec.Image {
+		image = app.Status.ResolvedImage
+	}
+	if app.Spec.MirrorImage {
+		return app.InfraImage(image)
+	}
+	return image
+}
+
+// GetImagePullPolicy returns the configured pull policy, or Always for
+// untagged and :latest images and IfNotPresent otherwise
+func (app *Application) GetImagePullPolicy() corev1.PullPolicy {
+	if app.Spec.ImagePullPolicy != "" {
+		return corev1.PullPolicy(app.Spec.ImagePullPolicy)
+	}
+	image := app.Spec.Image
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	// A colon after the last slash separates the tag; earlier ones belong to a registry port
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i < 0 || name[i+1:] == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// GetKind returns the workload kind, defaulting to Deployment
+func (app *Application) GetKind() WorkloadKind {
+	if app.Spec.Kind == "" {
+		return WorkloadDeployment
+	}
+	return app.Spec.Kind
+}
+
+// GetStrategy returns the rollout strategy, defaulting to RollingUpdate
+func (app *Application) GetStrategy() DeploymentStrategy {
+	if app.Spec.Strategy == "" {
+		return StrategyRollingUpdate
+	}
+	return app.Spec.Strategy
+}
+
+// UsesBlueGreen reports whether the application Deployment is rolled out blue/green
+func (app *Application) UsesBlueGreen() bool {
+	return app.GetKind() == WorkloadDeployment && app.GetStrategy() == StrategyBlueGreen
+}
+
+// UsesCanary reports whether new versions of the application Deployment start as a canary
+func (app *Application) UsesCanary() bool {
+	return app.GetKind() == WorkloadDeployment && app.GetStrategy() == StrategyCanary
+}
+
+// GetCanaryWeight returns the initial canary weight
+func (app *Application) GetCanaryWeight() int32 {
+	if app.Spec.CanaryWeight > 0 {
+		return app.Spec.CanaryWeight
+	}
+	return DefaultCanaryWeight
+}
+
+// OtherColor returns the idle blue/green color
+func OtherColor(color string) string {
+	if color == ColorBlue {
+		return ColorGreen
+	}
+	return ColorBlue
+}
+
+func (app *Application) NeedsDatabase() bool {
+	return app.Spec.Infrastructure.PostgreSQL != nil
+}
+
+
+func (app *Application) NeedsCache() bool {
+	return app.Spec.Infrastructure.Redis != nil
+}
+
+func (app *Application) NeedsStorage() bool {
+	return app.Spec.Infrastructure.S3 != nil
+}
+
+// NeedsDynamoDB reports whether the Application requests a DynamoDB table
+func (app *Application) NeedsDynamoDB() bool {
+	return app.Spec.Infrastructure.DynamoDB != nil
+}
+
+// GetDynamoDBTableName returns the configured table name or the Application name
+func (app *Application) GetDynamoDBTableName() string {
+	if app.Spec.Infrastructure.DynamoDB != nil && app.Spec.Infrastructure.DynamoDB.TableName != "" {
+		return app.Spec.Infrastructure.DynamoDB.TableName
+	}
+	return app.Name
+}
+
+// GetDynamoDBBillingMode returns the table billing mode, defaulting to on-demand
+func (app *Application) GetDynamoDBBillingMode() string {
+	if app.Spec.Infrastructure.DynamoDB != nil && app.Spec.Infrastructure.DynamoDB.BillingMode != "" {
+		return app.Spec.Infrastructure.DynamoDB.BillingMode
+	}
+	return DynamoDBBillingPayPerRequest
+}
+
+// NeedsQueue reports whether the Application requests an SQS queue
+func (app *Application) NeedsQueue() bool {
+	return app.Spec.Infrastructure.SQS != nil
+}
+
+// GetSQSQueueName returns the configured queue name or the Application name
+func (app *Application) GetSQSQueueName() string {
+	if app.Spec.Infrastructure.SQS != nil && app.Spec.Infrastructure.SQS.QueueName != "" {
+		return app.Spec.Infrastructure.SQS.QueueName
+	}
+	return app.Name
+}
+
+// NeedsStreaming reports whether the Application requests Kafka
+func (app *Application) NeedsStreaming() bool {
+	return app.Spec.Infrastructure.Kafka != nil
+}
+
+// GetKafkaVersion returns the configured Kafka version or the default
+func (app *Application) GetKafkaVersion() string {
+	if app.Spec.Infrastructure.Kafka != nil && app.Spec.Infrastructure.Kafka.Version != "" {
+		return app.Spec.Infrastructure.Kafka.Version
+	}
+	return DefaultKafkaVersion
+}
+
+// GetKafkaPartitions returns the partition count for created topics
+func (app *Application) GetKafkaPartitions() int32 {
+	if app.Spec.Infrastructure.Kafka != nil && app.Spec.Infrastructure.Kafka.Partitions > 0 {
+		return app.Spec.Infrastructure.Kafka.Partitions
+	}
+	return 1
+}
+
+func (app *Application) GetDatabaseEnvironment() Environment {
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.External != nil {
+		return EnvironmentExternal
+	}
+	if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.Environment != "" {
+		return app.Spec.Infrastructure.PostgreSQL.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+func (app *Application) GetRedisEnvironment() Environment {
+	if app.Spec.Infrastructure.Redis != nil && app.Spec.Infrastructure.Redis.Environment != "" {
+		return app.Spec.Infrastructure.Redis.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+func (app *Application) GetS3Environment() Environment {
+	if app.Spec.Infrastructure.S3 != nil && app.Spec.Infrastructure.S3.Environment != "" {
+		return app.Spec.Infrastructure.S3.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+func (app *Application) GetDynamoDBEnvironment() Environment {
+	if app.Spec.Infrastructure.DynamoDB != nil && app.Spec.Infrastructure.DynamoDB.Environment != "" {
+		return app.Spec.Infrastructure.DynamoDB.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+func (app *Application) GetSQSEnvironment() Environment {
+	if app.Spec.Infrastructure.SQS != nil && app.Spec.Infrastructure.SQS.Environment != "" {
+		return app.Spec.Infrastructure.SQS.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+func (app *Application) GetKafkaEnvironment() Environment {
+	if app.Spec.Infrastructure.Kafka != nil && app.Spec.Infrastructure.Kafka.Environment != "" {
+		return app.Spec.Infrastructure.Kafka.Environment
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		return app.Spec.Infrastructure.Environment
+	}
+	return EnvironmentAuto
+}
+
+// IsLocalDatabase reports whether the controller runs PostgreSQL in the
+// cluster. Like the other IsLocal* methods it is false for "auto" until
+// InfrastructureSpec.ResolveAutoEnvironment has replaced it with the
+// environment the controller detected.
+func (app *Application) IsLocalDatabase() bool {
+	env := app.GetDatabaseEnvironment()
+	return env == EnvironmentLocal
+}
+
+func (app *Application) IsLocalRedis() bool {
+	env := app.GetRedisEnvironment()
+	return env == EnvironmentLocal
+}
+
+func (app *Application) IsLocalS3() bool {
+	env := app.GetS3Environment()
+	return env == EnvironmentLocal
+}
+
+// IsLocalDynamoDB reports whether DynamoDB Local stands in for the AWS table
+func (app *Application) IsLocalDynamoDB() bool {
+	env := app.GetDynamoDBEnvironment()
+	return env == EnvironmentLocal
+}
+
+// IsExternalDynamoDB reports whether the DynamoDB table is user-managed
+func (app *Application) IsExternalDynamoDB() bool {
+	return app.NeedsDynamoDB() && app.GetDynamoDBEnvironment() == EnvironmentExternal
+}
+
+// IsLocalSQS reports whether ElasticMQ stands in for the AWS queue
+func (app *Application) IsLocalSQS() bool {
+	env := app.GetSQSEnvironment()
+	return env == EnvironmentLocal
+}
+
+// IsExternalSQS reports whether the queue is user-managed
+func (app *Application) IsExternalSQS() bool {
+	return app.NeedsQueue() && app.GetSQSEnvironment() == EnvironmentExternal
+}
+
+// IsLocalKafka reports whether the controller runs the Kafka broker itself
+func (app *Application) IsLocalKafka() bool {
+	env := app.GetKafkaEnvironment()
+	return env == EnvironmentLocal
+}
+
+// IsExternalKafka reports whether the Kafka cluster is user-managed
+func (app *Application) IsExternalKafka() bool {
+	return app.NeedsStreaming() && app.GetKafkaEnvironment() == EnvironmentExternal
+}
+
+// IsExternalDatabase reports whether the database is user-managed
+func (app *Application) IsExternalDatabase() bool {
+	return app.NeedsDatabase() && app.GetDatabaseEnvironment() == EnvironmentExternal
+}
+
+// IsExternalRedis reports whether Redis is user-managed
+func (app *Application) IsExternalRedis() bool {
+	return app.NeedsCache() && app.GetRedisEnvironment() == EnvironmentExternal
+}
+
+// IsExternalS3 reports whether the S3 bucket is user-managed
+func (app *Application) IsExternalS3() bool {
+	return app.NeedsStorage() && app.GetS3Environment() == EnvironmentExternal
 }
 
 func (app *Application) ValidateSpec() error {
-	if app.Spec.Image == "" {
+	if app.Spec.Image == "" && !app.Spec.InfrastructureOnly {
 		return fmt.Errorf("image is required")
 	}
+	if err := app.ValidateName(); err != nil {
+		return err
+	}
 	if app.Spec.Port != 0 && (app.Spec.Port < 1 || app.Spec.Port > 65535) {
 		return fmt.Errorf("port must be between 1 and 65535")
 	}
 	if app.Spec.Replicas < 0 {
 		return fmt.Errorf("replicas cannot be negative")
 	}
+	if app.Spec.PriorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(app.Spec.PriorityClassName); len(errs) > 0 {
+			return fmt.Errorf("invalid priorityClassName %q: %s", app.Spec.PriorityClassName, strings.Join(errs, "; "))
+		}
+	}
+	switch corev1.DNSPolicy(app.Spec.DNSPolicy) {
+	case "", corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSNone:
+		if app.Spec.DNSConfig == nil || len(app.Spec.DNSConfig.Nameservers) == 0 {
+			return fmt.Errorf("dnsConfig.nameservers is required when dnsPolicy is None")
+		}
+	default:
+		return fmt.Errorf("dnsPolicy must be ClusterFirst, ClusterFirstWithHostNet, Default or None")
+	}
+	if dc := app.Spec.DNSConfig; dc != nil && len(dc.Nameservers) > 3 {
+		return fmt.Errorf("dnsConfig allows at most 3 nameservers")
+	}
+	switch corev1.PullPolicy(app.Spec.ImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return fmt.Errorf("imagePullPolicy must be Always, IfNotPresent or Never")
+	}
+	for i, tsc := range app.Spec.TopologySpread {
+		if tsc.TopologyKey == "" {
+			return fmt.Errorf("topologySpread[%d]: topologyKey is required", i)
+		}
+		if tsc.MaxSkew < 0 {
+			return fmt.Errorf("topologySpread[%d]: maxSkew cannot be negative", i)
+		}
+		switch tsc.WhenUnsatisfiable {
+		case "", "DoNotSchedule", "ScheduleAnyway":
+		default:
+			return fmt.Errorf("topologySpread[%d]: whenUnsatisfiable must be DoNotSchedule or ScheduleAnyway", i)
+		}
+	}
+	initNames := map[string]bool{app.Name: true, DatabaseWaitContainer: true}
+	for i, c := range app.Spec.InitContainers {
+		if c.Name == "" {
+			return fmt.Errorf("initContainers[%d]: name is required", i)
+		}
+		if c.Image == "" {
+			return fmt.Errorf("initContainers[%d]: image is required", i)
+		}
+		if initNames[c.Name] {
+			return fmt.Errorf("initContainers[%d]: duplicate container name %q", i, c.Name)
+		}
+		initNames[c.Name] = true
+	}
+	if app.Spec.RunAsUser != nil && *app.Spec.RunAsUser < 0 {
+		return fmt.Errorf("runAsUser cannot be negative")
+	}
+	if app.Spec.RunAsGroup != nil && *app.Spec.RunAsGroup < 0 {
+		return fmt.Errorf("runAsGroup cannot be negative")
+	}
+	if app.Spec.FSGroup != nil && *app.Spec.FSGroup < 0 {
+		return fmt.Errorf("fsGroup cannot be negative")
+	}
+	if app.Spec.TerminationGracePeriodSeconds != nil && *app.Spec.TerminationGracePeriodSeconds < 0 {
+		return fmt.Errorf("terminationGracePeriodSeconds cannot be negative")
+	}
+	if ps := app.Spec.PreStop; ps != nil && ps.Exec == nil && ps.HTTPGet == nil && ps.TCPSocket == nil {
+		return fmt.Errorf("preStop must set exec, httpGet or tcpSocket")
+	}
+	if ps := app.Spec.PostStart; ps != nil {
+		if ps.TCPSocket != nil {
+			return fmt.Errorf("postStart does not support tcpSocket")
+		}
+		if (ps.Exec == nil) == (ps.HTTPGet == nil) {
+			return fmt.Errorf("postStart must set exactly one of exec or httpGet")
+		}
+	}
+	switch corev1.ServiceAffinity(app.Spec.SessionAffinity) {
+	case "", corev1.ServiceAffinityNone:
+		if app.Spec.SessionAffinityTimeoutSeconds != nil {
+			return fmt.Errorf("sessionAffinityTimeoutSeconds requires sessionAffinity ClientIP")
+		}
+	case corev1.ServiceAffinityClientIP:
+		if t := app.Spec.SessionAffinityTimeoutSeconds; t != nil && (*t < 1 || *t > 86400) {
+			return fmt.Errorf("sessionAffinityTimeoutSeconds must be between 1 and 86400")
+		}
+	default:
+		return fmt.Errorf("unsupported sessionAffinity %q (None or ClientIP)", app.Spec.SessionAffinity)
+	}
+	switch corev1.ServiceType(app.Spec.ServiceType) {
+	case "", corev1.ServiceTypeClusterIP:
+		if app.Spec.NodePort != 0 {
+			return fmt.Errorf("nodePort requires serviceType NodePort or LoadBalancer")
+		}
+	case corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		if app.Spec.Port == 0 {
+			return fmt.Errorf("serviceType %s exposes the application outside the cluster; set port explicitly", app.Spec.ServiceType)
+		}
+		if n := app.Spec.NodePort; n != 0 && (n < MinNodePort || n > MaxNodePort) {
+			return fmt.Errorf("nodePort %d is outside the node port range %d-%d", n, MinNodePort, MaxNodePort)
+		}
+	default:
+		return fmt.Errorf("unsupported serviceType %q (ClusterIP, NodePort or LoadBalancer)", app.Spec.ServiceType)
+	}
+	if err := app.validatePorts(); err != nil {
+		return err
+	}
+	if ns := app.Spec.TargetNamespace; ns != "" {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("invalid targetNamespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+	}
+	if len(app.Spec.Quota) > 0 && !app.DeploysToOtherNamespace() {
+		return fmt.Errorf("quota requires targetNamespace; a quota in the Application's own namespace would also limit its neighbours")
+	}
+	for name, quantity := range app.Spec.Quota {
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("quota %s cannot be negative", name)
+		}
+	}
+	if err := ValidateImageRegistry(app.Spec.ImageRegistry); err != nil {
+		return err
+	}
+	if err := app.validateEnvReferences(); err != nil {
+		return err
+	}
+	seenDeps := map[string]bool{}
+	for _, dep := range app.Spec.DependsOn {
+		switch {
+		case dep == app.Name:
+			return fmt.Errorf("dependsOn: an Application cannot depend on itself")
+		case seenDeps[dep]:
+			return fmt.Errorf("dependsOn: %q is listed twice", dep)
+		}
+		if errs := validation.IsDNS1123Subdomain(dep); len(errs) > 0 {
+			return fmt.Errorf("dependsOn: %q is not an Application name: %s", dep, strings.Join(errs, "; "))
+		}
+		seenDeps[dep] = true
+	}
+	if path := app.Spec.HealthCheckPath; path != "" {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("healthCheckPath must start with /")
+		}
+		if app.GetProtocol() != corev1.ProtocolTCP {
+			return fmt.Errorf("healthCheckPath needs a TCP port")
+		}
+	}
+	if smoke := app.Spec.SmokeTest; smoke != nil {
+		switch {
+		case app.Spec.InfrastructureOnly || app.GetKind() == WorkloadCronJob || app.GetKind() == WorkloadJob:
+			return fmt.Errorf("smokeTest needs a served application; it does not apply to %s workloads or infrastructureOnly", app.GetKind())
+		case app.GetProtocol() != corev1.ProtocolTCP:
+			return fmt.Errorf("smokeTest sends HTTP and needs a TCP port")
+		case !strings.HasPrefix(smoke.GetPath(), "/"):
+			return fmt.Errorf("smokeTest.path must start with /")
+		case smoke.GetExpectedStatus() < 100 || smoke.GetExpectedStatus() > 599:
+			return fmt.Errorf("smokeTest.expectedStatus must be an HTTP status code")
+		}
+	}
+	if hook := app.Spec.ReadyWebhook; hook != nil {
+		u, err := url.Parse(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("readyWebhook.url %q must be an absolute http or https URL", hook.URL)
+		}
+	}
+	if identity := app.GetIdentity(); identity != "" {
+		if errs := validation.IsValidLabelValue(identity); len(errs) > 0 {
+			return fmt.Errorf("invalid %s annotation %q: %s", IdentityAnnotation, identity, strings.Join(errs, "; "))
+		}
+	}
+	if tls := app.Spec.TLS; tls != nil {
+		if tls.SecretName == "" {
+			return fmt.Errorf("tls.secretName is required")
+		}
+		if errs := validation.IsDNS1123Subdomain(tls.SecretName); len(errs) > 0 {
+			return fmt.Errorf("invalid tls.secretName %q: %s", tls.SecretName, strings.Join(errs, "; "))
+		}
+		switch app.GetTLSMode() {
+		case TLSModeIngress:
+			if tls.Host == "" {
+				return fmt.Errorf("tls.host is required in Ingress mode")
+			}
+			if errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(tls.Host, "*.")); len(errs) > 0 {
+				return fmt.Errorf("invalid tls.host %q: %s", tls.Host, strings.Join(errs, "; "))
+			}
+		case TLSModeSidecar:
+			if tls.Host != "" || tls.IngressClassName != "" {
+				return fmt.Errorf("tls.host and tls.ingressClassName only apply in Ingress mode")
+			}
+			if app.GetPort() == TLSProxyPort {
+				return fmt.Errorf("tls sidecar listens on %d; the application port must differ", TLSProxyPort)
+			}
+		default:
+			return fmt.Errorf("unsupported tls.mode %q (Ingress or Sidecar)", tls.Mode)
+		}
+		if kind := app.GetKind(); kind == WorkloadCronJob || kind == WorkloadJob {
+			return fmt.Errorf("tls is not supported for kind %s, which has no Service", kind)
+		}
+	}
+	if m := app.Spec.Metrics; m != nil {
+		if m.Port < 0 || m.Port > 65535 {
+			return fmt.Errorf("metrics.port must be between 1 and 65535")
+		}
+		if m.Path != "" && !strings.HasPrefix(m.Path, "/") {
+			return fmt.Errorf("metrics.path %q must start with /", m.Path)
+		}
+	}
+	downwardNames := map[string]bool{}
+	generated := map[string]bool{}
+	for _, name := range app.ConnectionEnvNames() {
+		generated[name] = true
+	}
+	for i, d := range app.Spec.DownwardEnv {
+		if d.Name == "" {
+			return fmt.Errorf("downwardEnv[%d]: name is required", i)
+		}
+		if downwardNames[d.Name] {
+			return fmt.Errorf("downwardEnv[%d]: duplicate name %q", i, d.Name)
+		}
+		if _, ok := app.Spec.Env[d.Name]; ok {
+			return fmt.Errorf("downwardEnv[%d]: %s is also set in env", i, d.Name)
+		}
+		if generated[d.Name] {
+			return fmt.Errorf("downwardEnv[%d]: %s is set by the platform for the requested infrastructure", i, d.Name)
+		}
+		downwardNames[d.Name] = true
+		if !ValidDownwardFieldPath(d.FieldPath) {
+			return fmt.Errorf("downwardEnv[%d]: unsupported fieldPath %q", i, d.FieldPath)
+		}
+	}
+	mountPaths := map[string]bool{}
+	for i, sv := range app.Spec.SecretVolumes {
+		if sv.SecretName == "" {
+			return fmt.Errorf("secretVolumes[%d]: secretName is required", i)
+		}
+		if !strings.HasPrefix(sv.MountPath, "/") {
+			return fmt.Errorf("secretVolumes[%d]: mountPath must be an absolute path", i)
+		}
+		if mountPaths[sv.MountPath] {
+			return fmt.Errorf("secretVolumes[%d]: duplicate mountPath %q", i, sv.MountPath)
+		}
+		mountPaths[sv.MountPath] = true
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil {
+		if pg.Storage < 0 {
+			return fmt.Errorf("postgresql.storage cannot be negative")
+		}
+		if pg.LocalStorage != "" {
+			size, err := resource.ParseQuantity(pg.LocalStorage)
+			if err != nil {
+				return fmt.Errorf("invalid postgresql.localStorage %q: %w", pg.LocalStorage, err)
+			}
+			if size.Sign() <= 0 {
+				return fmt.Errorf("postgresql.localStorage must be greater than zero")
+			}
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil {
+		if err := validateAccessMode("postgresql.accessMode", pg.AccessMode); err != nil {
+			return err
+		}
+		switch appsv1.StatefulSetUpdateStrategyType(pg.UpdateStrategy) {
+		case "", appsv1.RollingUpdateStatefulSetStrategyType, appsv1.OnDeleteStatefulSetStrategyType:
+		default:
+			return fmt.Errorf("unsupported postgresql.updateStrategy %q (RollingUpdate or OnDelete)", pg.UpdateStrategy)
+		}
+	}
+	if kafka := app.Spec.Infrastructure.Kafka; kafka != nil {
+		if err := validateAccessMode("kafka.accessMode", kafka.AccessMode); err != nil {
+			return err
+		}
+	}
+	if s3 := app.Spec.Infrastructure.S3; s3 != nil && s3.LocalStorage != "" {
+		if _, err := resource.ParseQuantity(s3.LocalStorage); err != nil {
+			return fmt.Errorf("invalid s3.localStorage %q: %w", s3.LocalStorage, err)
+		}
+	}
+	if s3 := app.Spec.Infrastructure.S3; s3 != nil {
+		if err := validateDeletionPolicy("s3.deletionPolicy", s3.DeletionPolicy); err != nil {
+			return err
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil {
+		if err := validateDeletionPolicy("postgresql.deletionPolicy", pg.DeletionPolicy); err != nil {
+			return err
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.InitSQL != "" && pg.InitSQLConfigMap != "" {
+		return fmt.Errorf("postgresql.initSQL and postgresql.initSQLConfigMap are mutually exclusive")
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil {
+		if err := validatePostgresParameters(pg.Parameters); err != nil {
+			return err
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.External != nil {
+		if pg.External.Endpoint == "" {
+			return fmt.Errorf("postgresql.external.endpoint is required")
+		}
+		if pg.External.CredentialsSecretName == "" {
+			return fmt.Errorf("postgresql.external.credentialsSecretName is required")
+		}
+		if pg.Environment != "" && pg.Environment != EnvironmentExternal {
+			return fmt.Errorf("postgresql.external conflicts with environment %q", pg.Environment)
+		}
+	} else if app.IsExternalDatabase() {
+		return fmt.Errorf("postgresql.external is required when the database environment is external")
+	}
+	if app.IsExternalRedis() && app.Spec.Infrastructure.Redis.Endpoint == "" {
+		return fmt.Errorf("redis.endpoint is required when the redis environment is external")
+	}
+	if app.IsExternalS3() && app.Spec.Infrastructure.S3.BucketName == "" {
+		return fmt.Errorf("s3.bucketName is required when the s3 environment is external")
+	}
+	// Buckets the controller creates must be valid S3 names; external ones
+	// already exist under whatever name their provider accepted
+	if s3 := app.Spec.Infrastructure.S3; s3 != nil && s3.BucketName != "" && !app.IsExternalS3() {
+		name := s3.BucketName
+		if !s3BucketNamePattern.MatchString(name) || strings.Contains(name, "..") || ipAddressPattern.MatchString(name) {
+			return fmt.Errorf("invalid s3.bucketName %q: use 3-63 lowercase letters, digits, dots and hyphens, starting and ending with a letter or digit", name)
+		}
+	}
+	if redis := app.Spec.Infrastructure.Redis; redis != nil && redis.Memory != "" {
+		if _, err := resource.ParseQuantity(redis.Memory); err != nil {
+			return fmt.Errorf("invalid redis.memory %q: %w", redis.Memory, err)
+		}
+	}
+	if redis := app.Spec.Infrastructure.Redis; redis != nil {
+		if len(redis.Databases) > MaxRedisDatabases {
+			return fmt.Errorf("redis.databases can name at most %d databases", MaxRedisDatabases)
+		}
+		seen := map[string]bool{}
+		for _, name := range redis.Databases {
+			if !redisDatabaseNamePattern.MatchString(name) {
+				return fmt.Errorf("invalid redis.databases name %q: must start with a letter and contain only letters, digits and underscores", name)
+			}
+			if seen[strings.ToUpper(name)] {
+				return fmt.Errorf("duplicate redis.databases name %q", name)
+			}
+			seen[strings.ToUpper(name)] = true
+		}
+	}
+	if s3 := app.Spec.Infrastructure.S3; s3 != nil && s3.Version != "" && !minioReleasePattern.MatchString(s3.Version) {
+		return fmt.Errorf("s3.version %q must be a MinIO release tag like %s", s3.Version, DefaultMinIOVersion)
+	}
+	if ddb := app.Spec.Infrastructure.DynamoDB; ddb != nil {
+		if ddb.HashKey == "" {
+			return fmt.Errorf("dynamodb.hashKey is required")
+		}
+		if name := app.GetDynamoDBTableName(); !dynamoDBTableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid dynamodb table name %q: use 3-255 letters, digits, underscores, dots and hyphens", name)
+		}
+		if !dynamoDBKeyPattern.MatchString(ddb.HashKey) {
+			return fmt.Errorf("invalid dynamodb.hashKey %q: use letters, digits, underscores, dots and hyphens", ddb.HashKey)
+		}
+		if ddb.RangeKey != "" && !dynamoDBKeyPattern.MatchString(ddb.RangeKey) {
+			return fmt.Errorf("invalid dynamodb.rangeKey %q: use letters, digits, underscores, dots and hyphens", ddb.RangeKey)
+		}
+		if ddb.RangeKey != "" && ddb.RangeKey == ddb.HashKey {
+			return fmt.Errorf("dynamodb.rangeKey must differ from hashKey")
+		}
+		switch ddb.BillingMode {
+		case "", DynamoDBBillingPayPerRequest, DynamoDBBillingProvisioned:
+		default:
+			return fmt.Errorf("dynamodb.billingMode must be %s or %s", DynamoDBBillingPayPerRequest, DynamoDBBillingProvisioned)
+		}
+		if ddb.ReadCapacityUnits < 0 || ddb.WriteCapacityUnits < 0 {
+			return fmt.Errorf("dynamodb capacity units cannot be negative")
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.ReadReplicas < 0 {
+		return fmt.Errorf("postgresql.readReplicas cannot be negative")
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.Replicas != nil && *pg.Replicas < 1 {
+		return fmt.Errorf("postgresql.replicas must be at least 1; a database cannot be scaled to zero")
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil {
+		if pg.PoolSize < 0 {
+			return fmt.Errorf("postgresql.poolSize cannot be negative")
+		}
+		if pg.PoolSize > 0 && !pg.Pooler {
+			return fmt.Errorf("postgresql.poolSize requires postgresql.pooler")
+		}
+		if pg.Pooler && app.IsExternalDatabase() {
+			return fmt.Errorf("postgresql.pooler is only available for provisioned databases, not external ones")
+		}
+	}
+	if sqs := app.Spec.Infrastructure.SQS; sqs != nil {
+		if !sqsQueueNamePattern.MatchString(app.GetSQSQueueName()) {
+			return fmt.Errorf("sqs queue name %q must be 1-80 letters, digits, hyphens or underscores", app.GetSQSQueueName())
+		}
+		if sqs.VisibilityTimeoutSeconds < 0 || sqs.VisibilityTimeoutSeconds > 43200 {
+			return fmt.Errorf("sqs.visibilityTimeoutSeconds must be between 0 and 43200")
+		}
+		if sqs.MessageRetentionSeconds != 0 && (sqs.MessageRetentionSeconds < 60 || sqs.MessageRetentionSeconds > 1209600) {
+			return fmt.Errorf("sqs.messageRetentionSeconds must be between 60 and 1209600")
+		}
+	}
+	if kafka := app.Spec.Infrastructure.Kafka; kafka != nil {
+		// Checked on the effective environment, so a top-level aws (or auto
+		// once the controller has resolved it to aws) is caught too
+		if app.GetKafkaEnvironment() == EnvironmentAWS {
+			return fmt.Errorf("kafka cannot run on aws (there is no managed Kafka provisioning); set kafka.environment to local or external")
+		}
+		if kafka.Environment == EnvironmentExternal && kafka.Brokers == "" {
+			return fmt.Errorf("kafka.brokers is required when kafka.environment is external")
+		}
+		if kafka.Partitions < 0 {
+			return fmt.Errorf("kafka.partitions cannot be negative")
+		}
+		for _, topic := range kafka.Topics {
+			if topic == "." || topic == ".." || !kafkaTopicPattern.MatchString(topic) {
+				return fmt.Errorf("invalid kafka topic %q", topic)
+			}
+		}
+	}
+	if w := app.Spec.Infrastructure.MaintenanceWindow; w != nil {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateTolerations("tolerations", app.Spec.Tolerations); err != nil {
+		return err
+	}
+	if err := validateTolerations("infrastructure.tolerations", app.Spec.Infrastructure.Tolerations); err != nil {
+		return err
+	}
+	switch app.GetStrategy() {
+	case StrategyRollingUpdate:
+	case StrategyBlueGreen, StrategyCanary:
+		if app.GetKind() != WorkloadDeployment {
+			return fmt.Errorf("strategy %s requires kind Deployment", app.Spec.Strategy)
+		}
+		if app.GetStrategy() == StrategyCanary && app.GetReplicas() < MinCanaryReplicas {
+			return fmt.Errorf("strategy Canary requires at least %d replicas", MinCanaryReplicas)
+		}
+	default:
+		return fmt.Errorf("unsupported strategy %q", app.Spec.Strategy)
+	}
+	if app.Spec.CanaryWeight < 0 || app.Spec.CanaryWeight > 100 {
+		return fmt.Errorf("canaryWeight must be between 0 and 100")
+	}
+	if app.Spec.CanaryStep < 0 || app.Spec.CanaryStep > 100 {
+		return fmt.Errorf("canaryStep must be between 0 and 100")
+	}
+	if app.Spec.BackoffLimit != nil && *app.Spec.BackoffLimit < 0 {
+		return fmt.Errorf("backoffLimit cannot be negative")
+	}
+	if app.Spec.RevisionHistoryLimit != nil && *app.Spec.RevisionHistoryLimit < 0 {
+		return fmt.Errorf("revisionHistoryLimit cannot be negative")
+	}
+	switch app.GetKind() {
+	case WorkloadDeployment:
+	case WorkloadCronJob:
+		if app.Spec.Schedule == "" {
+			return fmt.Errorf("schedule is required when kind is CronJob")
+		}
+		if err := ValidateCronSchedule(app.Spec.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule %q: %w", app.Spec.Schedule, err)
+		}
+	case WorkloadJob:
+	case WorkloadStatefulSet:
+		if app.Spec.AppStorage != "" {
+			size, err := resource.ParseQuantity(app.Spec.AppStorage)
+			if err != nil {
+				return fmt.Errorf("invalid appStorage %q: %w", app.Spec.AppStorage, err)
+			}
+			if size.Sign() <= 0 {
+				return fmt.Errorf("appStorage must be greater than zero")
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %q", app.Spec.Kind)
+	}
 	return nil
 }
 
+// HasSecretMountAt reports whether a secretVolumes entry is mounted at path
+func (app *Application) HasSecretMountAt(path string) bool {
+	for _, sv := range app.Spec.SecretVolumes {
+		if sv.MountPath == path {
+			return true
+		}
+	}
+	return false
+}
+
 func (app *Application) GetReplicas() int32 {
 	if app.Spec.Replicas <= 0 {
 		return 1
@@ -306,6 +2139,17 @@ func (app *Application) GetReplicas() int32 {
 	return app.Spec.Replicas
 }
 
+// DefaultRevisionHistoryLimit keeps fewer old ReplicaSets than Kubernetes' default of 10
+const DefaultRevisionHistoryLimit int32 = 3
+
+// GetRevisionHistoryLimit returns the ReplicaSets kept for rollback
+func (app *Application) GetRevisionHistoryLimit() int32 {
+	if app.Spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+	return *app.Spec.RevisionHistoryLimit
+}
+
 func (app *Application) GetPort() int32 {
 	if app.Spec.Port <= 0 {
 		return 8080
@@ -313,12 +2157,150 @@ func (app *Application) GetPort() int32 {
 	return app.Spec.Port
 }
 
+// GetProtocol returns the application port's protocol, defaulting to TCP
+func (app *Application) GetProtocol() corev1.Protocol {
+	if app.Spec.Protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return corev1.Protocol(app.Spec.Protocol)
+}
+
+// validatePorts checks the application port's protocol and the additional
+// ports: valid names, no port/protocol pair used twice on the container or
+// the Service
+func (app *Application) validatePorts() error {
+	validProtocol := func(p corev1.Protocol) bool {
+		return p == corev1.ProtocolTCP || p == corev1.ProtocolUDP
+	}
+	if !validProtocol(app.GetProtocol()) {
+		return fmt.Errorf("unsupported protocol %q (TCP or UDP)", app.Spec.Protocol)
+	}
+	if app.Spec.TLS != nil && app.GetProtocol() != corev1.ProtocolTCP {
+		return fmt.Errorf("tls requires the application port to use TCP")
+	}
+
+	type portKey struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	containerPorts := map[portKey]bool{{app.GetPort(), app.GetProtocol()}: true}
+	servicePorts := map[portKey]bool{{80, app.GetProtocol()}: true}
+	// Names the controller gives its own ports
+	names := map[string]bool{"http": true, "https": true, "udp": true, "metrics": true}
+	for _, p := range app.Spec.AdditionalPorts {
+		if errs := validation.IsValidPortName(p.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid additionalPorts name %q: %s", p.Name, strings.Join(errs, "; "))
+		}
+		if names[p.Name] {
+			return fmt.Errorf("additionalPorts name %q is reserved or already in use", p.Name)
+		}
+		names[p.Name] = true
+		if p.Port < 1 || p.Port > 65535 || p.GetServicePort() < 1 || p.GetServicePort() > 65535 {
+			return fmt.Errorf("additionalPorts %s: ports must be between 1 and 65535", p.Name)
+		}
+		if !validProtocol(p.GetProtocol()) {
+			return fmt.Errorf("additionalPorts %s: unsupported protocol %q (TCP or UDP)", p.Name, p.Protocol)
+		}
+		container := portKey{p.Port, p.GetProtocol()}
+		if containerPorts[container] {
+			return fmt.Errorf("additionalPorts %s: container port %d/%s is already in use", p.Name, p.Port, p.GetProtocol())
+		}
+		containerPorts[container] = true
+		service := portKey{p.GetServicePort(), p.GetProtocol()}
+		if servicePorts[service] {
+			return fmt.Errorf("additionalPorts %s: service port %d/%s is already in use", p.Name, p.GetServicePort(), p.GetProtocol())
+		}
+		servicePorts[service] = true
+	}
+	return nil
+}
+
+// GetSessionAffinity returns the Service session affinity, defaulting to None
+func (app *Application) GetSessionAffinity() corev1.ServiceAffinity {
+	if app.Spec.SessionAffinity == "" {
+		return corev1.ServiceAffinityNone
+	}
+	return corev1.ServiceAffinity(app.Spec.SessionAffinity)
+}
+
+// GetTargetNamespace returns the namespace the application's resources live in
+func (app *Application) GetTargetNamespace() string {
+	if app.Spec.TargetNamespace != "" {
+		return app.Spec.TargetNamespace
+	}
+	return app.Namespace
+}
+
+// DeploysToOtherNamespace reports whether resources are created outside the
+// Application's namespace, where owner references cannot reach them
+func (app *Application) DeploysToOtherNamespace() bool {
+	return app.GetTargetNamespace() != app.Namespace
+}
+
+// GetServiceType returns the application Service type, defaulting to ClusterIP
+func (app *Application) GetServiceType() corev1.ServiceType {
+	if app.Spec.ServiceType == "" {
+		return corev1.ServiceTypeClusterIP
+	}
+	return corev1.ServiceType(app.Spec.ServiceType)
+}
+
+// GetTLSMode returns the TLS termination mode, defaulting to Ingress
+func (app *Application) GetTLSMode() TLSMode {
+	if app.Spec.TLS == nil || app.Spec.TLS.Mode == "" {
+		return TLSModeIngress
+	}
+	return app.Spec.TLS.Mode
+}
+
+// UsesTLSIngress reports whether HTTPS is terminated by an Ingress
+func (app *Application) UsesTLSIngress() bool {
+	return app.Spec.TLS != nil && app.GetTLSMode() == TLSModeIngress
+}
+
+// UsesTLSSidecar reports whether HTTPS is terminated by a sidecar in the pod
+func (app *Application) UsesTLSSidecar() bool {
+	return app.Spec.TLS != nil && app.GetTLSMode() == TLSModeSidecar
+}
+
+// MetricsEnabled reports whether the application should be scraped by Prometheus
+func (app *Application) MetricsEnabled() bool {
+	return app.Spec.Metrics != nil && app.Spec.Metrics.Enabled
+}
+
+// GetMetricsPath returns the metrics path or /metrics
+func (app *Application) GetMetricsPath() string {
+	if app.Spec.Metrics != nil && app.Spec.Metrics.Path != "" {
+		return app.Spec.Metrics.Path
+	}
+	return DefaultMetricsPath
+}
+
+// GetMetricsPort returns the metrics port, falling back to the application port
+func (app *Application) GetMetricsPort() int32 {
+	if app.Spec.Metrics != nil && app.Spec.Metrics.Port > 0 {
+		return app.Spec.Metrics.Port
+	}
+	return app.GetPort()
+}
+
+// HasSeparateMetricsPort reports whether metrics are served on their own port
+func (app *Application) HasSeparateMetricsPort() bool {
+	return app.MetricsEnabled() && app.GetMetricsPort() != app.GetPort()
+}
+
+// GetInfrastructureSummary describes each requested component and where it
+// runs; components still on "auto" are shown as such
 func (app *Application) GetInfrastructureSummary() string {
 	var components []string
 	
 	if app.NeedsDatabase() {
 		env := app.GetDatabaseEnvironment()
-		if app.IsLocalDatabase() {
+		if app.IsExternalDatabase() {
+			components = append(components, "PostgreSQL (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "PostgreSQL (auto)")
+		} else if app.IsLocalDatabase() {
 			components = append(components, fmt.Sprintf("PostgreSQL (local:%s)", env))
 		} else {
 			components = append(components, fmt.Sprintf("PostgreSQL (AWS:%s)", env))
@@ -327,7 +2309,11 @@ func (app *Application) GetInfrastructureSummary() string {
 	
 	if app.NeedsCache() {
 		env := app.GetRedisEnvironment()
-		if app.IsLocalRedis() {
+		if app.IsExternalRedis() {
+			components = append(components, "Redis (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "Redis (auto)")
+		} else if app.IsLocalRedis() {
 			components = append(components, fmt.Sprintf("Redis (local:%s)", env))
 		} else {
 			components = append(components, fmt.Sprintf("Redis (AWS:%s)", env))
@@ -336,13 +2322,56 @@ func (app *Application) GetInfrastructureSummary() string {
 	
 	if app.NeedsStorage() {
 		env := app.GetS3Environment()
-		if app.IsLocalS3() {
+		if app.IsExternalS3() {
+			components = append(components, "S3 (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "S3 (auto)")
+		} else if app.IsLocalS3() {
 			components = append(components, fmt.Sprintf("S3/MinIO (local:%s)", env))
 		} else {
 			components = append(components, fmt.Sprintf("S3 (AWS:%s)", env))
 		}
 	}
 	
+	if app.NeedsDynamoDB() {
+		env := app.GetDynamoDBEnvironment()
+		if app.IsExternalDynamoDB() {
+			components = append(components, "DynamoDB (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "DynamoDB (auto)")
+		} else if app.IsLocalDynamoDB() {
+			components = append(components, fmt.Sprintf("DynamoDB Local (local:%s)", env))
+		} else {
+			components = append(components, fmt.Sprintf("DynamoDB (AWS:%s)", env))
+		}
+	}
+	
+	if app.NeedsQueue() {
+		env := app.GetSQSEnvironment()
+		if app.IsExternalSQS() {
+			components = append(components, "SQS (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "SQS (auto)")
+		} else if app.IsLocalSQS() {
+			components = append(components, fmt.Sprintf("SQS/ElasticMQ (local:%s)", env))
+		} else {
+			components = append(components, fmt.Sprintf("SQS (AWS:%s)", env))
+		}
+	}
+	
+	if app.NeedsStreaming() {
+		env := app.GetKafkaEnvironment()
+		if app.IsExternalKafka() {
+			components = append(components, "Kafka (external)")
+		} else if env == EnvironmentAuto {
+			components = append(components, "Kafka (auto)")
+		} else if app.IsLocalKafka() {
+			components = append(components, fmt.Sprintf("Kafka (local:%s)", env))
+		} else {
+			components = append(components, fmt.Sprintf("Kafka (unsupported:%s)", env))
+		}
+	}
+	
 	if len(components) == 0 {
 		return "No external infrastructure"
 	}
@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestManagedResources(t *testing.T) {
+	status := &ApplicationStatus{}
+	deployment := ManagedResourceRef{Kind: "Deployment", Name: "web", Namespace: "default"}
+	service := ManagedResourceRef{Kind: "Service", Name: "web", Namespace: "default"}
+	status.AddManagedResource(deployment)
+	status.AddManagedResource(service)
+	status.AddManagedResource(deployment)
+	if len(status.ManagedResources) != 2 {
+		t.Fatalf("inventory = %+v, want each resource once", status.ManagedResources)
+	}
+
+	app := &Application{Status: *status}
+	copied := app.DeepCopy()
+	copied.Status.ManagedResources[0].Name = "changed"
+	if app.Status.ManagedResources[0].Name != "web" {
+		t.Error("DeepCopy shares the inventory with the original")
+	}
+
+	status.RemoveManagedResource(deployment)
+	if len(status.ManagedResources) != 1 || status.ManagedResources[0] != service {
+		t.Errorf("inventory = %+v, want only the Service", status.ManagedResources)
+	}
+}
+
+func TestValidateServiceType(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceType string
+		port        int32
+		nodePort    int32
+		wantErr     bool
+	}{
+		{"cluster IP without port", "", 0, 0, false},
+		{"node port without port", "NodePort", 0, 0, true},
+		{"load balancer without port", "LoadBalancer", 0, 0, true},
+		{"node port with port", "NodePort", 8080, 0, false},
+		{"fixed node port", "NodePort", 8080, 30080, false},
+		{"node port below range", "NodePort", 8080, 8080, true},
+		{"node port above range", "LoadBalancer", 8080, 40000, true},
+		{"node port on cluster IP", "ClusterIP", 8080, 30080, true},
+		{"unknown type", "ExternalName", 8080, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", ServiceType: tt.serviceType, Port: tt.port, NodePort: tt.nodePort}}
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRunAsIDs(t *testing.T) {
+	negative, zero := int64(-1), int64(0)
+	tests := []struct {
+		name       string
+		runAsUser  *int64
+		runAsGroup *int64
+		wantErr    bool
+	}{
+		{"unset", nil, nil, false},
+		{"root", &zero, &zero, false},
+		{"negative user", &negative, nil, true},
+		{"negative group", nil, &negative, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", RunAsUser: tt.runAsUser, RunAsGroup: tt.runAsGroup}}
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTolerations(t *testing.T) {
+	seconds := int64(300)
+	tests := []struct {
+		name       string
+		toleration corev1.Toleration
+		wantErr    bool
+	}{
+		{"equal", corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule}, false},
+		{"default operator", corev1.Toleration{Key: "dedicated", Value: "gpu"}, false},
+		{"exists", corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists}, false},
+		{"exists with value", corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists, Value: "true"}, true},
+		{"equal without key", corev1.Toleration{Operator: corev1.TolerationOpEqual, Value: "gpu"}, true},
+		{"unknown operator", corev1.Toleration{Key: "spot", Operator: "In"}, true},
+		{"unknown effect", corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists, Effect: "NoRun"}, true},
+		{"seconds with NoExecute", corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds}, false},
+		{"seconds with NoSchedule", corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: &seconds}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", Tolerations: []corev1.Toleration{tt.toleration}}}
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() = %v, want error %t", err, tt.wantErr)
+			}
+			// The infrastructure tolerations are checked the same way
+			app = &Application{Spec: ApplicationSpec{Image: "nginx:1.25"}}
+			app.Spec.Infrastructure.Tolerations = []corev1.Toleration{tt.toleration}
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() with infrastructure tolerations = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
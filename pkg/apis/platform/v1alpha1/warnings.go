@@ -0,0 +1,58 @@
+// pkg/apis/platform/v1alpha1/warnings.go
+// Settings that are valid but probably not what the user meant
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SpecWarnings lists valid-but-suspicious settings. Callers surface them as
+// warnings, or reject the spec when running in strict mode.
+func (app *Application) SpecWarnings() []string {
+	var warnings []string
+
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.Storage > 0 && pg.LocalStorage != "" {
+		local, err := resource.ParseQuantity(pg.LocalStorage)
+		storage := resource.MustParse(fmt.Sprintf("%dGi", pg.Storage))
+		if err == nil && local.Cmp(storage) != 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"postgresql.storage (%dGi) and postgresql.localStorage (%s) disagree: localStorage sizes the local volume, storage only applies to AWS",
+				pg.Storage, pg.LocalStorage))
+		}
+	}
+
+	if app.GetDatabaseReadReplicas() > 0 && app.IsExternalDatabase() {
+		warnings = append(warnings, "postgresql.readReplicas does not apply to external databases; set external.readEndpoint instead")
+	}
+	if app.GetDatabaseReadReplicas() > 0 && app.IsLocalDatabase() {
+		warnings = append(warnings,
+			"postgresql.readReplicas on a local database adds a read endpoint served by the primary; local databases have no replication")
+	}
+
+	if app.IsLocalDatabase() && app.GetDatabaseReplicas() > MaxLocalDatabaseReplicas {
+		warnings = append(warnings, fmt.Sprintf(
+			"postgresql.replicas (%d) is capped at %d for local databases, which have no streaming replication",
+			app.GetDatabaseReplicas(), MaxLocalDatabaseReplicas))
+	}
+
+	if app.UsesPooler() && app.GetDatabaseEnvironment() == EnvironmentAWS {
+		warnings = append(warnings, "postgresql.pooler is only provisioned for local databases and is ignored on AWS")
+	}
+
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.Exporter && !app.IsLocalDatabase() {
+		warnings = append(warnings, "postgresql.exporter only runs beside local databases and is ignored otherwise")
+	}
+	if redis := app.Spec.Infrastructure.Redis; redis != nil && redis.Exporter && !app.IsLocalRedis() {
+		warnings = append(warnings, "redis.exporter only runs beside local Redis and is ignored otherwise")
+	}
+
+	if app.Spec.HealthCheckPath != "" && app.GetProtocol() != corev1.ProtocolTCP {
+		warnings = append(warnings, "healthCheckPath is ignored: the HTTP readiness probe cannot reach a UDP application port")
+	}
+
+	return warnings
+}
@@ -0,0 +1,43 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecWarningsStorage(t *testing.T) {
+	tests := []struct {
+		name         string
+		storage      int32
+		localStorage string
+		wantWarning  bool
+	}{
+		{"conflicting sizes", 20, "10Gi", true},
+		{"matching sizes", 10, "10Gi", false},
+		{"storage only", 20, "", false},
+		{"localStorage only", 0, "10Gi", false},
+		{"invalid localStorage", 20, "ten gigs", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", Infrastructure: InfrastructureSpec{
+				PostgreSQL: &PostgreSQLSpec{Environment: EnvironmentLocal, Storage: tt.storage, LocalStorage: tt.localStorage},
+			}}}
+			warnings := app.SpecWarnings()
+			if got := len(warnings) == 1 && strings.Contains(warnings[0], "disagree"); got != tt.wantWarning {
+				t.Errorf("warnings = %v, want a storage conflict warning %t", warnings, tt.wantWarning)
+			}
+			if len(warnings) > 1 {
+				t.Errorf("warnings = %v, want at most one", warnings)
+			}
+		})
+	}
+
+	// An unparsable size is not a warning but a validation error
+	app := &Application{Spec: ApplicationSpec{Image: "nginx:1.25", Infrastructure: InfrastructureSpec{
+		PostgreSQL: &PostgreSQLSpec{Environment: EnvironmentLocal, Storage: 20, LocalStorage: "ten gigs"},
+	}}}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("invalid postgresql.localStorage accepted")
+	}
+}
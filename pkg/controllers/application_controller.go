@@ -5,19 +5,30 @@ package controllers
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"net/http"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
@@ -26,49 +37,212 @@ import (
 // ApplicationController manages the lifecycle of Application resources
 type ApplicationController struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Config   ReconcileConfig
+	Recorder record.EventRecorder
+	// Defaults holds platform-wide infrastructure defaults; nil means none
+	Defaults *InfrastructureDefaults
+	// Health records reconcile outcomes for the readiness check; may be nil
+	Health *ReconcileHealth
+	// AWS provisions AWS-managed components; nil uses the simulated client
+	AWS AWSClient
+	// simulatedAWS stands in for a nil AWS, created on first use
+	simulatedAWS     *simulatedAWSClient
+	simulatedAWSOnce sync.Once
+	// Registry resolves image digests; nil uses the registry HTTP API
+	Registry ImageResolver
+	// HTTPClient sends ready webhook notifications and smoke test requests;
+	// nil uses a client with a 10s timeout
+	HTTPClient *http.Client
+}
+
+// recordEvent emits an event on the Application when a recorder is configured
+func (r *ApplicationController) recordEvent(app *v1alpha1.Application, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(app, eventType, reason, message)
 }
 
 // Reconcile is the main controller logic - enhanced with environment awareness
 func (r *ApplicationController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-	logger.Info("🔄 Reconciling Application", "name", req.Name, "namespace", req.Namespace)
+	ctx, span := startReconcileSpan(ctx, req)
+	result, err := r.reconcile(ctx, req)
+	endSpan(span, err)
+	r.Health.Record(err)
+	reconcileTotal.WithLabelValues(resultLabel(err)).Inc()
+	return result, err
+}
+
+// reconcile fetches the Application and runs the lifecycle for it
+func (r *ApplicationController) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues(logKeyApplication, req.Name)
+	logger.Info("Reconciling Application")
 
 	// Fetch the Application resource that triggered this reconciliation
 	app := &v1alpha1.Application{}
 	err := r.Get(ctx, req.NamespacedName, app)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			logger.Info("❌ Application not found - might have been deleted", "name", req.Name)
+			logger.Info("Application not found - might have been deleted")
+			trackedPhases.set(req.NamespacedName, "")
 			return ctrl.Result{}, nil
 		}
-		logger.Error(err, "❌ Failed to get Application")
+		logger.Error(err, "Failed to get Application")
+		return ctrl.Result{}, err
+	}
+
+	logger = appLogger(ctx, app)
+	defer func() { trackedPhases.set(req.NamespacedName, app.Status.Phase) }()
+	previousPhase := app.Status.Phase
+	ctx, persistedPhase := withPersistedPhase(ctx, previousPhase)
+	defer func() { r.notifyPhaseTransition(ctx, app, previousPhase, *persistedPhase) }()
+
+	// Resources in a target namespace are not garbage collected with the
+	// Application; the cleanup finalizer deletes them
+	if !app.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeApplication(ctx, app)
+	}
+	if added, err := r.ensureCleanupFinalizer(ctx, app); err != nil || added {
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("📋 Found Application", 
+	// Paused Applications are left alone until the annotation is removed
+	if app.IsPaused() {
+		return r.pauseApplication(ctx, app)
+	}
+	if app.Status.Phase == v1alpha1.PhasePaused {
+		// Resume where the Application was paused; spec changes made meanwhile
+		// are picked up by that phase as usual
+		resumed := app.Status.PausedPhase
+		if resumed == "" {
+			resumed = v1alpha1.PhasePending
+		}
+		logger.Info("Pause annotation removed - resuming reconciliation", "phase", resumed)
+		r.recordEvent(app, corev1.EventTypeNormal, "Resumed", fmt.Sprintf("Reconciliation resumed in phase %s", resumed))
+		app.UpdateStatus(resumed, "Resuming reconciliation")
+		app.Status.PausedPhase = ""
+		if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Fill unset infrastructure fields from the platform defaults (user values win)
+	if r.Defaults != nil {
+		app.Spec.Infrastructure.ApplyDefaults(r.Defaults.Get())
+	}
+	// Recognized images get their well-known port and health check path when
+	// the Application opted in
+	app.ApplyImageProfile(r.Defaults.ImageProfiles())
+	app.Spec.Infrastructure.ResolveAutoEnvironment(r.detectEnvironment())
+	if app.Spec.ImageRegistry == "" {
+		app.Spec.ImageRegistry = r.Config.ImageRegistry
+	}
+
+	logger.Info("Found Application", 
 		"image", app.Spec.Image, 
 		"replicas", app.GetReplicas(),
 		"infrastructure", app.GetInfrastructureSummary())
 
 	// Validate the Application spec
 	if err := app.ValidateSpec(); err != nil {
-		logger.Error(err, "❌ Application spec validation failed")
+		logger.Error(err, "Application spec validation failed")
+		app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Validation failed: %v", err))
+		return r.updateApplicationStatus(ctx, app)
+	}
+	if err := app.ValidateLimits(r.Config.Limits); err != nil {
+		logger.Info("Application exceeds infrastructure limits", "error", err.Error())
 		app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Validation failed: %v", err))
 		return r.updateApplicationStatus(ctx, app)
 	}
+	if warnings := app.SpecWarnings(); len(warnings) > 0 {
+		summary := strings.Join(warnings, "; ")
+		if r.Config.StrictValidation {
+			logger.Info("Application spec rejected in strict mode", "warnings", summary)
+			app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Validation failed: %s", summary))
+			return r.updateApplicationStatus(ctx, app)
+		}
+		// Warnings only change with the spec, so each generation gets one event
+		if app.Status.SpecWarningGeneration != app.Generation {
+			logger.Info("Application spec has warnings", "warnings", summary)
+			r.recordEvent(app, corev1.EventTypeWarning, "SpecWarning", summary)
+			app.Status.SpecWarningGeneration = app.Generation
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if err := r.ensureTargetNamespace(ctx, app); err != nil {
+		logger.Error(err, "Target namespace unavailable")
+		if isPermanentError(err) {
+			app.UpdateStatus(v1alpha1.PhaseFailed, err.Error())
+			return r.updateApplicationStatus(ctx, app)
+		}
+		r.recordEvent(app, corev1.EventTypeWarning, "NamespaceUnavailable", err.Error())
+		app.UpdateStatus(app.Status.Phase, err.Error())
+		r.updateApplicationStatusOnly(ctx, app)
+		return ctrl.Result{RequeueAfter: r.Config.InfraFailureRequeue}, nil
+	}
+	if err := r.reconcileResourceQuota(ctx, app); err != nil {
+		// Usually transient (a conflict, the API server): keep the phase and retry
+		logger.Error(err, "Failed to apply resource quota")
+		r.recordEvent(app, corev1.EventTypeWarning, "QuotaFailed", err.Error())
+		app.UpdateStatus(app.Status.Phase, err.Error())
+		r.updateApplicationStatusOnly(ctx, app)
+		return ctrl.Result{RequeueAfter: r.Config.InfraFailureRequeue}, nil
+	}
 
 	// Main reconciliation logic
-	return r.reconcileApplication(ctx, app)
+	phaseCtx, span := startSpan(ctx, app, "reconcileApplication")
+	result, err := r.reconcileApplication(phaseCtx, app)
+	endSpan(span, err)
+
+	// Consumers without access to the Application read the mirrored status
+	if cmErr := r.reconcileStatusConfigMap(ctx, app); cmErr != nil {
+		logger.Error(cmErr, "Failed to update status ConfigMap")
+	}
+	return result, err
+}
+
+// pauseApplication records the Paused phase once and does nothing else; the
+// annotation change that resumes it triggers the next reconcile
+func (r *ApplicationController) pauseApplication(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, error) {
+	if app.Status.Phase == v1alpha1.PhasePaused {
+		return ctrl.Result{}, nil
+	}
+
+	appLogger(ctx, app).Info("Reconciliation paused by annotation", "previousPhase", app.Status.Phase)
+	r.recordEvent(app, corev1.EventTypeNormal, "Paused", fmt.Sprintf("Reconciliation paused by %s", v1alpha1.PausedAnnotation))
+	app.Status.PausedPhase = app.Status.Phase
+	app.UpdateStatus(v1alpha1.PhasePaused, fmt.Sprintf("Paused by %s annotation", v1alpha1.PausedAnnotation))
+	if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
 }
 
 // reconcileApplication handles the main application lifecycle with environment awareness
 func (r *ApplicationController) reconcileApplication(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
+	logger := appLogger(ctx, app)
 	
-	// Phase 1: Provision Infrastructure (environment-aware)
-	if app.Status.Phase == "" || app.Status.Phase == v1alpha1.PhasePending {
-		logger.Info("🏗️ Starting environment-aware infrastructure provisioning")
+	// Phase 1: Provision Infrastructure (environment-aware); repeated until the
+	// infrastructure reports ready
+	if app.Status.Phase == "" || app.Status.Phase == v1alpha1.PhasePending ||
+		(app.Status.Phase == v1alpha1.PhaseProvisioningInfra && !app.Status.InfrastructureReady) {
+		// Nothing is provisioned until the Applications this one depends on are Ready
+		if app.Status.Phase == "" || app.Status.Phase == v1alpha1.PhasePending {
+			if result, waiting, err := r.gateOnDependencies(ctx, app); waiting || err != nil {
+				return result, err
+			}
+		}
+		logger.Info("Starting environment-aware infrastructure provisioning")
+		// A fresh provisioning pass (new spec or drift) revisits every component;
+		// retries after a failure only redo the components that are not ready
+		if app.Status.Phase == "" || app.Status.Phase == v1alpha1.PhasePending {
+			app.Status.Components = nil
+		}
 		app.UpdateStatus(v1alpha1.PhaseProvisioningInfra, "Analyzing environment and provisioning infrastructure")
 		
 		if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
@@ -76,157 +250,608 @@ func (r *ApplicationController) reconcileApplication(ctx context.Context, app *v
 		}
 		
 		// Smart infrastructure provisioning
-		if err := r.provisionInfrastructure(ctx, app); err != nil {
-			logger.Error(err, "❌ Infrastructure provisioning failed")
-			app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Infrastructure failed: %v", err))
+		if err := r.traceStep(ctx, app, "provisionInfrastructure", r.provisionInfrastructure); err != nil {
+			if _, ok := asProviderDisabled(err); ok {
+				logger.Info("Application requests disabled infrastructure", "error", err.Error())
+				r.recordEvent(app, corev1.EventTypeWarning, "InfrastructureDisabled", err.Error())
+				app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Infrastructure rejected: %v", err))
+				return r.updateApplicationStatus(ctx, app)
+			}
+			// Retrying cannot fix these; the Application waits for a spec change
+			if isPermanentError(err) {
+				logger.Error(err, "Infrastructure provisioning failed permanently")
+				app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Infrastructure failed: %v", err))
+				return r.updateApplicationStatus(ctx, app)
+			}
+			// Failed components are retried; ready ones are left as they are
+			logger.Error(err, "Infrastructure provisioning failed")
+			app.UpdateStatus(v1alpha1.PhaseProvisioningInfra, fmt.Sprintf("Infrastructure failed: %v", err))
 			r.updateApplicationStatusOnly(ctx, app)
-			return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+			return ctrl.Result{RequeueAfter: r.Config.InfraFailureRequeue}, nil
 		}
 		
 		// Requeue to continue with deployment
-		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		return ctrl.Result{RequeueAfter: r.Config.InfraRequeue}, nil
 	}
 
-	// Phase 2: Deploy Application
-	if app.Status.Phase == v1alpha1.PhaseProvisioningInfra && app.Status.InfrastructureReady {
-		logger.Info("🚀 Starting application deployment")
+	// Phase 2: Deploy Application; a deploy step that stopped on a transient
+	// error runs again without leaving Deploying
+	if (app.Status.Phase == v1alpha1.PhaseProvisioningInfra && app.Status.InfrastructureReady) ||
+		(app.Status.Phase == v1alpha1.PhaseDeploying && app.Status.DeployRetry) {
+		logger.Info("Starting application deployment", "retry", app.Status.DeployRetry)
 		app.UpdateStatus(v1alpha1.PhaseDeploying, "Creating Kubernetes resources")
+		app.Status.SmokeTestFailures = 0
+		app.Status.DeployRetry = false
 		
 		if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
 			return ctrl.Result{}, err
 		}
+
+		// Infrastructure-only Applications stop once the endpoints are published
+		if app.Spec.InfrastructureOnly {
+			if err := r.traceStep(ctx, app, "reconcileConnectionSecret", r.reconcileConnectionSecret); err != nil {
+				return r.handleDeployError(ctx, app, "Connection secret", err)
+			}
+			logger.Info("Infrastructure ready - application is deployed by other tooling")
+			app.UpdateStatus(v1alpha1.PhaseInfrastructureReady, "Infrastructure ready; endpoints published for an externally deployed application")
+			return r.updateApplicationStatus(ctx, app)
+		}
 		
-		// Create Kubernetes Deployment
-		if err := r.createOrUpdateDeployment(ctx, app); err != nil {
-			logger.Error(err, "❌ Failed to create deployment")
-			app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Deployment failed: %v", err))
-			r.updateApplicationStatusOnly(ctx, app)
-			return ctrl.Result{RequeueAfter: time.Minute * 2}, nil
+		// Pin the image to a digest before rendering any pod template
+		r.resolveImageDigest(ctx, app)
+
+		if err := r.traceStep(ctx, app, "reconcileConnectionSecret", r.reconcileConnectionSecret); err != nil {
+			return r.handleDeployError(ctx, app, "Connection secret", err)
+		}
+
+		if err := r.traceStep(ctx, app, "reconcileTLS", r.reconcileTLS); err != nil {
+			return r.handleDeployError(ctx, app, "TLS", err)
+		}
+
+		// Scheduled workloads run to completion and are not exposed through a Service
+		if app.GetKind() == v1alpha1.WorkloadCronJob {
+			if err := r.traceStep(ctx, app, "createOrUpdateCronJob", r.createOrUpdateCronJob); err != nil {
+				return r.handleDeployError(ctx, app, "CronJob", err)
+			}
+			return r.requeueAfterDeploy(ctx, app)
+		}
+
+		// One-shot tasks likewise get a Job and no Service
+		if app.GetKind() == v1alpha1.WorkloadJob {
+			if err := r.traceStep(ctx, app, "createOrUpdateJob", r.createOrUpdateJob); err != nil {
+				return r.handleDeployError(ctx, app, "Job", err)
+			}
+			return r.requeueAfterDeploy(ctx, app)
+		}
+
+		// Stateful apps get a StatefulSet governed by a headless Service
+		if app.GetKind() == v1alpha1.WorkloadStatefulSet {
+			if err := r.traceStep(ctx, app, "createOrUpdateHeadlessService", r.createOrUpdateHeadlessService); err != nil {
+				return r.handleDeployError(ctx, app, "Headless service", err)
+			}
+			if err := r.traceStep(ctx, app, "createOrUpdateAppStatefulSet", r.createOrUpdateAppStatefulSet); err != nil {
+				return r.handleDeployError(ctx, app, "StatefulSet", err)
+			}
+		} else if app.UsesBlueGreen() {
+			if err := r.traceStep(ctx, app, "reconcileBlueGreen", r.reconcileBlueGreen); err != nil {
+				return r.handleDeployError(ctx, app, "Deployment", err)
+			}
+		} else if app.UsesCanary() {
+			if err := r.traceStep(ctx, app, "reconcileCanary", r.reconcileCanary); err != nil {
+				return r.handleDeployError(ctx, app, "Deployment", err)
+			}
+		} else if err := r.traceStep(ctx, app, "createOrUpdateDeployment", r.createOrUpdateDeployment); err != nil {
+			return r.handleDeployError(ctx, app, "Deployment", err)
 		}
 
 		// Create Kubernetes Service
-		if err := r.createOrUpdateService(ctx, app); err != nil {
-			logger.Error(err, "❌ Failed to create service")
-			app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Service failed: %v", err))
-			r.updateApplicationStatusOnly(ctx, app)
-			return ctrl.Result{RequeueAfter: time.Minute * 2}, nil
+		if err := r.traceStep(ctx, app, "createOrUpdateService", r.createOrUpdateService); err != nil {
+			return r.handleDeployError(ctx, app, "Service", err)
+		}
+
+		// Optional headless Service for client-side load balancing and per-pod DNS
+		if app.Spec.HeadlessService && app.GetKind() != v1alpha1.WorkloadStatefulSet {
+			if err := r.traceStep(ctx, app, "createOrUpdateHeadlessService", r.createOrUpdateHeadlessService); err != nil {
+				return r.handleDeployError(ctx, app, "Headless service", err)
+			}
+		}
+
+		if err := r.traceStep(ctx, app, "createOrUpdateIngress", r.createOrUpdateIngress); err != nil {
+			return r.handleDeployError(ctx, app, "Ingress", err)
 		}
 
 		// Requeue to check if deployment is ready
-		return ctrl.Result{RequeueAfter: time.Second * 15}, nil
+		return r.requeueAfterDeploy(ctx, app)
 	}
 
 	// Phase 3: Check if Application is Ready
 	if app.Status.Phase == v1alpha1.PhaseDeploying {
+		// Jobs finish rather than become ready, and may fail for good
+		if app.GetKind() == v1alpha1.WorkloadJob {
+			return r.reconcileJobCompletion(ctx, app)
+		}
+
 		ready, err := r.checkApplicationReady(ctx, app)
 		if err != nil {
-			logger.Error(err, "❌ Failed to check application readiness")
-			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+			logger.Error(err, "Failed to check application readiness")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
 		}
 
 		if ready {
-			logger.Info("✅ Application is ready!")
+			// A configured smoke test must pass before the Application is Ready
+			passed, err := r.checkSmokeTest(ctx, app)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if app.Status.Phase == v1alpha1.PhaseFailed {
+				return r.updateApplicationStatus(ctx, app)
+			}
+			if !passed {
+				return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
+			}
+			logger.Info("Application is ready!")
 			app.UpdateStatus(v1alpha1.PhaseReady, "All replicas ready and serving traffic")
 			return r.updateApplicationStatus(ctx, app)
 		}
 
 		// Still deploying, check again later
-		logger.Info("⏳ Application still deploying...")
-		return ctrl.Result{RequeueAfter: time.Second * 15}, nil
+		logger.Info("Application still deploying...")
+		return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
 	}
 
 	// Application is ready - periodic health check
-	if app.Status.Phase == v1alpha1.PhaseReady {
-		logger.Info("💚 Application healthy - periodic check")
-		return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+	if app.Status.Phase == v1alpha1.PhaseReady || app.Status.Phase == v1alpha1.PhaseInfrastructureReady {
+		// Toggling infrastructureOnly goes back through the deploy step; a
+		// workload created before the switch is left running
+		if (app.Status.Phase == v1alpha1.PhaseInfrastructureReady) != app.Spec.InfrastructureOnly {
+			logger.Info("infrastructureOnly changed - redeploying", "infrastructureOnly", app.Spec.InfrastructureOnly)
+			r.recordEvent(app, corev1.EventTypeNormal, "ModeChanged",
+				fmt.Sprintf("infrastructureOnly set to %t", app.Spec.InfrastructureOnly))
+			app.UpdateStatus(v1alpha1.PhaseProvisioningInfra, "Deployment mode changed")
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		if pruned, err := r.pruneInfrastructure(ctx, app); err != nil {
+			logger.Error(err, "Failed to prune removed infrastructure")
+		} else if len(pruned) > 0 {
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		drift, err := r.detectInfraDrift(ctx, app)
+		if err != nil {
+			logger.Error(err, "Failed to compare infrastructure against spec")
+			if isStorageShrink(err) {
+				r.recordEvent(app, corev1.EventTypeWarning, "StorageShrinkRejected", err.Error())
+			}
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		}
+		now := time.Now()
+		changes, deferred := splitInfraChanges(drift, app.InMaintenanceWindow(now))
+		if !reflect.DeepEqual(deferred, app.Status.DeferredChanges) {
+			if len(deferred) > 0 {
+				summary := strings.Join(deferred, "; ")
+				logger.Info("Disruptive infrastructure changes deferred to the maintenance window", "changes", summary)
+				r.recordEvent(app, corev1.EventTypeNormal, "ChangesDeferred", summary)
+			}
+			app.Status.DeferredChanges = deferred
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if len(changes) > 0 {
+			summary := strings.Join(changes, "; ")
+			logger.Info("Infrastructure spec changed - re-provisioning", "changes", summary)
+			r.recordEvent(app, corev1.EventTypeNormal, "InfrastructureChanged", summary)
+			app.Status.InfrastructureReady = false
+			app.UpdateStatus(v1alpha1.PhasePending, fmt.Sprintf("Applying infrastructure changes: %s", summary))
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		// Database replica changes are stepped, one replica per pass when shrinking
+		if changed, err := r.reconcileDatabaseScale(ctx, app); err != nil {
+			logger.Error(err, "Failed to scale database")
+			r.recordEvent(app, corev1.EventTypeWarning, "ScalingFailed", err.Error())
+		} else if changed {
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Keep the connection Secret in step with the infrastructure; a change
+		// rolls the pods through the config hash
+		if err := r.reconcileConnectionSecret(ctx, app); err != nil {
+			logger.Error(err, "Failed to update connection secret")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		}
+
+		// There is no workload to keep in step
+		if app.Spec.InfrastructureOnly {
+			logger.Info("Infrastructure healthy - periodic check")
+			return ctrl.Result{RequeueAfter: r.readyRequeue(app, now, deferred)}, nil
+		}
+
+		// Templates rebuilt below use the pinned digest; an image changed since
+		// the deploy is resolved first rather than rolled out by its tag
+		resolved := app.Status.ResolvedImage
+		r.resolveImageDigest(ctx, app)
+		if app.Status.ResolvedImage != resolved {
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// TLS changed since the deploy: the proxy config and the Ingress follow
+		// the spec before the workload template does
+		if err := r.reconcileTLS(ctx, app); err != nil {
+			logger.Error(err, "Failed to reconcile TLS")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		}
+		if err := r.createOrUpdateIngress(ctx, app); err != nil {
+			logger.Error(err, "Failed to reconcile ingress")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		}
+		// Port, protocol, type and affinity changes reach the running Service
+		if err := r.reconcileServices(ctx, app); err != nil {
+			logger.Error(err, "Failed to reconcile service")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		}
+
+		// Out-of-band edits to the workload are reverted to the spec
+		if corrected, err := r.correctWorkloadDrift(ctx, app); err != nil {
+			logger.Error(err, "Failed to correct workload drift")
+			return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+		} else if len(corrected) > 0 {
+			r.reportDrift(ctx, app, corrected)
+			// A re-run Job is tracked to completion again
+			if app.GetKind() == v1alpha1.WorkloadJob {
+				app.UpdateStatus(v1alpha1.PhaseDeploying, "Re-running the Job with changed configuration")
+				return r.requeueAfterDeploy(ctx, app)
+			}
+		}
+
+		// Volume claim templates are immutable, so a larger appStorage is applied
+		// by growing each replica's claim
+		if app.InMaintenanceWindow(now) {
+			if err := r.reconcileAppStorage(ctx, app); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Blue/green rollouts of a changed spec progress while the active color keeps serving
+		if app.UsesBlueGreen() {
+			active, preview, message := app.Status.ActiveColor, app.Status.PreviewColor, app.Status.Message
+			if err := r.reconcileBlueGreen(ctx, app); err != nil {
+				logger.Error(err, "Blue/green rollout failed")
+				return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+			}
+			if active != app.Status.ActiveColor || preview != app.Status.PreviewColor || message != app.Status.Message {
+				if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			if app.Status.PreviewColor != "" {
+				return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
+			}
+		}
+
+		// Canaries advance (or get promoted) while the application stays Ready
+		if app.UsesCanary() {
+			weight, message := app.Status.CanaryWeight, app.Status.Message
+			if err := r.reconcileCanary(ctx, app); err != nil {
+				logger.Error(err, "Canary rollout failed")
+				return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+			}
+			if weight != app.Status.CanaryWeight || message != app.Status.Message {
+				if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			if app.Status.CanaryWeight > 0 {
+				return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
+			}
+		}
+
+		logger.Info("Application healthy - periodic check")
+		return ctrl.Result{RequeueAfter: r.readyRequeue(app, now, deferred)}, nil
 	}
 
-	logger.Info("🤔 Unknown phase", "phase", app.Status.Phase)
-	return ctrl.Result{RequeueAfter: time.Minute}, nil
+	logger.Info("Unknown phase")
+	return ctrl.Result{RequeueAfter: r.Config.UnknownPhaseRequeue}, nil
+}
+
+// readyRequeue is the interval of the periodic check, shortened to come back
+// when the maintenance window opens if changes were deferred to it
+func (r *ApplicationController) readyRequeue(app *v1alpha1.Application, now time.Time, deferred []string) time.Duration {
+	requeue := r.Config.ReadyRequeue
+	if w := app.Spec.Infrastructure.MaintenanceWindow; w != nil && len(deferred) > 0 {
+		if wait := w.NextOpen(now).Sub(now); wait > 0 && wait < requeue {
+			requeue = wait
+		}
+	}
+	return requeue
 }
 
 // provisionInfrastructure handles environment-aware resource provisioning
 func (r *ApplicationController) provisionInfrastructure(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	
-	// Provision PostgreSQL
-	if app.NeedsDatabase() {
-		if app.IsLocalDatabase() {
-			logger.Info("🏠 Provisioning local PostgreSQL")
-			if err := r.provisionLocalPostgreSQL(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision local PostgreSQL: %w", err)
-			}
-			logger.Info("✅ Local PostgreSQL provisioned", "endpoint", app.Status.DatabaseEndpoint)
-		} else {
-			if err := r.provisionAWSPostgreSQL(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision AWS PostgreSQL: %w", err)
-			}
+	logger := appLogger(ctx, app)
+
+	// Components the controller is configured not to provision fail the
+	// Application before anything is created
+	if err := r.checkProviders(app); err != nil {
+		return err
+	}
+
+	// Each component is provisioned on its own: a failure is recorded against
+	// that component only, and components already ready are skipped on retry
+	components := []struct {
+		name      string
+		needed    bool
+		provision func(context.Context, *v1alpha1.Application) error
+	}{
+		{componentDatabase, app.NeedsDatabase(), r.provisionDatabase},
+		{componentCache, app.NeedsCache(), r.provisionCache},
+		{componentStorage, app.NeedsStorage(), r.provisionStorage},
+		{componentNoSQL, app.NeedsDynamoDB(), r.provisionNoSQL},
+		{componentQueue, app.NeedsQueue(), r.provisionQueue},
+		{componentStreaming, app.NeedsStreaming(), r.provisionStreaming},
+	}
+	var failed []error
+	failedComponents := map[string]bool{}
+	pendingComponents := map[string]string{}
+	for _, c := range components {
+		if !c.needed {
+			app.Status.RemoveComponentStatus(c.name)
+			app.Status.RemoveAWSResource(c.name)
+			continue
+		}
+		if app.Status.ComponentReady(c.name) {
+			componentLogger(ctx, app, c.name).V(1).Info("Component already ready - skipping")
+			continue
+		}
+		start := time.Now()
+		err := r.traceStep(ctx, app, "provision/"+c.name, c.provision, attrComponent.String(c.name))
+		if pending, ok := asAWSPending(err); ok {
+			// Requested from AWS and not available yet: wait, don't fail
+			app.Status.SetComponentStatus(c.name, false, pending.Error())
+			pendingComponents[c.name] = pending.Error()
+			continue
+		}
+		observeProvisioning(c.name, start, err)
+		if err != nil {
+			componentLogger(ctx, app, c.name).Error(err, "Component provisioning failed")
+			r.recordEvent(app, corev1.EventTypeWarning, "ComponentFailed", fmt.Sprintf("%s: %v", c.name, err))
+			app.Status.SetComponentStatus(c.name, false, err.Error())
+			failed = append(failed, fmt.Errorf("%s: %w", c.name, err))
+			failedComponents[c.name] = true
 		}
 	}
 	
-	// Provision Redis
-	if app.NeedsCache() {
-		if app.IsLocalRedis() {
-			logger.Info("🏠 Provisioning local Redis")
-			if err := r.provisionLocalRedis(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision local Redis: %w", err)
-			}
-			logger.Info("✅ Local Redis provisioned", "endpoint", app.Status.RedisEndpoint)
-		} else {
-			if err := r.provisionAWSRedis(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision AWS Redis: %w", err)
-			}
-		}
+	// Management UIs for local development (never for AWS components)
+	if err := r.provisionDevTools(ctx, app); err != nil {
+		return fmt.Errorf("failed to provision dev tools: %w", err)
 	}
 	
-	// Provision S3/Storage
-	if app.NeedsStorage() {
-		if app.IsLocalS3() {
-			logger.Info("🏠 Provisioning local S3 (MinIO)")
-			if err := r.provisionLocalS3(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision local S3 (MinIO): %w", err)
-			}
-			logger.Info("✅ Local S3 provisioned", "endpoint", app.Status.S3Endpoint)
-		} else {
-			if err := r.provisionAWSS3(ctx, app); err != nil {
-				return fmt.Errorf("failed to provision AWS S3: %w", err)
-			}
+	// Remove resources of components that were dropped from the spec
+	if _, err := r.pruneInfrastructure(ctx, app); err != nil {
+		logger.Error(err, "Failed to prune removed infrastructure")
+	}
+	
+	// Only mark infrastructure ready once the local pods are actually serving
+	unready, err := r.unreadyInfrastructure(ctx, app)
+	if err != nil {
+		return err
+	}
+	for _, c := range components {
+		if message, ok := pendingComponents[c.name]; ok {
+			unready = append(unready, fmt.Sprintf("%s (%s)", c.name, message))
 		}
 	}
+	waiting := map[string]bool{}
+	for _, component := range unready {
+		waiting[component] = true
+	}
+	for _, c := range components {
+		switch {
+		case !c.needed || failedComponents[c.name] || pendingComponents[c.name] != "":
+		case waiting[c.name]:
+			app.Status.SetComponentStatus(c.name, false, "Waiting for pods to become ready")
+		default:
+			app.Status.SetComponentStatus(c.name, true, "Ready")
+		}
+	}
+	if len(failed) > 0 {
+		app.Status.InfrastructureReady = false
+		return stderrors.Join(failed...)
+	}
+	if len(unready) > 0 {
+		app.Status.InfrastructureReady = false
+		app.Status.Message = fmt.Sprintf("Waiting for infrastructure: %s", strings.Join(unready, ", "))
+		logger.Info("Infrastructure not ready yet", "waitingFor", unready)
+		if err := r.writeStatus(ctx, app); err != nil {
+			return fmt.Errorf("failed to update infrastructure status: %w", err)
+		}
+		return nil
+	}
 	
 	// CRITICAL: Mark infrastructure as ready and update status immediately
 	app.Status.InfrastructureReady = true
-	logger.Info("✅ All infrastructure provisioned - updating status")
+	logger.Info("All infrastructure provisioned - updating status")
 	
 	// Update status in Kubernetes
-	if err := r.Status().Update(ctx, app); err != nil {
+	if err := r.writeStatus(ctx, app); err != nil {
 		logger.Error(err, "Failed to update infrastructure status")
 		return fmt.Errorf("failed to update infrastructure status: %w", err)
 	}
 	
-	logger.Info("🎉 Infrastructure provisioning complete and status updated")
+	logger.Info("Infrastructure provisioning complete and status updated")
+	return nil
+}
+
+// provisionDatabase provisions PostgreSQL locally or on AWS, or records an external one
+func (r *ApplicationController) provisionDatabase(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentDatabase)
+
+	if app.IsExternalDatabase() {
+		// User-managed database - nothing to provision, just record where it lives
+		app.Status.DatabaseEndpoint = app.Spec.Infrastructure.PostgreSQL.External.Endpoint
+		app.Status.DatabaseReadEndpoint = app.Spec.Infrastructure.PostgreSQL.External.ReadEndpoint
+		app.Status.DatabaseEnvironment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external PostgreSQL", "endpoint", app.Status.DatabaseEndpoint)
+	} else if app.IsLocalDatabase() {
+		logger.Info("Provisioning local PostgreSQL")
+		if err := r.provisionLocalPostgreSQL(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local PostgreSQL: %w", err)
+		}
+		logger.Info("Local PostgreSQL provisioned", "endpoint", app.Status.DatabaseEndpoint)
+	} else {
+		if err := r.provisionAWSPostgreSQL(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision AWS PostgreSQL: %w", err)
+		}
+	}
+	return nil
+}
+
+// provisionCache provisions Redis locally or on AWS, or records an external one
+func (r *ApplicationController) provisionCache(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentCache)
+
+	if app.IsExternalRedis() {
+		app.Status.RedisEndpoint = app.Spec.Infrastructure.Redis.Endpoint
+		app.Status.RedisEnvironment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external Redis", "endpoint", app.Status.RedisEndpoint)
+	} else if app.IsLocalRedis() {
+		logger.Info("Provisioning local Redis")
+		if err := r.provisionLocalRedis(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local Redis: %w", err)
+		}
+		logger.Info("Local Redis provisioned", "endpoint", app.Status.RedisEndpoint)
+	} else {
+		if err := r.provisionAWSRedis(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision AWS Redis: %w", err)
+		}
+	}
+	return nil
+}
+
+// provisionStorage provisions an S3 bucket (MinIO locally), or records an external one
+func (r *ApplicationController) provisionStorage(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentStorage)
+
+	if app.IsExternalS3() {
+		app.Status.S3BucketName = app.Spec.Infrastructure.S3.BucketName
+		app.Status.S3Endpoint = app.Spec.Infrastructure.S3.Endpoint
+		app.Status.S3Environment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external S3", "bucket", app.Status.S3BucketName)
+	} else if app.IsLocalS3() {
+		logger.Info("Provisioning local S3 (MinIO)")
+		if err := r.provisionLocalS3(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local S3 (MinIO): %w", err)
+		}
+		logger.Info("Local S3 provisioned", "endpoint", app.Status.S3Endpoint)
+	} else {
+		if err := r.provisionAWSS3(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision AWS S3: %w", err)
+		}
+	}
+	return nil
+}
+
+// provisionNoSQL provisions a DynamoDB table locally or on AWS, or records an external one
+func (r *ApplicationController) provisionNoSQL(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentNoSQL)
+
+	if app.IsExternalDynamoDB() {
+		app.Status.DynamoDBTableName = app.GetDynamoDBTableName()
+		app.Status.DynamoDBEnvironment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external DynamoDB", "table", app.Status.DynamoDBTableName)
+	} else if app.IsLocalDynamoDB() {
+		logger.Info("Provisioning local DynamoDB")
+		if err := r.provisionLocalDynamoDB(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local DynamoDB: %w", err)
+		}
+	} else {
+		if err := r.provisionAWSDynamoDB(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision AWS DynamoDB: %w", err)
+		}
+	}
+	return nil
+}
+
+// provisionQueue provisions an SQS queue (ElasticMQ locally), or records an external one
+func (r *ApplicationController) provisionQueue(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentQueue)
+
+	if app.IsExternalSQS() {
+		app.Status.SQSQueueName = app.GetSQSQueueName()
+		app.Status.SQSEnvironment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external SQS", "queue", app.Status.SQSQueueName)
+	} else if app.IsLocalSQS() {
+		logger.Info("Provisioning local SQS (ElasticMQ)")
+		if err := r.provisionLocalSQS(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local SQS (ElasticMQ): %w", err)
+		}
+	} else {
+		if err := r.provisionAWSSQS(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision AWS SQS: %w", err)
+		}
+	}
+	return nil
+}
+
+// provisionStreaming runs Kafka locally or records an external cluster
+func (r *ApplicationController) provisionStreaming(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentStreaming)
+
+	if app.IsExternalKafka() {
+		app.Status.KafkaBrokers = app.Spec.Infrastructure.Kafka.Brokers
+		app.Status.KafkaEnvironment = v1alpha1.EnvironmentExternal
+		logger.Info("Using external Kafka", "brokers", app.Status.KafkaBrokers)
+	} else if app.IsLocalKafka() {
+		logger.Info("Provisioning local Kafka")
+		if err := r.provisionLocalKafka(ctx, app); err != nil {
+			return fmt.Errorf("failed to provision local Kafka: %w", err)
+		}
+	} else {
+		return permanent(fmt.Errorf("managed Kafka is not supported in AWS; set kafka.environment to local or external"))
+	}
 	return nil
 }
 
 // provisionLocalPostgreSQL creates a local PostgreSQL with persistent storage
 func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("🏠 Creating local PostgreSQL with persistent storage")
+	logger := componentLogger(ctx, app, componentDatabase)
+	logger.Info("Creating local PostgreSQL with persistent storage")
 	
-	// Step 1: Create Persistent Volume Claim
-	storageSize := "2Gi" // Default
-	if app.Spec.Infrastructure.PostgreSQL.LocalStorage != "" {
-		storageSize = app.Spec.Infrastructure.PostgreSQL.LocalStorage
+	// Step 1: Create Persistent Volume Claim, or take over the one a previous
+	// Application with the same identity left behind
+	storageSize := app.GetDatabaseStorage()
+	claimName, err := r.adoptDatabaseClaim(ctx, app)
+	if err != nil {
+		return err
+	}
+	if claimName == "" {
+		claimName = app.GetPostgresClaimName()
+	}
+	if err := r.checkDatabaseStorageShrink(ctx, app, claimName); err != nil {
+		r.recordEvent(app, corev1.EventTypeWarning, "StorageShrinkRejected", err.Error())
+		return err
 	}
 	
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres-pvc", app.Name),
-			Namespace: app.Namespace,
+			Name:      claimName,
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "database", "managed-by": "orion-platform"},
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
-			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			AccessModes: []corev1.PersistentVolumeAccessMode{app.GetDatabaseAccessMode()},
 			Resources: corev1.ResourceRequirements{
 				Requests: corev1.ResourceList{
 					corev1.ResourceStorage: resource.MustParse(storageSize),
@@ -234,41 +859,63 @@ func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, ap
 			},
 		},
 	}
+	if class := app.Spec.Infrastructure.PostgreSQL.StorageClass; class != "" {
+		pvc.Spec.StorageClassName = &class
+	}
 	
-	if err := r.Create(ctx, pvc); err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create PostgreSQL PVC: %w", err)
+	setIdentityLabel(app, pvc)
+
+	// Deliberately not owned by the Application so deleting it never deletes the data
+	if err := r.createTracked(ctx, app, pvc); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create PostgreSQL PVC: %w", err)
+		}
+		// Claims from before the identity annotation was added get labeled too
+		if err := r.ensureIdentityLabel(ctx, app, pvc); err != nil {
+			return err
+		}
+		// Grow the existing claim if a larger size was requested, within the
+		// maintenance window
+		if app.InMaintenanceWindow(time.Now()) {
+			if err := r.expandPVC(ctx, pvc); err != nil {
+				return err
+			}
+		}
 	}
 	
 	// Step 2: Create StatefulSet with persistent storage
-	dbName := "webapp"
-	if app.Spec.Infrastructure.PostgreSQL.DatabaseName != "" {
-		dbName = app.Spec.Infrastructure.PostgreSQL.DatabaseName
-	}
+	dbName := app.GetDatabaseName()
 	
 	postgres := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetPostgresName(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "database", "managed-by": "orion-platform"},
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: &[]int32{1}[0],
+			Replicas: &[]int32{app.GetLocalDatabaseReplicas()}[0],
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{"app": app.Name, "component": "database"},
 			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: app.GetDatabaseUpdateStrategy()},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"app": app.Name, "component": "database"},
 				},
 				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(app.Spec.Infrastructure.PostgreSQL.NodeSelector),
+					Tolerations:  r.withSpotToleration(infraTolerations(app), app.Spec.Infrastructure.PostgreSQL.Preemptible),
+					Affinity:     r.infraAffinity(app.Spec.Infrastructure.PostgreSQL.Preemptible),
+					// The data directory must be writable by the image's postgres user
+					SecurityContext: infraPodSecurityContext(postgresFSGroup),
 					Containers: []corev1.Container{
 						{
 							Name:  "postgres",
-							Image: fmt.Sprintf("postgres:%s", app.Spec.Infrastructure.PostgreSQL.Version),
+							Image: app.InfraImage(fmt.Sprintf("postgres:%s", app.Spec.Infrastructure.PostgreSQL.Version)),
 							Env: []corev1.EnvVar{
 								{Name: "POSTGRES_DB", Value: dbName},
-								{Name: "POSTGRES_USER", Value: "appuser"},
-								{Name: "POSTGRES_PASSWORD", Value: "localpassword"},
+								{Name: "POSTGRES_USER", Value: v1alpha1.LocalDatabaseUser},
+								{Name: "POSTGRES_PASSWORD", Value: v1alpha1.LocalDatabasePassword},
 								{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
 							},
 							Ports: []corev1.ContainerPort{{ContainerPort: 5432}},
@@ -285,7 +932,7 @@ func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, ap
 							Name: "postgres-data",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: fmt.Sprintf("%s-postgres-pvc", app.Name),
+									ClaimName: claimName,
 								},
 							},
 						},
@@ -295,15 +942,48 @@ func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, ap
 		},
 	}
 	
-	if err := r.Create(ctx, postgres); err != nil && !errors.IsAlreadyExists(err) {
+	// Seed schema and roles on first boot
+	initConfigMap, err := r.ensurePostgresInitConfigMap(ctx, app)
+	if err != nil {
+		return err
+	}
+	if initConfigMap != "" {
+		volume, mount := postgresInitVolume(initConfigMap)
+		podSpec := &postgres.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, volume)
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, mount)
+	}
+	exporter := app.Spec.Infrastructure.PostgreSQL.Exporter
+	if exporter {
+		podSpec := &postgres.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, postgresExporterContainer(app))
+	}
+
+	// Tuned server settings are passed as server arguments
+	applyPostgresConfig(postgres, app.Spec.Infrastructure.PostgreSQL.Parameters)
+	
+	if err := r.createOrRecreateStatefulSet(ctx, app, postgres); err != nil {
 		return fmt.Errorf("failed to create PostgreSQL StatefulSet: %w", err)
 	}
+	if err := r.updatePostgresStrategy(ctx, postgres); err != nil {
+		return err
+	}
+	// Applying changed parameters restarts the database, so it waits for the
+	// maintenance window; with OnDelete the pods keep running until deleted
+	if app.InMaintenanceWindow(time.Now()) {
+		if err := r.updatePostgresConfig(ctx, app, postgres); err != nil {
+			return err
+		}
+		if err := r.updatePostgresExporter(ctx, postgres); err != nil {
+			return err
+		}
+	}
 	
 	// Step 3: Create Service for database access
 	dbService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetPostgresName(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "database", "managed-by": "orion-platform"},
 		},
 		Spec: corev1.ServiceSpec{
@@ -318,15 +998,34 @@ func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, ap
 		},
 	}
 	
-	if err := r.Create(ctx, dbService); err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create PostgreSQL Service: %w", err)
+	if exporter {
+		exposeExporter(dbService, "postgres", v1alpha1.PostgresExporterPort)
+	}
+	
+	if err := r.createOwned(ctx, app, dbService); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create PostgreSQL Service: %w", err)
+		}
+		if err := r.updateInfraServicePorts(ctx, dbService); err != nil {
+			return err
+		}
 	}
 	
 	// Update application status
-	app.Status.DatabaseEndpoint = fmt.Sprintf("%s-postgres:5432", app.Name)
+	app.Status.DatabaseEndpoint = fmt.Sprintf("%s:5432", app.GetPostgresName())
 	app.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+	if err := r.provisionPostgresReadService(ctx, app); err != nil {
+		return err
+	}
+
+	app.Status.DatabasePoolerEndpoint = ""
+	if app.UsesPooler() {
+		if err := r.provisionPgBouncer(ctx, app); err != nil {
+			return err
+		}
+	}
 	
-	logger.Info("✅ Local PostgreSQL created", 
+	logger.Info("Local PostgreSQL created", 
 		"endpoint", app.Status.DatabaseEndpoint,
 		"storage", storageSize,
 		"database", dbName)
@@ -334,15 +1033,47 @@ func (r *ApplicationController) provisionLocalPostgreSQL(ctx context.Context, ap
 	return nil
 }
 
+// provisionPostgresReadService gives a local database with readReplicas its
+// own read endpoint. Without replication the Service selects the primary, but
+// applications that split reads see the same DATABASE_READ_URL as on AWS.
+func (r *ApplicationController) provisionPostgresReadService(ctx context.Context, app *v1alpha1.Application) error {
+	app.Status.DatabaseReadEndpoint = ""
+	if app.GetDatabaseReadReplicas() == 0 {
+		return nil
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetPostgresReadName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": componentDatabaseRead, "managed-by": "orion-platform"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": app.Name, "component": componentDatabase},
+			Ports: []corev1.ServicePort{
+				{
+					Port:       5432,
+					TargetPort: intstr.FromInt(5432),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	if err := r.createOwned(ctx, app, service); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PostgreSQL read Service: %w", err)
+	}
+	app.Status.DatabaseReadEndpoint = fmt.Sprintf("%s:5432", service.Name)
+	return nil
+}
+
 // provisionLocalRedis creates a local Redis instance
 func (r *ApplicationController) provisionLocalRedis(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("🏠 Creating local Redis")
+	logger := componentLogger(ctx, app, componentCache)
+	logger.Info("Creating local Redis")
 	
 	redis := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-redis", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetRedisName(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "cache", "managed-by": "orion-platform"},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -355,10 +1086,14 @@ func (r *ApplicationController) provisionLocalRedis(ctx context.Context, app *v1
 					Labels: map[string]string{"app": app.Name, "component": "cache"},
 				},
 				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(app.Spec.Infrastructure.Redis.NodeSelector),
+					Tolerations:  r.withSpotToleration(infraTolerations(app), app.Spec.Infrastructure.Redis.Preemptible),
+					Affinity:     r.infraAffinity(app.Spec.Infrastructure.Redis.Preemptible),
 					Containers: []corev1.Container{
 						{
 							Name:  "redis",
-							Image: fmt.Sprintf("redis:%s", app.Spec.Infrastructure.Redis.Version),
+							Image: app.InfraImage(fmt.Sprintf("redis:%s", app.Spec.Infrastructure.Redis.Version)),
+							Args:  redisArgs(app),
 							Ports: []corev1.ContainerPort{{ContainerPort: 6379}},
 						},
 					},
@@ -366,16 +1101,33 @@ func (r *ApplicationController) provisionLocalRedis(ctx context.Context, app *v1
 			},
 		},
 	}
+	exporter := app.Spec.Infrastructure.Redis.Exporter
+	if exporter {
+		podSpec := &redis.Spec.Template.Spec
+		podSpec.Containers = append(podSpec.Containers, redisExporterContainer(app))
+	}
 	
-	if err := r.Create(ctx, redis); err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create Redis Deployment: %w", err)
+	if err := r.createOwned(ctx, app, redis); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Redis Deployment: %w", err)
+		}
+		// Apply memory setting and exporter changes to the running Redis; this
+		// restarts it, so it waits for the maintenance window
+		if app.InMaintenanceWindow(time.Now()) {
+			if err := r.updateRedisArgs(ctx, redis); err != nil {
+				return err
+			}
+			if err := r.updateRedisExporter(ctx, redis); err != nil {
+				return err
+			}
+		}
 	}
 	
 	// Create Redis Service
 	redisService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-redis", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetRedisName(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "cache", "managed-by": "orion-platform"},
 		},
 		Spec: corev1.ServiceSpec{
@@ -390,27 +1142,67 @@ func (r *ApplicationController) provisionLocalRedis(ctx context.Context, app *v1
 		},
 	}
 	
-	if err := r.Create(ctx, redisService); err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create Redis Service: %w", err)
+	if exporter {
+		exposeExporter(redisService, "redis", v1alpha1.RedisExporterPort)
+	}
+	
+	if err := r.createOwned(ctx, app, redisService); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Redis Service: %w", err)
+		}
+		if err := r.updateInfraServicePorts(ctx, redisService); err != nil {
+			return err
+		}
 	}
 	
 	// Update application status
-	app.Status.RedisEndpoint = fmt.Sprintf("%s-redis:6379", app.Name)
+	app.Status.RedisEndpoint = fmt.Sprintf("%s:6379", app.GetRedisName())
 	app.Status.RedisEnvironment = v1alpha1.EnvironmentLocal
 	
-	logger.Info("✅ Local Redis created", "endpoint", app.Status.RedisEndpoint)
+	logger.Info("Local Redis created", "endpoint", app.Status.RedisEndpoint)
 	return nil
 }
 
 // provisionLocalS3 creates a local MinIO (S3-compatible) instance
 func (r *ApplicationController) provisionLocalS3(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("🏠 Creating local S3 (MinIO)")
-	
+	logger := componentLogger(ctx, app, componentStorage)
+	logger.Info("Creating local S3 (MinIO)")
+
+	// With localStorage the objects live on a claim that, like the database
+	// volume, is not owned by the Application; the deletion policy decides
+	// whether the cleanup finalizer removes it
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	if size := app.Spec.Infrastructure.S3.LocalStorage; size != "" {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      app.GetS3ClaimName(),
+				Namespace: app.GetTargetNamespace(),
+				Labels:    map[string]string{"app": app.Name, "component": "storage", "managed-by": "orion-platform"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+		if err := r.createTracked(ctx, app, pvc); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create MinIO PVC: %w", err)
+		}
+		volumes = []corev1.Volume{{
+			Name: "s3-data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: app.GetS3ClaimName()},
+			},
+		}}
+		mounts = []corev1.VolumeMount{{Name: "s3-data", MountPath: "/data"}}
+	}
+
 	minio := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-s3", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetS3Name(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "storage", "managed-by": "orion-platform"},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -423,36 +1215,41 @@ func (r *ApplicationController) provisionLocalS3(ctx context.Context, app *v1alp
 					Labels: map[string]string{"app": app.Name, "component": "storage"},
 				},
 				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(app.Spec.Infrastructure.S3.NodeSelector),
+					Tolerations:  r.withSpotToleration(infraTolerations(app), app.Spec.Infrastructure.S3.Preemptible),
+					Affinity:     r.infraAffinity(app.Spec.Infrastructure.S3.Preemptible),
 					Containers: []corev1.Container{
 						{
 							Name:    "minio",
-							Image:   "minio/minio:latest",
+							Image:   app.InfraImage(app.GetMinIOImage()),
 							Command: []string{"/usr/bin/docker-entrypoint.sh"},
 							Args:    []string{"server", "/data", "--console-address", ":9001"},
 							Env: []corev1.EnvVar{
-								{Name: "MINIO_ROOT_USER", Value: "minioadmin"},
-								{Name: "MINIO_ROOT_PASSWORD", Value: "minioadmin"},
+								{Name: "MINIO_ROOT_USER", Value: v1alpha1.LocalS3AccessKey},
+								{Name: "MINIO_ROOT_PASSWORD", Value: v1alpha1.LocalS3SecretKey},
 							},
 							Ports: []corev1.ContainerPort{
 								{ContainerPort: 9000}, // API
 								{ContainerPort: 9001}, // Console
 							},
+							VolumeMounts: mounts,
 						},
 					},
+					Volumes: volumes,
 				},
 			},
 		},
 	}
 	
-	if err := r.Create(ctx, minio); err != nil && !errors.IsAlreadyExists(err) {
+	if err := r.createOwned(ctx, app, minio); err != nil && !errors.IsAlreadyExists(err) {
 		return fmt.Errorf("failed to create MinIO Deployment: %w", err)
 	}
 	
 	// Create MinIO Service
 	minioService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-s3", app.Name),
-			Namespace: app.Namespace,
+			Name:      app.GetS3Name(),
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "component": "storage", "managed-by": "orion-platform"},
 		},
 		Spec: corev1.ServiceSpec{
@@ -474,56 +1271,142 @@ func (r *ApplicationController) provisionLocalS3(ctx context.Context, app *v1alp
 		},
 	}
 	
-	if err := r.Create(ctx, minioService); err != nil && !errors.IsAlreadyExists(err) {
+	if err := r.createOwned(ctx, app, minioService); err != nil && !errors.IsAlreadyExists(err) {
 		return fmt.Errorf("failed to create MinIO Service: %w", err)
 	}
 	
-	// Update application status
 	bucketName := "default-bucket"
 	if app.Spec.Infrastructure.S3.BucketName != "" {
 		bucketName = app.Spec.Infrastructure.S3.BucketName
 	}
 	
+	// Create the bucket once MinIO accepts connections. The bucket name comes
+	// from the spec, so it reaches the script through the environment rather
+	// than the script text.
+	endpoint := fmt.Sprintf("http://%s:9000", app.GetS3Name())
+	script := fmt.Sprintf(`until mc alias set local %s %s %s; do sleep 2; done && mc mb --ignore-existing "local/$BUCKET"`,
+		endpoint, v1alpha1.LocalS3AccessKey, v1alpha1.LocalS3SecretKey)
+	if app.Spec.Infrastructure.S3.Versioning {
+		script += ` && mc version enable "local/$BUCKET"`
+	}
+	
+	bucketJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetS3BucketJobName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": "storage", "managed-by": "orion-platform"},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{6}[0],
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": app.Name, "component": "storage-setup"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeSelector:  r.infraNodeSelector(app.Spec.Infrastructure.S3.NodeSelector),
+					Tolerations:   infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:    "mc",
+							Image:   app.InfraImage(app.GetMinIOClientImage()),
+							Command: []string{"/bin/sh", "-c", script},
+							Env:     []corev1.EnvVar{{Name: "BUCKET", Value: bucketName}},
+						},
+					},
+				},
+			},
+		},
+	}
+	
+	if err := r.createOwned(ctx, app, bucketJob); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create MinIO bucket Job: %w", err)
+	}
+	
+	// Update application status
 	app.Status.S3BucketName = bucketName
-	app.Status.S3Endpoint = fmt.Sprintf("%s-s3:9000", app.Name)
+	app.Status.S3Endpoint = fmt.Sprintf("%s:9000", app.GetS3Name())
 	app.Status.S3Environment = v1alpha1.EnvironmentLocal
 	
-	logger.Info("✅ Local S3 (MinIO) created", 
+	logger.Info("Local S3 (MinIO) created", 
 		"endpoint", app.Status.S3Endpoint,
 		"bucket", bucketName,
-		"console", fmt.Sprintf("%s-s3:9001", app.Name))
+		"console", fmt.Sprintf("%s:9001", app.GetS3Name()))
 	
 	return nil
 }
 
 // AWS provisioning methods (simulated for now)
+
+// provisionAWSPostgreSQL requests the RDS instance on the first pass and polls
+// it on later ones, keeping the identifier, start time and attempt count in
+// status until the instance is available
 func (r *ApplicationController) provisionAWSPostgreSQL(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("☁️ Simulating AWS RDS PostgreSQL provisioning")
-	
-	// TODO: Real AWS RDS API calls
-	app.Status.DatabaseEndpoint = fmt.Sprintf("%s-db.cluster-xyz.us-west-2.rds.amazonaws.com", app.Name)
+	logger := componentLogger(ctx, app, componentDatabase)
+	aws := r.awsClient()
+	pg := app.Spec.Infrastructure.PostgreSQL
+
+	tracked := app.Status.AWSResource(componentDatabase)
+	if tracked == nil {
+		id, err := aws.CreateDBInstance(ctx, DBInstance{
+			Identifier:    fmt.Sprintf("%s-db", app.Name),
+			EngineVersion: pg.Version,
+			InstanceClass: pg.InstanceType,
+			StorageGB:     pg.Storage,
+			DatabaseName:  app.GetDatabaseName(),
+			ReadReplicas:  pg.ReadReplicas,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create RDS instance: %w", err)
+		}
+		app.Status.AWSResources = append(app.Status.AWSResources, v1alpha1.AWSResourceStatus{
+			Component:  componentDatabase,
+			ResourceID: id,
+			StartedAt:  metav1.Now(),
+		})
+		tracked = app.Status.AWSResource(componentDatabase)
+		logger.Info("Requested AWS RDS PostgreSQL", "id", id)
+	}
+
+	tracked.PollAttempts++
+	instance, err := aws.DescribeDBInstance(ctx, tracked.ResourceID)
+	if err != nil {
+		return fmt.Errorf("failed to describe RDS instance %s: %w", tracked.ResourceID, err)
+	}
+	tracked.State = instance.State
+	if instance.State != awsStateAvailable {
+		logger.Info("Waiting for AWS RDS PostgreSQL", "id", tracked.ResourceID, "state", instance.State, "attempt", tracked.PollAttempts)
+		return &awsPendingError{service: "RDS", state: instance.State, attempt: tracked.PollAttempts}
+	}
+
+	app.Status.DatabaseEndpoint = instance.Endpoint
+	app.Status.DatabaseReadEndpoint = ""
+	if pg.ReadReplicas > 0 {
+		app.Status.DatabaseReadEndpoint = instance.ReaderEndpoint
+	}
 	app.Status.DatabaseEnvironment = v1alpha1.EnvironmentAWS
-	
-	logger.Info("✅ AWS RDS PostgreSQL simulated", "endpoint", app.Status.DatabaseEndpoint)
+
+	logger.Info("AWS RDS PostgreSQL available", "endpoint", app.Status.DatabaseEndpoint,
+		"attempts", tracked.PollAttempts, "elapsed", time.Since(tracked.StartedAt.Time).Round(time.Second))
+	app.Status.RemoveAWSResource(componentDatabase)
 	return nil
 }
 
 func (r *ApplicationController) provisionAWSRedis(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("☁️ Simulating AWS ElastiCache Redis provisioning")
+	logger := componentLogger(ctx, app, componentCache)
+	logger.Info("Simulating AWS ElastiCache Redis provisioning")
 	
 	// TODO: Real AWS ElastiCache API calls
 	app.Status.RedisEndpoint = fmt.Sprintf("%s-cache.xyz.cache.amazonaws.com", app.Name)
 	app.Status.RedisEnvironment = v1alpha1.EnvironmentAWS
 	
-	logger.Info("✅ AWS ElastiCache Redis simulated", "endpoint", app.Status.RedisEndpoint)
+	logger.Info("AWS ElastiCache Redis simulated", "endpoint", app.Status.RedisEndpoint)
 	return nil
 }
 
 func (r *ApplicationController) provisionAWSS3(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	logger.Info("☁️ Simulating AWS S3 provisioning")
+	logger := componentLogger(ctx, app, componentStorage)
+	logger.Info("Simulating AWS S3 provisioning")
 	
 	// TODO: Real AWS S3 API calls
 	bucketName := fmt.Sprintf("%s-storage-%d", app.Name, time.Now().Unix())
@@ -534,12 +1417,33 @@ func (r *ApplicationController) provisionAWSS3(ctx context.Context, app *v1alpha
 	app.Status.S3BucketName = bucketName
 	app.Status.S3Environment = v1alpha1.EnvironmentAWS
 	
-	logger.Info("✅ AWS S3 simulated", "bucket", bucketName)
+	logger.Info("AWS S3 simulated", "bucket", bucketName)
 	return nil
 }
 
-// Environment detection helper
-func (r *ApplicationController) isLocalEnvironment() bool {
+// detectEnvironment resolves what "auto" means for this controller: the
+// --environment override when set, otherwise environment-variable heuristics
+func (r *ApplicationController) detectEnvironment() v1alpha1.Environment {
+	return DetectEnvironment(r.Config.Environment)
+}
+
+// DetectEnvironment returns the environment "auto" infrastructure resolves to:
+// the configured one when it is local or aws, otherwise the one the host
+// looks like. Offline tools use it to resolve "auto" as the controller does.
+func DetectEnvironment(configured v1alpha1.Environment) v1alpha1.Environment {
+	switch configured {
+	case v1alpha1.EnvironmentLocal, v1alpha1.EnvironmentAWS:
+		return configured
+	}
+	if isLocalHost() {
+		return v1alpha1.EnvironmentLocal
+	}
+	return v1alpha1.EnvironmentAWS
+}
+
+// isLocalHost guesses from the process environment whether the controller
+// runs outside a cloud provider
+func isLocalHost() bool {
 	// Check for AWS credentials
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" && os.Getenv("AWS_SECRET_ACCESS_KEY") != "" {
 		return false
@@ -564,146 +1468,373 @@ func (r *ApplicationController) isLocalEnvironment() bool {
 func (r *ApplicationController) buildEnvironmentVariables(app *v1alpha1.Application) []corev1.EnvVar {
 	envVars := []corev1.EnvVar{}
 
-	// Add user-defined environment variables
-	for key, value := range app.Spec.Env {
-		envVars = append(envVars, corev1.EnvVar{Name: key, Value: value})
+	// Add user-defined environment variables, sorted by name so map iteration
+	// order cannot change the pod template between reconciles. The rest of the
+	// list keeps its order, since entries such as DATABASE_URL refer to
+	// variables defined before them. ${databaseEndpoint}-style references to
+	// infrastructure status are substituted.
+	for _, key := range sortedKeys(app.Spec.Env) {
+		envVars = append(envVars, userEnvVar(app, key, app.Spec.Env[key]))
 	}
 
-	// Add infrastructure connection details (environment-aware)
-	if app.Status.DatabaseEndpoint != "" {
-		dbName := "webapp"
-		if app.Spec.Infrastructure.PostgreSQL != nil && app.Spec.Infrastructure.PostgreSQL.DatabaseName != "" {
-			dbName = app.Spec.Infrastructure.PostgreSQL.DatabaseName
-		}
-		
-		if app.Status.DatabaseEnvironment == v1alpha1.EnvironmentLocal {
-			envVars = append(envVars, corev1.EnvVar{
-				Name:  "DATABASE_URL",
-				Value: fmt.Sprintf("postgres://appuser:localpassword@%s/%s", app.Status.DatabaseEndpoint, dbName),
-			})
-		} else {
-			envVars = append(envVars, corev1.EnvVar{
-				Name:  "DATABASE_URL",
-				Value: fmt.Sprintf("postgres://user:password@%s/%s", app.Status.DatabaseEndpoint, dbName),
-			})
-		}
-	}
-
-	if app.Status.RedisEndpoint != "" {
+	// Pod fields from the downward API
+	for _, d := range app.Spec.DownwardEnv {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  "REDIS_URL",
-			Value: fmt.Sprintf("redis://%s", app.Status.RedisEndpoint),
+			Name: d.Name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: d.FieldPath},
+			},
 		})
 	}
 
-	if app.Status.S3BucketName != "" {
-		envVars = append(envVars, corev1.EnvVar{Name: "S3_BUCKET", Value: app.Status.S3BucketName})
-		
-		if app.Status.S3Environment == v1alpha1.EnvironmentLocal {
-			envVars = append(envVars, corev1.EnvVar{Name: "S3_ENDPOINT", Value: fmt.Sprintf("http://%s", app.Status.S3Endpoint)})
-			envVars = append(envVars, corev1.EnvVar{Name: "S3_ACCESS_KEY", Value: "minioadmin"})
-			envVars = append(envVars, corev1.EnvVar{Name: "S3_SECRET_KEY", Value: "minioadmin"})
-		}
+	// Add infrastructure connection details (environment-aware); with a
+	// connection Secret only the entries that cannot be stored in it stay inline
+	connEnv := connectionEnvVars(app.GetConnectionInfo())
+	if app.Spec.ConnectionSecret {
+		_, connEnv = splitConnectionEnv(connEnv)
+	}
+
+	// A name set both ways is only kept once: the user's value, unless the
+	// controller gives the generated connection details precedence
+	if r.Config.InfraEnvPrecedence {
+		envVars = withoutEnvNames(envVars, connEnv)
+	} else {
+		connEnv = withoutEnvNames(connEnv, envVars)
 	}
+	envVars = append(envVars, connEnv...)
 
 	return envVars
 }
 
+// withoutEnvNames drops the vars whose name is also defined in other
+func withoutEnvNames(vars, other []corev1.EnvVar) []corev1.EnvVar {
+	names := make(map[string]bool, len(other))
+	for _, v := range other {
+		names[v.Name] = true
+	}
+	kept := make([]corev1.EnvVar, 0, len(vars))
+	for _, v := range vars {
+		if !names[v.Name] {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// EnvironmentVariables returns the variables the application container would
+// get for the Application's current status, for previews without a cluster
+func EnvironmentVariables(app *v1alpha1.Application) []corev1.EnvVar {
+	return (&ApplicationController{}).buildEnvironmentVariables(app)
+}
+
+// secretEnvVar builds an env var sourced from a key in a Secret
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
 // Keep all existing methods (createOrUpdateDeployment, createOrUpdateService, etc.)
 // ... (include all the remaining methods from the previous version)
 
-// createOrUpdateDeployment creates a Kubernetes Deployment for the application
-func (r *ApplicationController) createOrUpdateDeployment(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	
-	deployment := &appsv1.Deployment{
+// buildAppContainer renders the application container shared by every workload kind
+func (r *ApplicationController) buildAppContainer(app *v1alpha1.Application) corev1.Container {
+	container := corev1.Container{
+		Name:            app.Name,
+		Image:           app.GetDeployImage(),
+		ImagePullPolicy: app.GetImagePullPolicy(),
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: app.GetPort(),
+				Protocol:      app.GetProtocol(),
+			},
+		},
+		Env:        r.buildEnvironmentVariables(app),
+		WorkingDir: app.Spec.WorkingDir,
+	}
+	if app.Spec.RunAsUser != nil || app.Spec.RunAsGroup != nil {
+		container.SecurityContext = &corev1.SecurityContext{
+			RunAsUser:  app.Spec.RunAsUser,
+			RunAsGroup: app.Spec.RunAsGroup,
+		}
+	}
+	if app.Spec.ConnectionSecret {
+		container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: app.GetConnectionSecretName()},
+			},
+		})
+	}
+	container.Ports = append(container.Ports, additionalContainerPorts(app)...)
+	container.Ports = append(container.Ports, metricsContainerPort(app)...)
+	// An HTTP probe cannot reach a UDP port
+	if path := app.Spec.HealthCheckPath; path != "" && app.GetProtocol() == corev1.ProtocolTCP {
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromInt32(app.GetPort())},
+			},
+		}
+	}
+	if app.Spec.PreStop != nil || app.Spec.PostStart != nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+		if app.Spec.PreStop != nil {
+			container.Lifecycle.PreStop = app.Spec.PreStop.DeepCopy()
+		}
+		if app.Spec.PostStart != nil {
+			container.Lifecycle.PostStart = app.Spec.PostStart.DeepCopy()
+		}
+	}
+	return container
+}
+
+// buildPodTemplate renders the pod template shared by every workload kind. The
+// template carries a hash of its configuration so config changes roll the pods.
+func (r *ApplicationController) buildPodTemplate(ctx context.Context, app *v1alpha1.Application) (corev1.PodTemplateSpec, error) {
+	container := r.buildAppContainer(app)
+	volumes, mounts := r.buildSecretVolumes(app)
+	container.VolumeMounts = append(container.VolumeMounts, mounts...)
+	containers := []corev1.Container{container}
+	if app.UsesTLSSidecar() {
+		sidecar, tlsVolumes := tlsSidecar(app)
+		containers = append(containers, sidecar)
+		volumes = append(volumes, tlsVolumes...)
+	}
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: appSelectorLabels(app),
+		},
+		Spec: corev1.PodSpec{
+			InitContainers:            r.buildInitContainers(app),
+			Containers:                containers,
+			Volumes:                   volumes,
+			TopologySpreadConstraints: r.buildTopologySpreadConstraints(app),
+			Tolerations:               r.withSpotToleration(appTolerations(app), app.Spec.Preemptible),
+			Affinity:                  r.appAffinity(app),
+			PriorityClassName:         app.Spec.PriorityClassName,
+			DNSPolicy:                 corev1.DNSPolicy(app.Spec.DNSPolicy),
+			DNSConfig:                 app.Spec.DNSConfig.DeepCopy(),
+			// Left nil so Kubernetes applies its default unless the spec overrides it
+			TerminationGracePeriodSeconds: app.Spec.TerminationGracePeriodSeconds,
+			AutomountServiceAccountToken:  r.automountServiceAccountToken(app),
+			SecurityContext:               appPodSecurityContext(app),
+		},
+	}
+
+	// Without rollouts on config changes the hash only covers the env, so new
+	// Secret or ConfigMap data leaves the template as it is
+	hash, err := r.configHash(ctx, app.GetTargetNamespace(), &template.Spec, app.RollsOutOnConfigChange())
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+	template.Annotations = map[string]string{v1alpha1.ConfigHashAnnotation: hash}
+	template.Annotations = mergeAnnotations(template.Annotations, prometheusAnnotations(app))
+	return template, nil
+}
+
+// automountServiceAccountToken returns the spec's choice, or false under
+// --secure-defaults; nil leaves the service account's own setting in charge
+func (r *ApplicationController) automountServiceAccountToken(app *v1alpha1.Application) *bool {
+	if app.Spec.AutomountServiceAccountToken != nil {
+		automount := *app.Spec.AutomountServiceAccountToken
+		return &automount
+	}
+	if r.Config.SecureDefaults {
+		return &[]bool{false}[0]
+	}
+	return nil
+}
+
+// buildInitContainers returns the user's init containers, which always run
+// before any init containers the platform adds
+func (r *ApplicationController) buildInitContainers(app *v1alpha1.Application) []corev1.Container {
+	var containers []corev1.Container
+	for _, c := range app.Spec.InitContainers {
+		containers = append(containers, *c.DeepCopy())
+	}
+	if wait := databaseWaitContainer(app); wait != nil {
+		containers = append(containers, *wait)
+	}
+	return containers
+}
+
+// buildTopologySpreadConstraints maps the spec's constraints onto the app pods,
+// falling back to a zone spread when enabled on the controller
+func (r *ApplicationController) buildTopologySpreadConstraints(app *v1alpha1.Application) []corev1.TopologySpreadConstraint {
+	selector := &metav1.LabelSelector{MatchLabels: appSelectorLabels(app)}
+
+	if len(app.Spec.TopologySpread) == 0 {
+		if r.Config.DefaultZoneSpread && app.GetReplicas() > 1 {
+			return []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector:     selector,
+				},
+			}
+		}
+		return nil
+	}
+
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(app.Spec.TopologySpread))
+	for _, tsc := range app.Spec.TopologySpread {
+		maxSkew := tsc.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+		whenUnsatisfiable := corev1.DoNotSchedule
+		if tsc.WhenUnsatisfiable != "" {
+			whenUnsatisfiable = corev1.UnsatisfiableConstraintAction(tsc.WhenUnsatisfiable)
+		}
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       tsc.TopologyKey,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector:     selector,
+		})
+	}
+	return constraints
+}
+
+// buildAppDeployment renders the application Deployment for the RollingUpdate strategy
+func (r *ApplicationController) buildAppDeployment(ctx context.Context, app *v1alpha1.Application) (*appsv1.Deployment, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      app.Name,
-			Namespace: app.Namespace,
+			Namespace: app.GetTargetNamespace(),
 			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &[]int32{app.GetReplicas()}[0],
+			Replicas:             &[]int32{app.GetReplicas()}[0],
+			RevisionHistoryLimit: &[]int32{app.GetRevisionHistoryLimit()}[0],
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{"app": app.Name},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"app": app.Name},
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:  app.Name,
-							Image: app.Spec.Image,
-							Ports: []corev1.ContainerPort{
-								{
-									ContainerPort: app.GetPort(),
-									Protocol:      corev1.ProtocolTCP,
-								},
-							},
-							Env: r.buildEnvironmentVariables(app),
-						},
-					},
-				},
+				MatchLabels: appSelectorLabels(app),
 			},
+			Template: template,
 		},
+	}, nil
+}
+
+// createOrUpdateDeployment creates a Kubernetes Deployment for the application
+func (r *ApplicationController) createOrUpdateDeployment(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	deployment, err := r.buildAppDeployment(ctx, app)
+	if err != nil {
+		return err
 	}
 
-	if err := r.Create(ctx, deployment); err != nil {
+	if err := r.createOwned(ctx, app, deployment); err != nil {
 		if errors.IsAlreadyExists(err) {
-			logger.Info("📦 Deployment already exists, updating...")
+			existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: app.Name, Namespace: app.GetTargetNamespace()}}
+			if err := r.claimByKey(ctx, app, existing); err != nil {
+				return err
+			}
+			logger.Info("Deployment already exists, updating...")
 			return nil
 		}
 		return fmt.Errorf("failed to create deployment: %w", err)
 	}
 
-	logger.Info("✅ Created Kubernetes Deployment", "replicas", app.GetReplicas())
+	logger.Info("Created Kubernetes Deployment", "replicas", app.GetReplicas())
 	return nil
 }
 
 func (r *ApplicationController) createOrUpdateService(ctx context.Context, app *v1alpha1.Application) error {
-	logger := log.FromContext(ctx)
-	
+	logger := appLogger(ctx, app)
+
+	service, err := r.buildAppService(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return r.updateService(ctx, app, service)
+		}
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+
+	logger.Info("Created Kubernetes Service", "port", app.GetPort(), "type", service.Spec.Type)
+	return nil
+}
+
+// buildAppService renders the application Service
+func (r *ApplicationController) buildAppService(ctx context.Context, app *v1alpha1.Application) (*corev1.Service, error) {
+	selector, err := r.appServiceSelector(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      app.Name,
-			Namespace: app.Namespace,
-			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+			Name:        app.Name,
+			Namespace:   app.GetTargetNamespace(),
+			Labels:      map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+			Annotations: prometheusAnnotations(app),
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: map[string]string{"app": app.Name},
+			Selector: selector,
 			Ports: []corev1.ServicePort{
 				{
 					Port:       80,
 					TargetPort: intstr.FromInt32(app.GetPort()),
-					Protocol:   corev1.ProtocolTCP,
+					Protocol:   app.GetProtocol(),
 				},
 			},
-			Type: corev1.ServiceTypeClusterIP,
+			Type:            app.GetServiceType(),
+			SessionAffinity: app.GetSessionAffinity(),
 		},
 	}
-
-	if err := r.Create(ctx, service); err != nil {
-		if errors.IsAlreadyExists(err) {
-			logger.Info("🌐 Service already exists")
-			return nil
+	if timeout := app.Spec.SessionAffinityTimeoutSeconds; timeout != nil && service.Spec.SessionAffinity == corev1.ServiceAffinityClientIP {
+		service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: timeout},
 		}
-		return fmt.Errorf("failed to create service: %w", err)
 	}
-
-	logger.Info("✅ Created Kubernetes Service", "port", app.GetPort())
-	return nil
+	if usesNodePorts(service.Spec.Type) {
+		service.Spec.Ports[0].NodePort = app.Spec.NodePort
+	}
+	extraPorts := append(additionalServicePorts(app), metricsServicePort(app)...)
+	if extraPorts = append(extraPorts, tlsServicePort(app)...); len(extraPorts) > 0 {
+		// Ports must be named once a Service has more than one
+		service.Spec.Ports[0].Name = mainPortName(app)
+		service.Spec.Ports = append(service.Spec.Ports, extraPorts...)
+	}
+	return service, nil
 }
 
 func (r *ApplicationController) checkApplicationReady(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	switch app.GetKind() {
+	case v1alpha1.WorkloadCronJob:
+		return r.checkCronJobReady(ctx, app)
+	case v1alpha1.WorkloadStatefulSet:
+		return r.checkAppStatefulSetReady(ctx, app)
+	}
+
 	deployment := &appsv1.Deployment{}
-	err := r.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: app.Namespace}, deployment)
+	err := r.Get(ctx, client.ObjectKey{Name: appDeploymentName(app), Namespace: app.GetTargetNamespace()}, deployment)
 	if err != nil {
 		return false, err
 	}
 
-	if deployment.Status.ReadyReplicas == app.GetReplicas() {
+	want := app.GetReplicas()
+	if app.UsesCanary() && deployment.Spec.Replicas != nil {
+		// The stable track runs short while a canary holds part of the replicas
+		want = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ReadyReplicas == want {
 		app.Status.ReadyReplicas = deployment.Status.ReadyReplicas
 		return true, nil
 	}
@@ -713,25 +1844,80 @@ func (r *ApplicationController) checkApplicationReady(ctx context.Context, app *
 }
 
 func (r *ApplicationController) updateApplicationStatus(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, error) {
-	if err := r.Status().Update(ctx, app); err != nil {
+	if err := r.writeStatus(ctx, app); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update Application status: %w", err)
 	}
 	return ctrl.Result{}, nil
 }
 
+// requeueAfterDeploy persists the resources created by the deploy step and
+// requeues to check on readiness
+func (r *ApplicationController) requeueAfterDeploy(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, error) {
+	if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
+}
+
+// handleDeployError decides what a failed resource create means for the
+// Application. After a transient error it stays Deploying and the deploy step
+// is retried with the controller's backoff; anything else is Failed.
+func (r *ApplicationController) handleDeployError(ctx context.Context, app *v1alpha1.Application, object string, err error) (ctrl.Result, error) {
+	logger := appLogger(ctx, app)
+
+	if isRetryableError(err) {
+		logger.Info("Transient error creating resource, will retry", "resource", object, "error", err.Error())
+		r.recordEvent(app, corev1.EventTypeWarning, "DeployRetrying", fmt.Sprintf("%s: %v", object, err))
+		app.UpdateStatus(v1alpha1.PhaseDeploying, fmt.Sprintf("Retrying %s: %v", object, err))
+		app.Status.DeployRetry = true
+		if statusErr := r.updateApplicationStatusOnly(ctx, app); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger.Error(err, "Failed to create resource", "resource", object)
+	r.recordEvent(app, corev1.EventTypeWarning, "DeployFailed", fmt.Sprintf("%s: %v", object, err))
+	app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("%s failed: %v", object, err))
+	r.updateApplicationStatusOnly(ctx, app)
+	return ctrl.Result{RequeueAfter: r.Config.DeployFailureRequeue}, nil
+}
+
 func (r *ApplicationController) updateApplicationStatusOnly(ctx context.Context, app *v1alpha1.Application) error {
-	if err := r.Status().Update(ctx, app); err != nil {
+	if err := r.writeStatus(ctx, app); err != nil {
 		return fmt.Errorf("failed to update Application status: %w", err)
 	}
 	return nil
 }
 
 func (r *ApplicationController) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexReferencedSecrets(context.Background(), mgr); err != nil {
+		return fmt.Errorf("failed to index referenced secrets: %w", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Application{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&networkingv1.Ingress{}).
+		// Resources in a target namespace carry owner labels instead of references
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		Watches(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(enqueueLabelOwner)).
+		// Secrets the user supplies (credentials, TLS, mounted files) roll the pods
+		// on change; only their metadata is cached
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.applicationsForSecret), builder.OnlyMetadata).
+		// Dependents waiting in Pending move on as soon as a dependency is Ready
+		Watches(&v1alpha1.Application{}, handler.EnqueueRequestsFromMapFunc(r.dependentApplications)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles,
+			RateLimiter:             newReconcileRateLimiter(r.Config.MinReconcileInterval),
+		}).
 		Complete(r)
 }
\ No newline at end of file
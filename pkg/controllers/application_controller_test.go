@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestTransientDeployErrorStaysDeploying(t *testing.T) {
+	app := newTestApplication("web")
+	app.Finalizers = []string{cleanupFinalizer}
+	app.Status.Phase = v1alpha1.PhaseDeploying
+	app.Status.InfrastructureReady = true
+	r, recorder := newTestController(t, app)
+
+	_, err := r.handleDeployError(testCtx, app, "Deployment", apierrors.NewServerTimeout(appsv1.Resource("deployments"), "create", 1))
+	if err == nil {
+		t.Error("handleDeployError returned no error, want one to requeue with backoff")
+	}
+	if app.Status.Phase != v1alpha1.PhaseDeploying || !app.Status.DeployRetry {
+		t.Fatalf("phase = %s, deployRetry = %t; want Deploying with a retry", app.Status.Phase, app.Status.DeployRetry)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "DeployRetrying") {
+		t.Errorf("events = %v, want DeployRetrying", events)
+	}
+
+	// The next reconcile runs the deploy step again
+	if _, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: app.Namespace, Name: app.Name}, &appsv1.Deployment{}); err != nil {
+		t.Errorf("Deployment not created on retry: %v", err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(app), stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Phase != v1alpha1.PhaseDeploying || stored.Status.DeployRetry {
+		t.Errorf("phase = %s, deployRetry = %t; want Deploying after the retry", stored.Status.Phase, stored.Status.DeployRetry)
+	}
+}
+
+func TestPermanentDeployErrorFails(t *testing.T) {
+	app := newTestApplication("web")
+	app.Status.Phase = v1alpha1.PhaseDeploying
+	r, _ := newTestController(t, app)
+
+	if _, err := r.handleDeployError(testCtx, app, "Deployment", fmt.Errorf("invalid template")); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status.Phase != v1alpha1.PhaseFailed || app.Status.DeployRetry {
+		t.Errorf("phase = %s, deployRetry = %t; want Failed", app.Status.Phase, app.Status.DeployRetry)
+	}
+}
+
+func TestResumeReturnsToPausedPhase(t *testing.T) {
+	app := newTestApplication("web")
+	app.Finalizers = []string{cleanupFinalizer}
+	app.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+	app.Status.Phase = v1alpha1.PhaseReady
+	r, _ := newTestController(t, app)
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	if _, err := r.Reconcile(testCtx, req); err != nil {
+		t.Fatal(err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Phase != v1alpha1.PhasePaused || stored.Status.PausedPhase != v1alpha1.PhaseReady {
+		t.Fatalf("phase = %s, pausedPhase = %s; want Paused from Ready", stored.Status.Phase, stored.Status.PausedPhase)
+	}
+
+	stored.Annotations = nil
+	if err := r.Update(testCtx, stored); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(testCtx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Phase != v1alpha1.PhaseReady || stored.Status.PausedPhase != "" {
+		t.Errorf("phase = %s, pausedPhase = %s; want Ready after resuming", stored.Status.Phase, stored.Status.PausedPhase)
+	}
+}
+
+func TestLocalS3Images(t *testing.T) {
+	for _, version := range []string{"", "RELEASE.2023-12-02T10-51-33Z"} {
+		t.Run("version="+version, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.Infrastructure.S3 = &v1alpha1.S3Spec{Environment: v1alpha1.EnvironmentLocal, Version: version}
+			r, _ := newTestController(t, app)
+
+			if err := r.provisionLocalS3(testCtx, app); err != nil {
+				t.Fatalf("provisionLocalS3: %v", err)
+			}
+			minio := &appsv1.Deployment{}
+			if err := r.Get(testCtx, client.ObjectKey{Name: app.GetS3Name(), Namespace: "default"}, minio); err != nil {
+				t.Fatal(err)
+			}
+			want := "minio/minio:" + v1alpha1.DefaultMinIOVersion
+			if version != "" {
+				want = "minio/minio:" + version
+			}
+			if got := minio.Spec.Template.Spec.Containers[0].Image; got != want {
+				t.Errorf("MinIO image = %q, want %q", got, want)
+			}
+
+			job := &batchv1.Job{}
+			if err := r.Get(testCtx, client.ObjectKey{Name: app.GetS3BucketJobName(), Namespace: "default"}, job); err != nil {
+				t.Fatal(err)
+			}
+			if got := job.Spec.Template.Spec.Containers[0].Image; got != "minio/mc:"+v1alpha1.DefaultMinIOClientVersion {
+				t.Errorf("bucket job image = %q, want the pinned mc release", got)
+			}
+		})
+	}
+}
+
+func TestInfraImageMirror(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.ImageRegistry = "mirror.internal"
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, Version: "7"}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	redis := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetRedisName(), Namespace: "default"}, redis); err != nil {
+		t.Fatal(err)
+	}
+	if got := redis.Spec.Template.Spec.Containers[0].Image; got != "mirror.internal/library/redis:7" {
+		t.Errorf("Redis image = %q, want it pulled through the mirror", got)
+	}
+
+	// The application image is the user's own and is left as written
+	deployment, err := r.buildAppDeployment(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildAppDeployment: %v", err)
+	}
+	if got := deployment.Spec.Template.Spec.Containers[0].Image; got != "nginx:1.25" {
+		t.Errorf("application image = %q, want nginx:1.25 unchanged", got)
+	}
+}
+
+func TestPodDNS(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.DNSPolicy = string(corev1.DNSNone)
+	app.Spec.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}, Searches: []string{"svc.example.com"}}
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	if template.Spec.DNSPolicy != corev1.DNSNone {
+		t.Errorf("dnsPolicy = %q, want None", template.Spec.DNSPolicy)
+	}
+	dns := template.Spec.DNSConfig
+	if dns == nil || len(dns.Nameservers) != 1 || dns.Nameservers[0] != "10.0.0.10" || dns.Searches[0] != "svc.example.com" {
+		t.Fatalf("dnsConfig = %+v, want the spec's resolver settings", dns)
+	}
+	dns.Nameservers[0] = "10.0.0.11"
+	if app.Spec.DNSConfig.Nameservers[0] != "10.0.0.10" {
+		t.Error("dnsConfig in the spec shares memory with the pod template")
+	}
+}
+
+func TestValidatePodDNS(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		config  *corev1.PodDNSConfig
+		wantErr bool
+	}{
+		{"default", "", nil, false},
+		{"cluster first", "ClusterFirst", nil, false},
+		{"none with nameservers", "None", &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}}, false},
+		{"none without nameservers", "None", nil, true},
+		{"unknown policy", "Custom", nil, true},
+		{"too many nameservers", "", &corev1.PodDNSConfig{Nameservers: []string{"1.1.1.1", "8.8.8.8", "9.9.9.9", "1.0.0.1"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.DNSPolicy = tt.policy
+			app.Spec.DNSConfig = tt.config
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecWarningOncePerGeneration(t *testing.T) {
+	app := newTestApplication("web")
+	app.Generation = 1
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentAWS, Exporter: true}
+	r, recorder := newTestController(t, app)
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	countWarnings := func() int {
+		n := 0
+		for _, e := range drainEvents(recorder) {
+			if strings.Contains(e, " SpecWarning ") {
+				n++
+			}
+		}
+		return n
+	}
+
+	for pass := 0; pass < 3; pass++ {
+		if _, err := r.Reconcile(testCtx, req); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+	}
+	if n := countWarnings(); n != 1 {
+		t.Errorf("%d SpecWarning events over three reconciles of one generation, want 1", n)
+	}
+
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	stored.Generation = 2
+	if err := r.Update(testCtx, stored); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(testCtx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if n := countWarnings(); n != 1 {
+		t.Errorf("%d SpecWarning events after a spec change, want 1", n)
+	}
+}
@@ -0,0 +1,209 @@
+// pkg/controllers/aws.go
+// AWS API surface used by the AWS-only components
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// DefaultAWSRegion is used by the simulated client and local AWS tooling
+const DefaultAWSRegion = "us-west-2"
+
+// awsCLIImage runs resource setup against the local AWS stand-ins
+const awsCLIImage = "amazon/aws-cli:2.15.10"
+
+// DynamoDBTable is the table the controller asks AWS to create
+type DynamoDBTable struct {
+	Name               string
+	HashKey            string
+	RangeKey           string
+	BillingMode        string
+	ReadCapacityUnits  int64
+	WriteCapacityUnits int64
+}
+
+// SQSQueue is the queue the controller asks AWS to create; zero durations
+// keep the SQS defaults
+type SQSQueue struct {
+	Name                     string
+	VisibilityTimeoutSeconds int32
+	MessageRetentionSeconds  int32
+}
+
+// DBInstance is the RDS PostgreSQL instance the controller asks AWS to create
+type DBInstance struct {
+	Identifier    string
+	EngineVersion string
+	InstanceClass string
+	StorageGB     int32
+	DatabaseName  string
+	ReadReplicas  int32
+}
+
+// DBInstanceStatus is what AWS reports about an RDS instance
+type DBInstanceStatus struct {
+	// State is the RDS status, e.g. creating, backing-up or available
+	State          string
+	Endpoint       string
+	ReaderEndpoint string
+}
+
+// AWS resource states: available once a resource can be used, creating while
+// it is being set up
+const (
+	awsStateAvailable = "available"
+	awsStateCreating  = "creating"
+)
+
+// AWSClient creates AWS-managed resources. Implementations must treat an
+// already existing resource as success.
+type AWSClient interface {
+	// CreateDynamoDBTable creates the table and returns the service endpoint
+	CreateDynamoDBTable(ctx context.Context, table DynamoDBTable) (string, error)
+	// CreateSQSQueue creates the queue and returns its URL
+	CreateSQSQueue(ctx context.Context, queue SQSQueue) (string, error)
+	// CreateDBInstance starts creating the RDS instance and returns its identifier
+	CreateDBInstance(ctx context.Context, db DBInstance) (string, error)
+	// DescribeDBInstance reports the state of an RDS instance
+	DescribeDBInstance(ctx context.Context, id string) (DBInstanceStatus, error)
+	// DeleteBucket empties and deletes an S3 bucket
+	DeleteBucket(ctx context.Context, bucket string) error
+}
+
+// simulatedAWSClient pretends to call AWS, like the RDS/ElastiCache/S3
+// provisioners. A simulated RDS instance reports creating on its first
+// describe, so provisioning polls it like a real one.
+type simulatedAWSClient struct {
+	region string
+
+	mu sync.Mutex
+	// described counts the describes of each simulated RDS instance
+	described map[string]int
+}
+
+// simulatedRDSCreatingPolls is how many describes a simulated RDS instance
+// answers with creating before it is available
+const simulatedRDSCreatingPolls = 1
+
+func (c *simulatedAWSClient) CreateDynamoDBTable(ctx context.Context, table DynamoDBTable) (string, error) {
+	// TODO: Real AWS DynamoDB API calls
+	return fmt.Sprintf("dynamodb.%s.amazonaws.com", c.region), nil
+}
+
+func (c *simulatedAWSClient) CreateSQSQueue(ctx context.Context, queue SQSQueue) (string, error) {
+	// TODO: Real AWS SQS API calls
+	return fmt.Sprintf("https://sqs.%s.amazonaws.com/123456789012/%s", c.region, queue.Name), nil
+}
+
+func (c *simulatedAWSClient) CreateDBInstance(ctx context.Context, db DBInstance) (string, error) {
+	// TODO: Real AWS RDS API calls
+	return db.Identifier, nil
+}
+
+func (c *simulatedAWSClient) DescribeDBInstance(ctx context.Context, id string) (DBInstanceStatus, error) {
+	// TODO: Real AWS RDS API calls
+	c.mu.Lock()
+	if c.described == nil {
+		c.described = map[string]int{}
+	}
+	c.described[id]++
+	polls := c.described[id]
+	c.mu.Unlock()
+	if polls <= simulatedRDSCreatingPolls {
+		return DBInstanceStatus{State: awsStateCreating}, nil
+	}
+	return DBInstanceStatus{
+		State:          awsStateAvailable,
+		Endpoint:       fmt.Sprintf("%s.cluster-xyz.%s.rds.amazonaws.com", id, c.region),
+		ReaderEndpoint: fmt.Sprintf("%s.cluster-ro-xyz.%s.rds.amazonaws.com", id, c.region),
+	}, nil
+}
+
+func (c *simulatedAWSClient) DeleteBucket(ctx context.Context, bucket string) error {
+	// TODO: Real AWS S3 API calls
+	return errAWSSimulated
+}
+
+// errAWSSimulated is returned by simulated calls that cannot pretend to have
+// done their work, such as deleting data
+var errAWSSimulated = errors.New("no AWS client is configured")
+
+// awsPendingError reports an AWS resource that was requested but is not
+// available yet; provisioning waits on it instead of counting it as failed
+type awsPendingError struct {
+	service string
+	state   string
+	attempt int32
+}
+
+func (e *awsPendingError) Error() string {
+	return fmt.Sprintf("provisioning, waiting for %s available (state %s, attempt %d)", e.service, e.state, e.attempt)
+}
+
+// asAWSPending returns the awsPendingError wrapped in err, if any
+func asAWSPending(err error) (*awsPendingError, bool) {
+	var pending *awsPendingError
+	ok := errors.As(err, &pending)
+	return pending, ok
+}
+
+// awsClient returns the configured AWS client, falling back to the simulated
+// one, which lives as long as the controller so its instances keep their state
+func (r *ApplicationController) awsClient() AWSClient {
+	if r.AWS != nil {
+		return r.AWS
+	}
+	r.simulatedAWSOnce.Do(func() {
+		r.simulatedAWS = &simulatedAWSClient{region: DefaultAWSRegion}
+	})
+	return r.simulatedAWS
+}
+
+// localAWSCLIJob runs an aws CLI script against a local AWS stand-in (DynamoDB
+// Local, ElasticMQ), retrying until the service accepts connections. Values
+// from the spec go in env, for the script to reference as quoted variables.
+func localAWSCLIJob(app *v1alpha1.Application, name, component, script string, env []corev1.EnvVar, nodeSelector map[string]string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": component, "managed-by": "orion-platform"},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{6}[0],
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": app.Name, "component": component + "-setup"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeSelector:  nodeSelector,
+					Tolerations:   infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:    "aws-cli",
+							Image:   app.InfraImage(awsCLIImage),
+							Command: []string{"/bin/sh", "-c", script},
+							// The local services accept any credentials but the CLI requires some
+							Env: append([]corev1.EnvVar{
+								{Name: "AWS_ACCESS_KEY_ID", Value: "local"},
+								{Name: "AWS_SECRET_ACCESS_KEY", Value: "local"},
+								{Name: "AWS_DEFAULT_REGION", Value: DefaultAWSRegion},
+							}, env...),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestAWSPostgreSQLPollsUntilAvailable(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentAWS, Version: "15"}
+	r, _ := newTestController(t, app)
+
+	err := r.provisionAWSPostgreSQL(testCtx, app)
+	pending, ok := asAWSPending(err)
+	if !ok {
+		t.Fatalf("first poll: err = %v, want the instance pending", err)
+	}
+	tracked := app.Status.AWSResource(componentDatabase)
+	if tracked == nil || tracked.ResourceID == "" || tracked.StartedAt.IsZero() {
+		t.Fatalf("tracked resource = %+v, want an identifier and start time", tracked)
+	}
+	if tracked.State != awsStateCreating || tracked.PollAttempts != 1 {
+		t.Errorf("tracked resource = %+v, want creating on attempt 1", tracked)
+	}
+	if !strings.Contains(pending.Error(), "attempt 1") {
+		t.Errorf("pending message = %q, want the attempt", pending.Error())
+	}
+	if app.Status.DatabaseEndpoint != "" {
+		t.Errorf("endpoint = %q before the instance is available", app.Status.DatabaseEndpoint)
+	}
+
+	if err := r.provisionAWSPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if app.Status.DatabaseEndpoint == "" || app.Status.DatabaseEnvironment != v1alpha1.EnvironmentAWS {
+		t.Errorf("status = %+v, want the RDS endpoint", app.Status)
+	}
+	if app.Status.AWSResource(componentDatabase) != nil {
+		t.Error("available instance still tracked as provisioning")
+	}
+}
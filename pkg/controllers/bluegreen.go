@@ -0,0 +1,298 @@
+// pkg/controllers/bluegreen.go
+// Blue/green rollouts: two Deployments with the Service switched between them
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// colorLabel tells the blue and green pods apart
+const colorLabel = "color"
+
+// colorDeploymentName returns the Deployment name for a blue/green color
+func colorDeploymentName(app *v1alpha1.Application, color string) string {
+	return app.ChildName(color)
+}
+
+// appDeploymentName returns the Deployment currently serving the application
+func appDeploymentName(app *v1alpha1.Application) string {
+	if app.UsesBlueGreen() && app.Status.ActiveColor != "" {
+		return colorDeploymentName(app, app.Status.ActiveColor)
+	}
+	return app.Name
+}
+
+// appServiceSelector selects the serving pods; blue/green narrows it to the active color
+func (r *ApplicationController) appServiceSelector(ctx context.Context, app *v1alpha1.Application) (map[string]string, error) {
+	selector, err := r.podSelectorFor(ctx, app, appDeploymentName(app))
+	if err != nil {
+		return nil, err
+	}
+	if app.UsesBlueGreen() && app.Status.ActiveColor != "" {
+		selector[colorLabel] = app.Status.ActiveColor
+	}
+	return selector, nil
+}
+
+// podTemplateHash fingerprints a rendered pod template so two colors can be
+// compared without diffing defaulted fields
+func podTemplateHash(template *corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash pod template: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// buildColorDeployment renders the application Deployment for one color
+func (r *ApplicationController) buildColorDeployment(ctx context.Context, app *v1alpha1.Application, color string) (*appsv1.Deployment, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	// Hashed before the color label so both colors hash the same for the same spec
+	hash, err := podTemplateHash(&template)
+	if err != nil {
+		return nil, err
+	}
+	template.Labels[colorLabel] = color
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        colorDeploymentName(app, color),
+			Namespace:   app.GetTargetNamespace(),
+			Labels:      map[string]string{"app": app.Name, colorLabel: color, "managed-by": "orion-platform"},
+			Annotations: map[string]string{v1alpha1.TemplateHashAnnotation: hash},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas:             &[]int32{app.GetReplicas()}[0],
+			RevisionHistoryLimit: &[]int32{app.GetRevisionHistoryLimit()}[0],
+			Selector: &metav1.LabelSelector{
+				MatchLabels: appSelectorWith(app, colorLabel, color),
+			},
+			Template: template,
+		},
+	}, nil
+}
+
+// applyAppDeployment creates an application Deployment or updates its template
+// and replica count to match desired, using the template hash to skip no-ops
+func (r *ApplicationController) applyAppDeployment(ctx context.Context, app *v1alpha1.Application, desired *appsv1.Deployment) error {
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if errors.IsNotFound(err) {
+		if err := r.createOwned(ctx, app, desired); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create deployment %s: %w", desired.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", desired.Name, err)
+	}
+	if err := r.claimExisting(ctx, app, existing); err != nil {
+		return err
+	}
+
+	hash := desired.Annotations[v1alpha1.TemplateHashAnnotation]
+	if existing.Annotations[v1alpha1.TemplateHashAnnotation] == hash &&
+		existing.Spec.Replicas != nil && *existing.Spec.Replicas == *desired.Spec.Replicas {
+		return nil
+	}
+
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[v1alpha1.TemplateHashAnnotation] = hash
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Template = desired.Spec.Template
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update deployment %s: %w", desired.Name, err)
+	}
+	return nil
+}
+
+// reconcileBlueGreen drives a blue/green rollout one step. The first deploy goes
+// straight to blue. A changed pod template is rolled out on the idle color; once
+// every replica there is ready the Service is switched over and the previous
+// color is scaled to zero unless blueGreen.keepPrevious is set.
+func (r *ApplicationController) reconcileBlueGreen(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	active := app.Status.ActiveColor
+	if active == "" {
+		active = v1alpha1.ColorBlue
+		desired, err := r.buildColorDeployment(ctx, app, active)
+		if err != nil {
+			return err
+		}
+		if err := r.applyAppDeployment(ctx, app, desired); err != nil {
+			return err
+		}
+		app.Status.ActiveColor = active
+		logger.Info("Created blue/green Deployment", "color", active, "replicas", app.GetReplicas())
+		return nil
+	}
+
+	desired, err := r.buildColorDeployment(ctx, app, active)
+	if err != nil {
+		return err
+	}
+	current := &appsv1.Deployment{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	if errors.IsNotFound(err) {
+		// The active color was deleted out from under us; recreate it in place
+		return r.applyAppDeployment(ctx, app, desired)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", desired.Name, err)
+	}
+
+	// Same template: only keep the replica count in step, and drop any rollout
+	// that the spec has since been reverted from
+	if current.Annotations[v1alpha1.TemplateHashAnnotation] == desired.Annotations[v1alpha1.TemplateHashAnnotation] {
+		if err := r.applyAppDeployment(ctx, app, desired); err != nil {
+			return err
+		}
+		if preview := app.Status.PreviewColor; preview != "" {
+			logger.Info("Rollout abandoned, spec matches the active color", "preview", preview)
+			app.Status.PreviewColor = ""
+			return r.retireColor(ctx, app, preview)
+		}
+		return r.retireRollingDeployment(ctx, app)
+	}
+
+	preview := v1alpha1.OtherColor(active)
+	target, err := r.buildColorDeployment(ctx, app, preview)
+	if err != nil {
+		return err
+	}
+	if err := r.applyAppDeployment(ctx, app, target); err != nil {
+		return err
+	}
+	if app.Status.PreviewColor != preview {
+		app.Status.PreviewColor = preview
+		r.recordEvent(app, corev1.EventTypeNormal, "RolloutStarted", fmt.Sprintf("Rolling out new version on %s", preview))
+		logger.Info("Started blue/green rollout", "active", active, "preview", preview)
+	}
+
+	ready, err := r.deploymentRolledOut(ctx, app.GetTargetNamespace(), target.Name, app.GetReplicas())
+	if err != nil {
+		return err
+	}
+	if !ready {
+		app.Status.Message = fmt.Sprintf("Rolling out %s, %s still serving", preview, active)
+		return nil
+	}
+
+	if err := r.switchServiceColor(ctx, app, preview); err != nil {
+		return err
+	}
+	app.Status.ActiveColor = preview
+	app.Status.PreviewColor = ""
+	app.Status.Message = fmt.Sprintf("Serving %s", preview)
+	r.recordEvent(app, corev1.EventTypeNormal, "ColorSwitched", fmt.Sprintf("Service switched from %s to %s", active, preview))
+	logger.Info("Switched Service to new color", "from", active, "to", preview)
+
+	return r.retireColor(ctx, app, active)
+}
+
+// retireRollingDeployment deletes the Deployment left from before the
+// Application switched to blue/green. It keeps serving until the active color
+// has rolled out; then the Service is narrowed to that color first.
+func (r *ApplicationController) retireRollingDeployment(ctx context.Context, app *v1alpha1.Application) error {
+	legacy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.Name}, legacy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !managedBy(app, legacy) {
+		return nil
+	}
+	active := app.Status.ActiveColor
+	ready, err := r.deploymentRolledOut(ctx, app.GetTargetNamespace(), colorDeploymentName(app, active), app.GetReplicas())
+	if err != nil || !ready {
+		return err
+	}
+
+	if err := r.switchServiceColor(ctx, app, active); err != nil {
+		return err
+	}
+	if err := r.Delete(ctx, legacy, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete deployment %s: %w", legacy.Name, err)
+	}
+	app.Status.RemoveManagedResource(v1alpha1.ManagedResourceRef{Kind: "Deployment", Name: legacy.Name, Namespace: legacy.Namespace})
+	app.Status.Message = fmt.Sprintf("Serving %s", active)
+	r.recordEvent(app, corev1.EventTypeNormal, "ColorSwitched", fmt.Sprintf("Service switched from the rolling Deployment to %s", active))
+	appLogger(ctx, app).Info("Deleted the rolling Deployment replaced by blue/green", "deployment", legacy.Name, "active", active)
+	return nil
+}
+
+// deploymentRolledOut reports whether a Deployment runs its latest template on
+// the given number of ready replicas
+func (r *ApplicationController) deploymentRolledOut(ctx context.Context, namespace, name string, replicas int32) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas, nil
+}
+
+// switchServiceColor points the application Service at color
+func (r *ApplicationController) switchServiceColor(ctx context.Context, app *v1alpha1.Application, color string) error {
+	service := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.Name}, service); err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	selector, err := r.podSelectorFor(ctx, app, colorDeploymentName(app, color))
+	if err != nil {
+		return err
+	}
+	selector[colorLabel] = color
+	service.Spec.Selector = selector
+	if err := r.Update(ctx, service); err != nil {
+		return fmt.Errorf("failed to switch service to %s: %w", color, err)
+	}
+	return nil
+}
+
+// retireColor scales a color that no longer serves traffic to zero, unless the
+// spec keeps it warm for rollback
+func (r *ApplicationController) retireColor(ctx context.Context, app *v1alpha1.Application, color string) error {
+	if app.Spec.BlueGreen != nil && app.Spec.BlueGreen.KeepPrevious {
+		return nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: colorDeploymentName(app, color)}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s deployment: %w", color, err)
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		return nil
+	}
+	deployment.Spec.Replicas = &[]int32{0}[0]
+	if err := r.Update(ctx, deployment); err != nil {
+		return fmt.Errorf("failed to scale down %s deployment: %w", color, err)
+	}
+	appLogger(ctx, app).Info("Scaled down previous color", "color", color)
+	return nil
+}
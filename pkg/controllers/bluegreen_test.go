@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestSwitchToBlueGreenRetiresRollingDeployment(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Strategy = v1alpha1.StrategyBlueGreen
+	app.Status.Phase = v1alpha1.PhaseReady
+	app.Status.ActiveColor = v1alpha1.ColorBlue
+
+	owner := []metav1.OwnerReference{{
+		APIVersion: v1alpha1.GroupVersion.String(), Kind: "Application", Name: app.Name, UID: app.UID,
+		Controller: &[]bool{true}[0],
+	}}
+	legacy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", OwnerReferences: owner}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: appSelectorLabels(app)},
+	}
+	r, recorder := newTestController(t, app, legacy, service)
+
+	// The blue color exists but is not rolled out yet: the rolling Deployment keeps serving
+	blue, err := r.buildColorDeployment(testCtx, app, v1alpha1.ColorBlue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blue.OwnerReferences = owner
+	if err := r.Create(testCtx, blue); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileBlueGreen(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(legacy), &appsv1.Deployment{}); err != nil {
+		t.Fatalf("rolling Deployment deleted before blue was ready: %v", err)
+	}
+
+	blue.Status = appsv1.DeploymentStatus{ObservedGeneration: blue.Generation, UpdatedReplicas: 1, ReadyReplicas: 1}
+	if err := r.Status().Update(testCtx, blue); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileBlueGreen(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(legacy), &appsv1.Deployment{}); err == nil {
+		t.Error("rolling Deployment still exists after blue rolled out")
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(service), service); err != nil {
+		t.Fatal(err)
+	}
+	if service.Spec.Selector[colorLabel] != v1alpha1.ColorBlue {
+		t.Errorf("Service selector = %v, want the blue color", service.Spec.Selector)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "ColorSwitched") {
+		t.Errorf("events = %v, want ColorSwitched", events)
+	}
+}
@@ -0,0 +1,271 @@
+// pkg/controllers/canary.go
+// Canary rollouts: a new version runs on a share of the replicas before promotion
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// trackLabel separates stable and canary pods; the Service does not select on it
+// so traffic splits across both in proportion to their replicas
+const (
+	trackLabel  = "track"
+	trackStable = "stable"
+	trackCanary = "canary"
+)
+
+// canaryReplicas returns how many of total replicas the canary gets at weight
+// percent. A running canary always gets at least one replica.
+func canaryReplicas(total, weight int32) int32 {
+	if weight <= 0 {
+		return 0
+	}
+	if weight >= 100 {
+		return total
+	}
+	n := (total*weight + 50) / 100
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// stableReplicas returns the replicas left on the stable track; it keeps at
+// least one until the canary is promoted
+func stableReplicas(total, canary int32) int32 {
+	if n := total - canary; n > 0 {
+		return n
+	}
+	return 1
+}
+
+// buildTrackDeployment renders the application Deployment for the stable or
+// canary track with the given replica count
+func (r *ApplicationController) buildTrackDeployment(ctx context.Context, app *v1alpha1.Application, track string, replicas int32) (*appsv1.Deployment, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	// Hashed before the track label so both tracks hash the same for the same spec
+	hash, err := podTemplateHash(&template)
+	if err != nil {
+		return nil, err
+	}
+	template.Labels[trackLabel] = track
+
+	name := app.Name
+	if track == trackCanary {
+		name = app.GetCanaryName()
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   app.GetTargetNamespace(),
+			Labels:      map[string]string{"app": app.Name, trackLabel: track, "managed-by": "orion-platform"},
+			Annotations: map[string]string{v1alpha1.TemplateHashAnnotation: hash},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas:             &replicas,
+			RevisionHistoryLimit: &[]int32{app.GetRevisionHistoryLimit()}[0],
+			Selector: &metav1.LabelSelector{
+				MatchLabels: appSelectorWith(app, trackLabel, track),
+			},
+			Template: template,
+		},
+	}, nil
+}
+
+// nextCanaryWeight works out the weight for this reconcile: the initial weight
+// for a new canary, then whichever is higher of the annotation and an automatic
+// step taken once the canary is healthy
+func nextCanaryWeight(app *v1alpha1.Application, healthy bool) int32 {
+	current := app.Status.CanaryWeight
+	if current == 0 {
+		return app.GetCanaryWeight()
+	}
+
+	next := current
+	if healthy && app.Spec.CanaryStep > 0 {
+		next = current + app.Spec.CanaryStep
+	}
+	if value, ok := app.GetAnnotations()[v1alpha1.CanaryWeightAnnotation]; ok {
+		if bumped, err := strconv.ParseInt(value, 10, 32); err == nil && int32(bumped) > next {
+			next = int32(bumped)
+		}
+	}
+	if next > 100 {
+		next = 100
+	}
+	return next
+}
+
+// reconcileCanary drives a canary rollout one step. The first deploy creates
+// the stable Deployment at full size. When the pod template changes, the new
+// version runs as <app>-canary on canaryWeight percent of the replicas and the
+// stable track shrinks to match; the weight then advances by canaryStep per
+// healthy reconcile or by the canary-weight annotation. At 100 the stable
+// Deployment is updated to the new version and the canary removed.
+func (r *ApplicationController) reconcileCanary(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+	total := app.GetReplicas()
+
+	desired, err := r.buildTrackDeployment(ctx, app, trackStable, total)
+	if err != nil {
+		return err
+	}
+
+	stable := &appsv1.Deployment{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), stable)
+	if errors.IsNotFound(err) {
+		app.Status.CanaryWeight = 0
+		if err := r.applyAppDeployment(ctx, app, desired); err != nil {
+			return err
+		}
+		logger.Info("Created stable Deployment", "replicas", total)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", desired.Name, err)
+	}
+	if migrated, err := r.migrateStableSelector(ctx, app, stable); err != nil || !migrated {
+		return err
+	}
+
+	// Stable already runs the spec: no rollout, or one that was reverted
+	if stable.Annotations[v1alpha1.TemplateHashAnnotation] == desired.Annotations[v1alpha1.TemplateHashAnnotation] {
+		if err := r.applyAppDeployment(ctx, app, desired); err != nil {
+			return err
+		}
+		if app.Status.CanaryWeight != 0 {
+			logger.Info("Canary abandoned, spec matches the stable track")
+			app.Status.CanaryWeight = 0
+		}
+		return r.removeCanary(ctx, app)
+	}
+
+	canaryName := app.GetCanaryName()
+	healthy := false
+	if app.Status.CanaryWeight > 0 {
+		healthy, err = r.deploymentRolledOut(ctx, app.GetTargetNamespace(), canaryName, canaryReplicas(total, app.Status.CanaryWeight))
+		if err != nil {
+			return err
+		}
+	}
+
+	weight := nextCanaryWeight(app, healthy)
+	if app.Status.CanaryWeight == 0 {
+		r.recordEvent(app, corev1.EventTypeNormal, "CanaryStarted", fmt.Sprintf("New version started as a %d%% canary", weight))
+		logger.Info("Started canary", "weight", weight)
+	}
+
+	if weight >= 100 {
+		// Promote: the stable track rolls to the new version and takes all traffic
+		if err := r.applyAppDeployment(ctx, app, desired); err != nil {
+			return err
+		}
+		if err := r.removeCanary(ctx, app); err != nil {
+			return err
+		}
+		app.Status.CanaryWeight = 0
+		app.Status.Message = "Canary promoted"
+		r.recordEvent(app, corev1.EventTypeNormal, "CanaryPromoted", "Canary promoted to 100%")
+		logger.Info("Canary promoted")
+		return nil
+	}
+
+	canary, err := r.buildTrackDeployment(ctx, app, trackCanary, canaryReplicas(total, weight))
+	if err != nil {
+		return err
+	}
+	if err := r.applyAppDeployment(ctx, app, canary); err != nil {
+		return err
+	}
+
+	// Only the replica count of the stable track changes; it keeps the old version
+	if want := stableReplicas(total, *canary.Spec.Replicas); stable.Spec.Replicas == nil || *stable.Spec.Replicas != want {
+		stable.Spec.Replicas = &want
+		if err := r.Update(ctx, stable); err != nil {
+			return fmt.Errorf("failed to scale stable deployment: %w", err)
+		}
+	}
+
+	if weight != app.Status.CanaryWeight {
+		logger.Info("Canary weight changed", "from", app.Status.CanaryWeight, "to", weight)
+	}
+	app.Status.CanaryWeight = weight
+	app.Status.Message = fmt.Sprintf("Canary at %d%%", weight)
+	return nil
+}
+
+// migrateStableSelector moves a stable Deployment created before the
+// Application switched to canaries onto the track selector; without the track
+// label its selector also matches canary pods. Selectors are immutable, so the
+// pods are first rolled to carry track=stable, then the Deployment is deleted
+// with its ReplicaSets orphaned so they keep serving, and the replacement the
+// next reconcile creates adopts them. It reports whether the selector is
+// already migrated.
+func (r *ApplicationController) migrateStableSelector(ctx context.Context, app *v1alpha1.Application, stable *appsv1.Deployment) (bool, error) {
+	if stable.Spec.Selector != nil && stable.Spec.Selector.MatchLabels[trackLabel] == trackStable {
+		return true, nil
+	}
+	if !managedBy(app, stable) {
+		return false, fmt.Errorf("deployment %s is not managed by this Application", stable.Name)
+	}
+	if stable.DeletionTimestamp != nil {
+		return false, nil
+	}
+	logger := appLogger(ctx, app)
+
+	if stable.Spec.Template.Labels[trackLabel] != trackStable {
+		if stable.Spec.Template.Labels == nil {
+			stable.Spec.Template.Labels = map[string]string{}
+		}
+		stable.Spec.Template.Labels[trackLabel] = trackStable
+		if err := r.Update(ctx, stable); err != nil {
+			return false, fmt.Errorf("failed to label stable deployment pods: %w", err)
+		}
+		app.Status.Message = "Moving the stable Deployment to the track selector"
+		r.recordEvent(app, corev1.EventTypeNormal, "StableSelectorMigrating",
+			fmt.Sprintf("Deployment %s is rolled to track=%s pods before it is recreated with the track selector", stable.Name, trackStable))
+		logger.Info("Labelling stable pods before recreating the Deployment", "deployment", stable.Name)
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if stable.Spec.Replicas != nil {
+		replicas = *stable.Spec.Replicas
+	}
+	ready, err := r.deploymentRolledOut(ctx, stable.Namespace, stable.Name, replicas)
+	if err != nil || !ready {
+		return false, err
+	}
+	if err := r.Delete(ctx, stable, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete deployment %s: %w", stable.Name, err)
+	}
+	logger.Info("Deleted stable Deployment to recreate it with the track selector", "deployment", stable.Name)
+	return false, nil
+}
+
+// removeCanary deletes the canary Deployment if there is one
+func (r *ApplicationController) removeCanary(ctx context.Context, app *v1alpha1.Application) error {
+	canary := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: app.GetCanaryName(), Namespace: app.GetTargetNamespace()},
+	}
+	if err := r.Delete(ctx, canary); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete canary deployment: %w", err)
+	}
+	app.Status.RemoveManagedResource(v1alpha1.ManagedResourceRef{Kind: "Deployment", Name: canary.Name, Namespace: canary.Namespace})
+	return nil
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestCanaryValidationRequiresTwoReplicas(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.Strategy = v1alpha1.StrategyCanary
+	app.Spec.Replicas = 1
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("canary with one replica passed validation")
+	}
+	app.Spec.Replicas = 2
+	if err := app.ValidateSpec(); err != nil {
+		t.Errorf("canary with two replicas rejected: %v", err)
+	}
+}
+
+func TestCanaryTracksDoNotOverlap(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Replicas = 4
+	r, _ := newTestController(t, app)
+
+	stable, err := r.buildTrackDeployment(testCtx, app, trackStable, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canary, err := r.buildTrackDeployment(testCtx, app, trackCanary, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range stable.Spec.Selector.MatchLabels {
+		if canary.Spec.Template.Labels[key] != value {
+			return
+		}
+	}
+	t.Errorf("stable selector %v matches canary pods %v", stable.Spec.Selector.MatchLabels, canary.Spec.Template.Labels)
+}
+
+func TestCanaryMigratesLegacyStableSelector(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Strategy = v1alpha1.StrategyCanary
+	app.Spec.Replicas = 2
+
+	replicas := int32(2)
+	legacy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1alpha1.GroupVersion.String(), Kind: "Application", Name: app.Name, UID: app.UID,
+				Controller: &[]bool{true}[0],
+			}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: appSelectorLabels(app)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: appSelectorLabels(app)},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.25"}}},
+			},
+		},
+	}
+	r, recorder := newTestController(t, app, legacy)
+
+	// First the pods are rolled to carry the track label under the old selector
+	if err := r.reconcileCanary(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	live := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(legacy), live); err != nil {
+		t.Fatal(err)
+	}
+	if live.Spec.Template.Labels[trackLabel] != trackStable {
+		t.Fatalf("stable pod template labels = %v, want track=%s", live.Spec.Template.Labels, trackStable)
+	}
+	if !hasEvent(drainEvents(recorder), "StableSelectorMigrating") {
+		t.Error("no StableSelectorMigrating event")
+	}
+
+	// Once rolled out the Deployment is deleted so it can be recreated
+	live.Status = appsv1.DeploymentStatus{ObservedGeneration: live.Generation, UpdatedReplicas: 2, ReadyReplicas: 2}
+	if err := r.Status().Update(testCtx, live); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileCanary(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(legacy), &appsv1.Deployment{}); err == nil {
+		t.Fatal("legacy stable Deployment not deleted after its pods were relabelled")
+	}
+
+	// The replacement selects on the track label
+	if err := r.reconcileCanary(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(legacy), live); err != nil {
+		t.Fatal(err)
+	}
+	if live.Spec.Selector.MatchLabels[trackLabel] != trackStable {
+		t.Errorf("recreated stable selector = %v, want track=%s", live.Spec.Selector.MatchLabels, trackStable)
+	}
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// cleanupFixture is a deleted cross-namespace Application with one ConfigMap
+// left in its target namespace, whose deletion fails while failDelete is set
+type cleanupFixture struct {
+	r          *ApplicationController
+	recorder   *record.FakeRecorder
+	app        *v1alpha1.Application
+	configMap  client.ObjectKey
+	failDelete bool
+}
+
+func newCleanupFixture(t *testing.T) *cleanupFixture {
+	t.Helper()
+	now := metav1.Now()
+	app := newTestApplication("shop")
+	app.Spec.TargetNamespace = "shop-prod"
+	app.Finalizers = []string{cleanupFinalizer}
+	app.DeletionTimestamp = &now
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shop-config", Namespace: "shop-prod"}}
+	setOwnerLabels(app, cm)
+
+	f := &cleanupFixture{configMap: client.ObjectKeyFromObject(cm)}
+	f.r, f.recorder = newTestController(t, app, cm)
+	f.r.Client = interceptor.NewClient(f.r.Client.(client.WithWatch), interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if f.failDelete && obj.GetName() == cm.Name {
+				return errors.New("connection refused")
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	})
+	f.app = &v1alpha1.Application{}
+	if err := f.r.Get(testCtx, client.ObjectKeyFromObject(app), f.app); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// released reports whether the Application is gone, its finalizer removed
+func (f *cleanupFixture) released(t *testing.T) bool {
+	t.Helper()
+	err := f.r.Get(testCtx, client.ObjectKeyFromObject(f.app), &v1alpha1.Application{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		t.Fatal(err)
+	}
+	return apierrors.IsNotFound(err)
+}
+
+func TestFinalizeApplicationCleansUp(t *testing.T) {
+	f := newCleanupFixture(t)
+
+	if err := f.r.finalizeApplication(testCtx, f.app); err != nil {
+		t.Fatalf("finalizeApplication: %v", err)
+	}
+	if err := f.r.Get(testCtx, f.configMap, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("target namespace ConfigMap not deleted: %v", err)
+	}
+	if !f.released(t) {
+		t.Error("finalizer not removed after a successful cleanup")
+	}
+}
+
+func TestFinalizeApplicationRetriesCleanup(t *testing.T) {
+	f := newCleanupFixture(t)
+	f.failDelete = true
+	f.r.Config.CleanupMaxAttempts = 3
+
+	if err := f.r.finalizeApplication(testCtx, f.app); err == nil {
+		t.Fatal("finalizeApplication succeeded while the delete fails")
+	}
+	if f.app.Status.CleanupAttempts != 1 || f.released(t) {
+		t.Fatalf("attempts = %d, released = %t; want one attempt recorded and the finalizer kept", f.app.Status.CleanupAttempts, f.released(t))
+	}
+	if events := drainEvents(f.recorder); !hasEvent(events, "CleanupFailed") {
+		t.Errorf("events = %v, want CleanupFailed", events)
+	}
+
+	// The delete recovers before the limit: the next attempt completes
+	f.failDelete = false
+	if err := f.r.finalizeApplication(testCtx, f.app); err != nil {
+		t.Fatalf("finalizeApplication: %v", err)
+	}
+	if !f.released(t) {
+		t.Error("finalizer not removed once cleanup succeeded")
+	}
+}
+
+func TestFinalizeApplicationForcedAfterLimit(t *testing.T) {
+	for _, orphan := range []bool{false, true} {
+		name := "kept"
+		if orphan {
+			name = "orphaned"
+		}
+		t.Run(name, func(t *testing.T) {
+			f := newCleanupFixture(t)
+			f.failDelete = true
+			f.r.Config.CleanupMaxAttempts = 2
+			f.r.Config.OrphanOnCleanupFailure = orphan
+
+			if err := f.r.finalizeApplication(testCtx, f.app); err == nil {
+				t.Fatal("first attempt succeeded while the delete fails")
+			}
+			err := f.r.finalizeApplication(testCtx, f.app)
+			events := drainEvents(f.recorder)
+
+			if !orphan {
+				if err == nil || !strings.Contains(err.Error(), "--orphan-on-cleanup-failure") {
+					t.Errorf("finalizeApplication = %v, want a pointer to --orphan-on-cleanup-failure", err)
+				}
+				if f.released(t) {
+					t.Error("finalizer removed without --orphan-on-cleanup-failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("finalizeApplication = %v, want the finalizer forced off", err)
+			}
+			if !f.released(t) {
+				t.Error("finalizer kept after the attempt limit")
+			}
+			found := false
+			for _, e := range events {
+				if strings.Contains(e, "ResourcesOrphaned") && strings.Contains(e, "ConfigMap/shop-config") {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("events = %v, want ResourcesOrphaned naming ConfigMap/shop-config", events)
+			}
+		})
+	}
+}
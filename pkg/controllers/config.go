@@ -0,0 +1,132 @@
+// pkg/controllers/config.go
+// Tunable reconcile settings for the Application controller
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// ReconcileConfig holds the requeue intervals and controller-wide toggles used by
+// the reconcile loop
+type ReconcileConfig struct {
+	// InfraRequeue is how long to wait after provisioning infrastructure before deploying
+	InfraRequeue time.Duration
+	// DeployRequeue is how often to check on an application that is still deploying
+	DeployRequeue time.Duration
+	// ReadyRequeue is the periodic health check interval for Ready applications
+	ReadyRequeue time.Duration
+	// InfraFailureRequeue is the retry interval after infrastructure provisioning fails
+	InfraFailureRequeue time.Duration
+	// DeployFailureRequeue is the retry interval after creating app resources fails
+	DeployFailureRequeue time.Duration
+	// ReadinessErrorRequeue is the retry interval when the readiness check itself errors
+	ReadinessErrorRequeue time.Duration
+	// UnknownPhaseRequeue is the retry interval for applications in an unrecognized phase
+	UnknownPhaseRequeue time.Duration
+	// DependencyRequeue is how often a Pending application rechecks the
+	// Applications it depends on
+	DependencyRequeue time.Duration
+
+	// Environment is what "auto" resolves to: local, aws, or auto to detect it
+	// from the controller's environment variables
+	Environment v1alpha1.Environment
+
+	// DefaultZoneSpread adds a zone spread constraint to multi-replica apps that
+	// don't specify their own
+	DefaultZoneSpread bool
+	// MountDatabaseCredentials also mounts the database credentials Secret as
+	// files at DatabaseCredentialsMountPath
+	MountDatabaseCredentials bool
+	// InfraEnvPrecedence lets generated connection variables such as
+	// DATABASE_URL replace user env of the same name; by default the user's
+	// value is kept and the generated one dropped
+	InfraEnvPrecedence bool
+
+	// InfraNodeSelector is applied to every locally provisioned infrastructure
+	// pod; component nodeSelectors are merged over it
+	InfraNodeSelector map[string]string
+	// SpotNodeLabel ("key=value") identifies spot/preemptible nodes. Preemptible
+	// applications prefer them; infrastructure avoids them unless preemptible.
+	// Empty turns spot scheduling off.
+	SpotNodeLabel string
+
+	// StrictValidation treats spec warnings (such as conflicting storage sizes)
+	// as validation errors
+	StrictValidation bool
+
+	// Limits caps the infrastructure a single Application may request
+	Limits v1alpha1.InfrastructureLimits
+
+	// CreateNamespaces creates an Application's targetNamespace when it does
+	// not exist yet
+	CreateNamespaces bool
+	// WatchNamespace restricts the controller to one namespace; Applications
+	// there cannot deploy to another targetNamespace. Empty watches all.
+	WatchNamespace string
+
+	// AllowRecreate deletes (orphaning pods and volumes) and recreates
+	// infrastructure StatefulSets whose immutable fields changed
+	AllowRecreate bool
+
+	// SecureDefaults turns off settings an application must opt into, such as
+	// mounting the service account token
+	SecureDefaults bool
+
+	// CleanupMaxAttempts and CleanupTimeout bound how long a deleted
+	// cross-namespace Application retries its cleanup; zero is unbounded
+	CleanupMaxAttempts int
+	CleanupTimeout     time.Duration
+	// OrphanOnCleanupFailure releases the cleanup finalizer once those bounds
+	// are reached, leaving the remaining resources behind
+	OrphanOnCleanupFailure bool
+
+	// ImageRegistry is the mirror infrastructure and sidecar images are pulled
+	// through when an Application does not set its own
+	ImageRegistry string
+
+	// DisableLocalProvisioning rejects Applications whose infrastructure would
+	// run in the cluster, leaving only AWS and external components
+	DisableLocalProvisioning bool
+	// DisabledComponents names infrastructure components (postgresql, redis,
+	// s3, dynamodb, sqs, kafka) that Applications may not request at all
+	DisabledComponents []string
+
+	// Prune deletes managed infrastructure whose spec was removed from the Application
+	Prune bool
+
+	// WebhookAllowedHosts are the hosts ready webhooks may be sent to; an entry
+	// also allows its subdomains, and "*" allows any host. Empty sends none.
+	WebhookAllowedHosts []string
+
+	// MinReconcileInterval is the cooldown between rate-limited requeues of the
+	// same Application (after an error or Requeue); watch events and
+	// RequeueAfter are not throttled. Zero disables it.
+	MinReconcileInterval time.Duration
+	// MaxConcurrentReconciles is how many Applications are reconciled in parallel
+	MaxConcurrentReconciles int
+}
+
+// DefaultReconcileConfig returns the intervals the controller has always used
+func DefaultReconcileConfig() ReconcileConfig {
+	return ReconcileConfig{
+		InfraRequeue:          10 * time.Second,
+		DeployRequeue:         15 * time.Second,
+		ReadyRequeue:          5 * time.Minute,
+		InfraFailureRequeue:   5 * time.Minute,
+		DeployFailureRequeue:  2 * time.Minute,
+		ReadinessErrorRequeue: 30 * time.Second,
+		UnknownPhaseRequeue:   time.Minute,
+		DependencyRequeue:     30 * time.Second,
+
+		Environment: v1alpha1.EnvironmentAuto,
+
+		CleanupMaxAttempts: 10,
+		CleanupTimeout:     30 * time.Minute,
+
+		MinReconcileInterval:    time.Second,
+		MaxConcurrentReconciles: 1,
+	}
+}
@@ -0,0 +1,103 @@
+// pkg/controllers/config_hash.go
+// Checksums the pod configuration so config changes trigger a rolling restart
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configHash returns a SHA256 over the containers' env and, with
+// includeData, the data of every Secret and ConfigMap the pod references.
+// Secrets that don't exist yet are skipped; the pod cannot start without them
+// anyway.
+func (r *ApplicationController) configHash(ctx context.Context, namespace string, spec *corev1.PodSpec, includeData bool) (string, error) {
+	h := sha256.New()
+
+	secrets := map[string]bool{}
+	configMaps := map[string]bool{}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		env := append([]corev1.EnvVar{}, c.Env...)
+		sort.SliceStable(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+		for _, e := range env {
+			fmt.Fprintf(h, "env:%s:%s=%s\n", c.Name, e.Name, e.Value)
+			if e.ValueFrom == nil {
+				continue
+			}
+			if ref := e.ValueFrom.SecretKeyRef; ref != nil {
+				secrets[ref.Name] = true
+			}
+			if ref := e.ValueFrom.ConfigMapKeyRef; ref != nil {
+				configMaps[ref.Name] = true
+			}
+		}
+		for _, from := range c.EnvFrom {
+			if from.SecretRef != nil {
+				secrets[from.SecretRef.Name] = true
+			}
+			if from.ConfigMapRef != nil {
+				configMaps[from.ConfigMapRef.Name] = true
+			}
+		}
+	}
+	for _, v := range spec.Volumes {
+		if v.Secret != nil {
+			secrets[v.Secret.SecretName] = true
+		}
+		if v.ConfigMap != nil {
+			configMaps[v.ConfigMap.Name] = true
+		}
+	}
+	if !includeData {
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	for _, name := range sortedKeys(secrets) {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to read secret %s for config hash: %w", name, err)
+		}
+		for _, key := range sortedKeys(secret.Data) {
+			fmt.Fprintf(h, "secret:%s:%s=", name, key)
+			h.Write(secret.Data[key])
+			h.Write([]byte("\n"))
+		}
+	}
+
+	for _, name := range sortedKeys(configMaps) {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to read configmap %s for config hash: %w", name, err)
+		}
+		for _, key := range sortedKeys(cm.Data) {
+			fmt.Fprintf(h, "configmap:%s:%s=%s\n", name, key, cm.Data[key])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestConfigHashChangesWithEnv(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Env = map[string]string{"MODE": "a"}
+	r, _ := newTestController(t, app)
+
+	before, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	app.Spec.Env["MODE"] = "b"
+	after, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	if before.Annotations[v1alpha1.ConfigHashAnnotation] == after.Annotations[v1alpha1.ConfigHashAnnotation] {
+		t.Error("config hash did not change with the env")
+	}
+}
+
+func TestCronJobTemplateFollowsConfig(t *testing.T) {
+	app := newTestApplication("report")
+	app.Spec.Kind = v1alpha1.WorkloadCronJob
+	app.Spec.Schedule = "0 * * * *"
+	app.Spec.Env = map[string]string{"MODE": "a"}
+	r, _ := newTestController(t, app)
+
+	if err := r.createOrUpdateCronJob(testCtx, app); err != nil {
+		t.Fatalf("createOrUpdateCronJob: %v", err)
+	}
+	app.Spec.Env["MODE"] = "b"
+	changes, err := r.correctWorkloadDrift(testCtx, app)
+	if err != nil || len(changes) == 0 {
+		t.Fatalf("correctWorkloadDrift = %v, %v; want the job template updated", changes, err)
+	}
+	cronJob := &batchv1.CronJob{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: "report", Namespace: "default"}, cronJob); err != nil {
+		t.Fatal(err)
+	}
+	if env := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env; !hasEnv(env, "MODE", "b") {
+		t.Errorf("CronJob env = %v, want MODE=b", env)
+	}
+}
+
+func TestJobReplacedOnConfigChange(t *testing.T) {
+	app := newTestApplication("migrate")
+	app.Spec.Kind = v1alpha1.WorkloadJob
+	app.Spec.Env = map[string]string{"MODE": "a"}
+	r, recorder := newTestController(t, app)
+
+	if err := r.createOrUpdateJob(testCtx, app); err != nil {
+		t.Fatalf("createOrUpdateJob: %v", err)
+	}
+	if changes, _ := r.correctWorkloadDrift(testCtx, app); len(changes) != 0 {
+		t.Fatalf("unchanged Job reported drift: %v", changes)
+	}
+	app.Spec.Env["MODE"] = "b"
+	if changes, err := r.correctWorkloadDrift(testCtx, app); err != nil || len(changes) == 0 {
+		t.Fatalf("correctWorkloadDrift = %v, %v; want the Job re-run", changes, err)
+	}
+	job := &batchv1.Job{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: "migrate", Namespace: "default"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if !hasEnv(job.Spec.Template.Spec.Containers[0].Env, "MODE", "b") {
+		t.Errorf("Job env = %v, want MODE=b", job.Spec.Template.Spec.Containers[0].Env)
+	}
+	if events := drainEvents(recorder); len(events) == 0 {
+		t.Error("no JobReplaced event")
+	}
+}
+
+// hasEnv reports whether env sets name to value
+func hasEnv(env []corev1.EnvVar, name, value string) bool {
+	for _, e := range env {
+		if e.Name == name && e.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRolloutOnConfigChange(t *testing.T) {
+	for _, rollout := range []bool{true, false} {
+		t.Run(fmt.Sprintf("rollout=%t", rollout), func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "shop-config", Namespace: "default"},
+				Data:       map[string][]byte{"config.yaml": []byte("mode: a")},
+			}
+			app := newTestApplication("shop")
+			app.Spec.Env = map[string]string{"MODE": "a"}
+			app.Spec.SecretVolumes = []v1alpha1.SecretVolumeMount{{SecretName: "shop-config", MountPath: "/etc/shop"}}
+			app.Spec.RolloutOnConfigChange = &rollout
+			r, _ := newTestController(t, app, secret)
+
+			hash := func() string {
+				t.Helper()
+				template, err := r.buildPodTemplate(testCtx, app)
+				if err != nil {
+					t.Fatalf("buildPodTemplate: %v", err)
+				}
+				return template.Annotations[v1alpha1.ConfigHashAnnotation]
+			}
+			before := hash()
+
+			secret.Data["config.yaml"] = []byte("mode: b")
+			if err := r.Update(testCtx, secret); err != nil {
+				t.Fatal(err)
+			}
+			afterData := hash()
+			if changed := afterData != before; changed != rollout {
+				t.Errorf("config hash changed = %t with new Secret data, want %t", changed, rollout)
+			}
+
+			// The env is part of the pod spec and rolls the pods either way
+			app.Spec.Env["MODE"] = "b"
+			if hash() == afterData {
+				t.Error("config hash did not change with the env")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestReconcileUsesConfiguredIntervals(t *testing.T) {
+	app := newTestApplication("web")
+	r, _ := newTestController(t, app)
+	r.Config.InfraRequeue = 3 * time.Second
+	r.Config.DeployRequeue = 7 * time.Second
+	r.Config.ReadyRequeue = 11 * time.Minute
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	reconcileOnce := func() (time.Duration, v1alpha1.ApplicationPhase) {
+		t.Helper()
+		result, err := r.Reconcile(testCtx, req)
+		if err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		stored := &v1alpha1.Application{}
+		if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+			t.Fatal(err)
+		}
+		return result.RequeueAfter, stored.Status.Phase
+	}
+
+	if after, phase := reconcileOnce(); after != 3*time.Second || phase != v1alpha1.PhaseProvisioningInfra {
+		t.Errorf("after provisioning: requeue %s in %s, want 3s in ProvisioningInfrastructure", after, phase)
+	}
+	if after, phase := reconcileOnce(); after != 7*time.Second || phase != v1alpha1.PhaseDeploying {
+		t.Errorf("after deploying: requeue %s in %s, want 7s in Deploying", after, phase)
+	}
+	if after, _ := reconcileOnce(); after != 7*time.Second {
+		t.Errorf("while not ready: requeue %s, want 7s", after)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(testCtx, req.NamespacedName, deployment); err != nil {
+		t.Fatal(err)
+	}
+	deployment.Status.Replicas = 1
+	deployment.Status.ReadyReplicas = 1
+	deployment.Status.UpdatedReplicas = 1
+	deployment.Status.AvailableReplicas = 1
+	deployment.Status.ObservedGeneration = deployment.Generation
+	if err := r.Status().Update(testCtx, deployment); err != nil {
+		t.Fatal(err)
+	}
+	// The status write that marks it Ready triggers the first health check
+	if _, phase := reconcileOnce(); phase != v1alpha1.PhaseReady {
+		t.Fatalf("phase = %s once the Deployment is ready, want Ready", phase)
+	}
+	if after, _ := reconcileOnce(); after != 11*time.Minute {
+		t.Errorf("health check: requeue %s, want 11m", after)
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Validation rejects downwardEnv names that connection env vars use; the list
+// it checks against must cover every name the controller renders
+func TestConnectionEnvNamesCoverRenderedVars(t *testing.T) {
+	app := newTestApplication("web")
+	infra := &app.Spec.Infrastructure
+	infra.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	infra.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, Databases: []string{"sessions"}}
+	infra.S3 = &v1alpha1.S3Spec{Environment: v1alpha1.EnvironmentLocal}
+	infra.DynamoDB = &v1alpha1.DynamoDBSpec{Environment: v1alpha1.EnvironmentLocal}
+	infra.SQS = &v1alpha1.SQSSpec{Environment: v1alpha1.EnvironmentLocal}
+	infra.Kafka = &v1alpha1.KafkaSpec{Environment: v1alpha1.EnvironmentLocal}
+
+	status := &app.Status
+	status.DatabaseEndpoint, status.DatabaseReadEndpoint = "web-postgres:5432", "web-postgres-read:5432"
+	status.DatabaseEnvironment = v1alpha1.EnvironmentExternal
+	status.RedisEndpoint = "web-redis:6379"
+	status.S3BucketName, status.S3Endpoint, status.S3Environment = "web", "web-s3:9000", v1alpha1.EnvironmentLocal
+	status.DynamoDBTableName, status.DynamoDBEndpoint, status.DynamoDBEnvironment = "web", "web-dynamodb:8000", v1alpha1.EnvironmentLocal
+	status.SQSQueueName, status.SQSQueueURL, status.SQSEnvironment = "web", "http://web-sqs:9324/queue/web", v1alpha1.EnvironmentLocal
+	status.KafkaBrokers, status.KafkaEnvironment = "web-kafka:9092", v1alpha1.EnvironmentLocal
+
+	names := map[string]bool{}
+	for _, name := range app.ConnectionEnvNames() {
+		names[name] = true
+	}
+	for _, env := range connectionEnvVars(app.GetConnectionInfo()) {
+		if !names[env.Name] {
+			t.Errorf("%s is rendered but missing from ConnectionEnvNames", env.Name)
+		}
+	}
+}
+
+func TestBuildEnvironmentVariablesOrder(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Env = map[string]string{"ZONE": "a", "API_URL": "http://api", "LOG_LEVEL": "debug", "MODE": "prod", "BATCH": "10"}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Status.RedisEndpoint = "web-redis:6379"
+	r, _ := newTestController(t, app)
+
+	first := r.buildEnvironmentVariables(app)
+	for i := 0; i < 20; i++ {
+		if again := r.buildEnvironmentVariables(app); !reflect.DeepEqual(first, again) {
+			t.Fatalf("env changed between builds:\n%v\n%v", first, again)
+		}
+	}
+
+	var names []string
+	for _, e := range first {
+		names = append(names, e.Name)
+	}
+	want := []string{"API_URL", "BATCH", "LOG_LEVEL", "MODE", "ZONE", "REDIS_URL"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("env names = %v, want user vars sorted, then connection vars: %v", names, want)
+	}
+}
+
+func TestNamedRedisDatabases(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, Databases: []string{"cache", "sessions"}}
+	app.Status.RedisEndpoint = "web-redis:6379"
+	r, _ := newTestController(t, app)
+
+	env := r.buildEnvironmentVariables(app)
+	for name, value := range map[string]string{
+		"REDIS_URL":          "redis://web-redis:6379",
+		"REDIS_URL_CACHE":    "redis://web-redis:6379/1",
+		"REDIS_URL_SESSIONS": "redis://web-redis:6379/2",
+	} {
+		if !hasEnv(env, name, value) {
+			t.Errorf("env lacks %s=%s: %v", name, value, env)
+		}
+	}
+
+	// A single instance keeps only REDIS_URL
+	app.Spec.Infrastructure.Redis.Databases = nil
+	for _, e := range r.buildEnvironmentVariables(app) {
+		if strings.HasPrefix(e.Name, "REDIS_URL_") {
+			t.Errorf("%s rendered without named databases", e.Name)
+		}
+	}
+}
+
+func TestUserEnvOverridesConnectionEnv(t *testing.T) {
+	const userURL = "postgres://reporting@replica:5432/shop"
+	tests := []struct {
+		name            string
+		env             map[string]string
+		infraPrecedence bool
+		wantUserURL     bool
+	}{
+		{"no conflict", map[string]string{"LOG_LEVEL": "debug"}, false, false},
+		{"user value kept", map[string]string{"LOG_LEVEL": "debug", "DATABASE_URL": userURL}, false, true},
+		{"generated value kept", map[string]string{"LOG_LEVEL": "debug", "DATABASE_URL": userURL}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.Env = tt.env
+			app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, DatabaseName: "shop"}
+			app.Status.DatabaseEndpoint = "web-postgres:5432"
+			app.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+			r, _ := newTestController(t, app)
+			r.Config.InfraEnvPrecedence = tt.infraPrecedence
+
+			counts := map[string]int{}
+			var databaseURL string
+			for _, e := range r.buildEnvironmentVariables(app) {
+				counts[e.Name]++
+				if e.Name == "DATABASE_URL" {
+					databaseURL = e.Value
+				}
+			}
+			for name, n := range counts {
+				if n > 1 {
+					t.Errorf("%s set %d times", name, n)
+				}
+			}
+			if counts["LOG_LEVEL"] != 1 {
+				t.Error("LOG_LEVEL from the spec missing")
+			}
+			if (databaseURL == userURL) != tt.wantUserURL {
+				t.Errorf("DATABASE_URL = %q, want the user value %t", databaseURL, tt.wantUserURL)
+			}
+			if !tt.wantUserURL && !strings.HasPrefix(databaseURL, "postgres://") {
+				t.Errorf("DATABASE_URL = %q, want the generated connection URL", databaseURL)
+			}
+		})
+	}
+}
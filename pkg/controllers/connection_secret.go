@@ -0,0 +1,137 @@
+// pkg/controllers/connection_secret.go
+// Infrastructure connection details as env vars or a single Secret
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// connectionEnvVars renders the infrastructure connection details as env vars
+func connectionEnvVars(conn v1alpha1.ConnectionInfo) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
+
+	if conn.Database != nil {
+		if conn.Database.CredentialsSecretName != "" {
+			// Credentials come from the referenced Secret and are expanded into DATABASE_URL
+			envVars = append(envVars,
+				secretEnvVar("DATABASE_USER", conn.Database.CredentialsSecretName, "username"),
+				secretEnvVar("DATABASE_PASSWORD", conn.Database.CredentialsSecretName, "password"),
+			)
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: "DATABASE_URL", Value: conn.Database.URL})
+		if conn.Database.ReadURL != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: "DATABASE_READ_URL", Value: conn.Database.ReadURL})
+		}
+	}
+
+	if conn.Cache != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "REDIS_URL", Value: conn.Cache.URL})
+		for _, db := range conn.Cache.Databases {
+			envVars = append(envVars, corev1.EnvVar{Name: db.EnvName(), Value: db.URL})
+		}
+	}
+
+	if conn.Storage != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "S3_BUCKET", Value: conn.Storage.BucketName})
+
+		if conn.Storage.URL != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: "S3_ENDPOINT", Value: conn.Storage.URL})
+			envVars = append(envVars, corev1.EnvVar{Name: "S3_ACCESS_KEY", Value: conn.Storage.AccessKey})
+			envVars = append(envVars, corev1.EnvVar{Name: "S3_SECRET_KEY", Value: conn.Storage.SecretKey})
+		}
+	}
+
+	if conn.DynamoDB != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "DYNAMODB_TABLE", Value: conn.DynamoDB.TableName})
+		if conn.DynamoDB.URL != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: "DYNAMODB_ENDPOINT", Value: conn.DynamoDB.URL})
+		}
+	}
+
+	if conn.Queue != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "SQS_QUEUE_NAME", Value: conn.Queue.QueueName})
+		if conn.Queue.URL != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: "SQS_QUEUE_URL", Value: conn.Queue.URL})
+		}
+		if conn.Queue.Endpoint != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: "SQS_ENDPOINT", Value: conn.Queue.Endpoint})
+		}
+	}
+
+	if conn.Kafka != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "KAFKA_BROKERS", Value: conn.Kafka.Brokers})
+	}
+
+	return envVars
+}
+
+// splitConnectionEnv separates connection env vars into Secret data and the
+// vars that must stay inline: those read from another Secret, and those that
+// expand $(VAR) references, which only works for inline env
+func splitConnectionEnv(vars []corev1.EnvVar) (map[string]string, []corev1.EnvVar) {
+	data := map[string]string{}
+	var inline []corev1.EnvVar
+	for _, v := range vars {
+		if v.ValueFrom != nil || strings.Contains(v.Value, "$(") {
+			inline = append(inline, v)
+			continue
+		}
+		data[v.Name] = v.Value
+	}
+	return data, inline
+}
+
+// reconcileConnectionSecret writes the <app>-connections Secret that the app
+// container loads with envFrom when connectionSecret is enabled. It is always
+// written for infrastructureOnly Applications, whose workload lives elsewhere.
+func (r *ApplicationController) reconcileConnectionSecret(ctx context.Context, app *v1alpha1.Application) error {
+	if !app.Spec.ConnectionSecret && !app.Spec.InfrastructureOnly {
+		return nil
+	}
+	data, _ := splitConnectionEnv(connectionEnvVars(app.GetConnectionInfo()))
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetConnectionSecretName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: data,
+	}
+
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create connection secret: %w", err)
+		}
+		existing := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+			return fmt.Errorf("failed to get connection secret: %w", err)
+		}
+		current := make(map[string]string, len(existing.Data))
+		for k, v := range existing.Data {
+			current[k] = string(v)
+		}
+		if reflect.DeepEqual(current, data) {
+			return nil
+		}
+		existing.Data = nil
+		existing.StringData = data
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update connection secret: %w", err)
+		}
+		appLogger(ctx, app).Info("Updated connection secret", "secret", desired.Name)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestConnectionSecretMatchesConnectionInfo(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.ConnectionSecret = true
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Spec.Infrastructure.S3 = &v1alpha1.S3Spec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionInfrastructure(testCtx, app); err != nil {
+		t.Fatalf("provisionInfrastructure: %v", err)
+	}
+	if err := r.reconcileConnectionSecret(testCtx, app); err != nil {
+		t.Fatalf("reconcileConnectionSecret: %v", err)
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetConnectionSecretName(), Namespace: "default"}, secret); err != nil {
+		t.Fatalf("get connection secret: %v", err)
+	}
+	conn := app.GetConnectionInfo()
+	for key, want := range map[string]string{
+		"DATABASE_URL":  conn.Database.URL,
+		"REDIS_URL":     conn.Cache.URL,
+		"S3_BUCKET":     conn.Storage.BucketName,
+		"S3_ENDPOINT":   conn.Storage.URL,
+		"S3_ACCESS_KEY": conn.Storage.AccessKey,
+		"S3_SECRET_KEY": conn.Storage.SecretKey,
+	} {
+		if got, ok := secret.StringData[key]; !ok || got != want {
+			t.Errorf("%s = %q (present %t), want %q", key, got, ok, want)
+		}
+	}
+
+	// The container loads the Secret instead of carrying the values inline
+	container := r.buildAppContainer(app)
+	if len(container.EnvFrom) != 1 || container.EnvFrom[0].SecretRef == nil || container.EnvFrom[0].SecretRef.Name != secret.Name {
+		t.Errorf("envFrom = %+v, want the connection secret", container.EnvFrom)
+	}
+	for _, env := range container.Env {
+		if env.Name == "DATABASE_URL" || env.Name == "REDIS_URL" {
+			t.Errorf("%s is inline as well as in the connection secret", env.Name)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// pkg/controllers/controller_metrics.go
+// Prometheus metrics about the controller itself, served on the manager's
+// metrics endpoint
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Metric names, shared with the generated Grafana dashboard
+const (
+	MetricReconcileTotal       = "orion_reconcile_total"
+	MetricProvisioningDuration = "orion_provisioning_duration_seconds"
+	MetricApplications         = "orion_applications"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricReconcileTotal,
+		Help: "Application reconciles by result (success or error).",
+	}, []string{"result"})
+
+	provisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    MetricProvisioningDuration,
+		Help:    "Time taken to provision one infrastructure component.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"component", "result"})
+
+	applicationsByPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricApplications,
+		Help: "Applications known to the controller by phase.",
+	}, []string{"phase"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileTotal, provisioningDuration, applicationsByPhase)
+}
+
+// resultLabel turns an error into the result label value
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// observeProvisioning records how long provisioning a component took
+func observeProvisioning(component string, start time.Time, err error) {
+	provisioningDuration.WithLabelValues(component, resultLabel(err)).Observe(time.Since(start).Seconds())
+}
+
+// phaseTracker remembers the last phase of each Application so the
+// applications-by-phase gauge can be recomputed as they change
+type phaseTracker struct {
+	mu     sync.Mutex
+	phases map[types.NamespacedName]v1alpha1.ApplicationPhase
+}
+
+var trackedPhases = &phaseTracker{phases: map[types.NamespacedName]v1alpha1.ApplicationPhase{}}
+
+// set records an Application's phase; an empty phase forgets it
+func (t *phaseTracker) set(key types.NamespacedName, phase v1alpha1.ApplicationPhase) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if phase == "" {
+		delete(t.phases, key)
+	} else {
+		t.phases[key] = phase
+	}
+
+	counts := map[v1alpha1.ApplicationPhase]float64{}
+	for _, p := range t.phases {
+		counts[p]++
+	}
+	applicationsByPhase.Reset()
+	for p, n := range counts {
+		applicationsByPhase.WithLabelValues(string(p)).Set(n)
+	}
+}
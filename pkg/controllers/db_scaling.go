@@ -0,0 +1,86 @@
+// pkg/controllers/db_scaling.go
+// Steps the local database StatefulSet toward its requested replica count
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// reconcileDatabaseScale moves the local PostgreSQL StatefulSet toward the
+// replica count it may run, which is capped at a single primary, so in
+// practice it steps StatefulSets scaled up before the cap back down. Scaling
+// up is applied at once. Scaling down removes one replica per pass and only
+// while every current replica is ready; the StatefulSet removes the highest
+// ordinal first, so the primary (ordinal 0) is always the last pod standing.
+// It reports whether the status changed.
+func (r *ApplicationController) reconcileDatabaseScale(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	if !app.NeedsDatabase() || !app.IsLocalDatabase() {
+		return false, nil
+	}
+	logger := componentLogger(ctx, app, componentDatabase)
+
+	desired := app.GetLocalDatabaseReplicas()
+	if desired < 1 {
+		return false, fmt.Errorf("refusing to scale database below 1 replica")
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.GetPostgresName(), Namespace: app.GetTargetNamespace()}, sts); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get PostgreSQL StatefulSet: %w", err)
+	}
+	current := int32(1)
+	if sts.Spec.Replicas != nil {
+		current = *sts.Spec.Replicas
+	}
+
+	changed := app.Status.DatabaseReplicas != current
+	app.Status.DatabaseReplicas = current
+	if current == desired {
+		return changed, nil
+	}
+
+	next := desired
+	if desired < current {
+		settled := sts.Status.ObservedGeneration >= sts.Generation && sts.Status.ReadyReplicas >= current
+		if !settled {
+			message := fmt.Sprintf("Scaling down %d -> %d replicas: waiting for %d/%d ready", current, desired, sts.Status.ReadyReplicas, current)
+			app.Status.SetComponentStatus(componentDatabase, true, message)
+			logger.Info("Waiting for database replicas before scaling down", "ready", sts.Status.ReadyReplicas, "replicas", current)
+			return true, nil
+		}
+		next = current - 1
+	}
+
+	patch := client.MergeFrom(sts.DeepCopy())
+	sts.Spec.Replicas = &next
+	if err := r.Patch(ctx, sts, patch); err != nil {
+		return changed, fmt.Errorf("failed to scale PostgreSQL StatefulSet to %d replicas: %w", next, err)
+	}
+	app.Status.DatabaseReplicas = next
+
+	if next < current {
+		logger.Info("Scaling down database", "from", current, "to", next, "target", desired)
+		r.recordEvent(app, corev1.EventTypeNormal, "ScalingDown", fmt.Sprintf("PostgreSQL %d -> %d replicas (target %d)", current, next, desired))
+	} else {
+		logger.Info("Scaling up database", "from", current, "to", next)
+		r.recordEvent(app, corev1.EventTypeNormal, "ScalingUp", fmt.Sprintf("PostgreSQL %d -> %d replicas", current, next))
+	}
+	message := "Ready"
+	if next != desired {
+		message = fmt.Sprintf("Scaling down %d -> %d replicas", next, desired)
+	}
+	app.Status.SetComponentStatus(componentDatabase, true, message)
+	return true, nil
+}
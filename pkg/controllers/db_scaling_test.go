@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestDatabaseScaleDownOneAtATime(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, recorder := newTestController(t, app)
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	key := client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}
+
+	setReplicas := func(replicas, ready int32) {
+		t.Helper()
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(testCtx, key, sts); err != nil {
+			t.Fatal(err)
+		}
+		sts.Spec.Replicas = &replicas
+		if err := r.Update(testCtx, sts); err != nil {
+			t.Fatal(err)
+		}
+		sts.Status.ReadyReplicas = ready
+		sts.Status.ObservedGeneration = sts.Generation
+		if err := r.Status().Update(testCtx, sts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	replicas := func() int32 {
+		t.Helper()
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(testCtx, key, sts); err != nil {
+			t.Fatal(err)
+		}
+		return *sts.Spec.Replicas
+	}
+	// A StatefulSet scaled to 3 before the single-primary cap, all ready
+	setReplicas(3, 3)
+
+	if _, err := r.reconcileDatabaseScale(testCtx, app); err != nil {
+		t.Fatalf("reconcileDatabaseScale: %v", err)
+	}
+	if got := replicas(); got != 2 {
+		t.Fatalf("replicas = %d after one pass, want a single step to 2", got)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "ScalingDown") {
+		t.Errorf("events = %v, want ScalingDown", events)
+	}
+
+	// The next step waits until every remaining replica is ready
+	setReplicas(2, 1)
+	if _, err := r.reconcileDatabaseScale(testCtx, app); err != nil {
+		t.Fatalf("reconcileDatabaseScale: %v", err)
+	}
+	if got := replicas(); got != 2 {
+		t.Errorf("replicas = %d while a replica is unready, want 2", got)
+	}
+	if c := app.Status.Components; len(c) == 0 || !strings.Contains(c[0].Message, "waiting for 1/2 ready") {
+		t.Errorf("components = %+v, want the wait reported", c)
+	}
+
+	setReplicas(2, 2)
+	if _, err := r.reconcileDatabaseScale(testCtx, app); err != nil {
+		t.Fatalf("reconcileDatabaseScale: %v", err)
+	}
+	if got := replicas(); got != 1 || app.Status.DatabaseReplicas != 1 {
+		t.Errorf("replicas = %d, status %d; want the primary alone", got, app.Status.DatabaseReplicas)
+	}
+}
+
+func TestDatabaseScaleToZeroRejected(t *testing.T) {
+	zero := int32(0)
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, Replicas: &zero}
+
+	if err := app.ValidateSpec(); err == nil || !strings.Contains(err.Error(), "scaled to zero") {
+		t.Errorf("ValidateSpec() = %v, want zero replicas rejected", err)
+	}
+	r, _ := newTestController(t, app)
+	if _, err := r.reconcileDatabaseScale(testCtx, app); err == nil {
+		t.Error("reconcileDatabaseScale accepted zero replicas")
+	}
+}
@@ -0,0 +1,37 @@
+// pkg/controllers/db_wait.go
+// Holds application pods until their database accepts connections
+
+package controllers
+
+import (
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// databaseWaitImage only needs a shell and nc
+const databaseWaitImage = "busybox:1.36"
+
+// databaseWaitContainer returns the init container that polls the database
+// endpoint until it accepts TCP connections, or nil when the Application has
+// no database or its endpoint is not known yet
+func databaseWaitContainer(app *v1alpha1.Application) *corev1.Container {
+	endpoint := app.Status.DatabaseEndpoint
+	if !app.NeedsDatabase() || endpoint == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		// External endpoints may leave the port at its default
+		host, port = endpoint, "5432"
+	}
+	return &corev1.Container{
+		Name:  v1alpha1.DatabaseWaitContainer,
+		Image: app.InfraImage(databaseWaitImage),
+		Command: []string{"sh", "-c",
+			fmt.Sprintf("until nc -z -w 2 %s %s; do echo waiting for database %s:%s; sleep 2; done", host, port, host, port)},
+	}
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestInitContainersRunBeforeDatabaseWait(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.InitContainers = []corev1.Container{
+		{Name: "fetch-assets", Image: "busybox:1.36"},
+		{Name: "preflight", Image: "busybox:1.36"},
+	}
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	// Until the database has an endpoint there is nothing to wait for
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := containerNames(template.Spec.InitContainers); len(got) != 2 {
+		t.Fatalf("init containers = %v before the database endpoint is known", got)
+	}
+
+	app.Status.DatabaseEndpoint = app.GetPostgresName() + ":5432"
+	template, err = r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"fetch-assets", "preflight", v1alpha1.DatabaseWaitContainer}
+	got := containerNames(template.Spec.InitContainers)
+	if len(got) != len(want) {
+		t.Fatalf("init containers = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("init containers = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInitContainerCannotTakeDatabaseWaitName(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.InitContainers = []corev1.Container{{Name: v1alpha1.DatabaseWaitContainer, Image: "busybox:1.36"}}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("init container named after the database wait container accepted")
+	}
+}
+
+func containerNames(containers []corev1.Container) []string {
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,144 @@
+// pkg/controllers/defaults.go
+// Platform-wide default InfrastructureSpec loaded from a ConfigMap
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/yaml"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// DefaultsConfigMapKey is the ConfigMap key holding the default InfrastructureSpec as YAML
+const DefaultsConfigMapKey = "infrastructure"
+
+// ImageProfilesConfigMapKey is the optional ConfigMap key holding extra image
+// profiles as YAML, keyed by image repository; they override the built-in ones
+const ImageProfilesConfigMapKey = "imageProfiles"
+
+// InfrastructureDefaults holds the current platform defaults, safe for
+// concurrent use by reconciles while the ConfigMap is reloaded
+type InfrastructureDefaults struct {
+	mu       sync.RWMutex
+	spec     v1alpha1.InfrastructureSpec
+	profiles map[string]v1alpha1.ImageProfile
+}
+
+// ImageProfiles returns the built-in image profiles merged with the ones from
+// the ConfigMap. A nil receiver returns the built-in profiles.
+func (d *InfrastructureDefaults) ImageProfiles() map[string]v1alpha1.ImageProfile {
+	profiles := make(map[string]v1alpha1.ImageProfile, len(v1alpha1.BuiltinImageProfiles))
+	for image, profile := range v1alpha1.BuiltinImageProfiles {
+		profiles[image] = profile
+	}
+	if d == nil {
+		return profiles
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for image, profile := range d.profiles {
+		profiles[image] = profile
+	}
+	return profiles
+}
+
+// Get returns a copy of the current defaults
+func (d *InfrastructureDefaults) Get() v1alpha1.InfrastructureSpec {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var out v1alpha1.InfrastructureSpec
+	d.spec.DeepCopyInto(&out)
+	return out
+}
+
+// Set replaces the current defaults
+func (d *InfrastructureDefaults) Set(spec v1alpha1.InfrastructureSpec) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.spec = spec
+}
+
+// Load reads the defaults ConfigMap. A missing ConfigMap resets to the
+// built-in defaults (no overrides).
+func (d *InfrastructureDefaults) Load(ctx context.Context, reader client.Reader, key types.NamespacedName) error {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, cm); err != nil {
+		if errors.IsNotFound(err) {
+			d.Set(v1alpha1.InfrastructureSpec{})
+			d.setImageProfiles(nil)
+			return nil
+		}
+		return fmt.Errorf("failed to get defaults ConfigMap %s: %w", key, err)
+	}
+
+	spec := v1alpha1.InfrastructureSpec{}
+	if err := yaml.UnmarshalStrict([]byte(cm.Data[DefaultsConfigMapKey]), &spec); err != nil {
+		return fmt.Errorf("failed to parse %q in ConfigMap %s: %w", DefaultsConfigMapKey, key, err)
+	}
+	var profiles map[string]v1alpha1.ImageProfile
+	if err := yaml.UnmarshalStrict([]byte(cm.Data[ImageProfilesConfigMapKey]), &profiles); err != nil {
+		return fmt.Errorf("failed to parse %q in ConfigMap %s: %w", ImageProfilesConfigMapKey, key, err)
+	}
+	d.Set(spec)
+	d.setImageProfiles(profiles)
+	return nil
+}
+
+func (d *InfrastructureDefaults) setImageProfiles(profiles map[string]v1alpha1.ImageProfile) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.profiles = profiles
+}
+
+// DefaultsCacheConfig limits the ConfigMap informer to the defaults ConfigMap,
+// the only ConfigMap watched; other ConfigMaps are read from the API server
+// (see UncachedObjects)
+func DefaultsCacheConfig(key types.NamespacedName) cache.ByObject {
+	return cache.ByObject{
+		Namespaces: map[string]cache.Config{key.Namespace: {}},
+		Field:      fields.OneTermEqualSelector("metadata.name", key.Name),
+	}
+}
+
+// DefaultsReconciler reloads InfrastructureDefaults whenever its ConfigMap changes
+type DefaultsReconciler struct {
+	client.Client
+	Defaults  *InfrastructureDefaults
+	ConfigMap types.NamespacedName
+}
+
+// Reconcile reloads the defaults from the watched ConfigMap
+func (r *DefaultsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("configmap", r.ConfigMap.String())
+	if err := r.Defaults.Load(ctx, r.Client, r.ConfigMap); err != nil {
+		logger.Error(err, "Failed to reload infrastructure defaults")
+		return ctrl.Result{}, err
+	}
+	logger.Info("Reloaded infrastructure defaults")
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager watches only the configured defaults ConfigMap
+func (r *DefaultsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isDefaults := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.ConfigMap.Namespace && obj.GetName() == r.ConfigMap.Name
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("infrastructure-defaults").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(isDefaults)).
+		Complete(r)
+}
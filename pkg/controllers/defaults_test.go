@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestDefaultsCacheConfig(t *testing.T) {
+	key := types.NamespacedName{Namespace: "orion-system", Name: "orion-defaults"}
+	config := DefaultsCacheConfig(key)
+
+	if _, ok := config.Namespaces["orion-system"]; !ok || len(config.Namespaces) != 1 {
+		t.Errorf("namespaces = %v, want only orion-system", config.Namespaces)
+	}
+	if !config.Field.Matches(fields.Set{"metadata.name": "orion-defaults"}) {
+		t.Error("field selector does not match the defaults ConfigMap")
+	}
+	if config.Field.Matches(fields.Set{"metadata.name": "app-config"}) {
+		t.Error("field selector matches another ConfigMap")
+	}
+}
+
+func TestImageProfilesFromConfigMap(t *testing.T) {
+	key := types.NamespacedName{Namespace: "orion-system", Name: "orion-defaults"}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data: map[string]string{ImageProfilesConfigMapKey: `
+example/shop: {port: 8080, healthCheckPath: /healthz}
+nginx: {port: 8081, healthCheckPath: /status}
+`},
+	}
+	r, _ := newTestController(t, cm)
+	defaults := &InfrastructureDefaults{}
+	if err := defaults.Load(testCtx, r.Client, key); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		image    string
+		wantPort int32
+		wantPath string
+	}{
+		{"example/shop:1.0", 8080, "/healthz"},
+		{"nginx:1.25", 8081, "/status"},
+		{"redis:7", 6379, ""},
+		{"example/other:1.0", 0, ""},
+	}
+	for _, tt := range tests {
+		app := newTestApplication("shop")
+		app.Spec.Image = tt.image
+		app.Spec.UseImageProfile = true
+		app.ApplyImageProfile(defaults.ImageProfiles())
+		if app.Spec.Port != tt.wantPort || app.Spec.HealthCheckPath != tt.wantPath {
+			t.Errorf("%s: port %d path %q, want %d %q", tt.image, app.Spec.Port, app.Spec.HealthCheckPath, tt.wantPort, tt.wantPath)
+		}
+	}
+
+	// Without the ConfigMap only the built-in profiles remain
+	if err := r.Delete(testCtx, cm); err != nil {
+		t.Fatal(err)
+	}
+	if err := defaults.Load(testCtx, r.Client, key); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	profiles := defaults.ImageProfiles()
+	if _, ok := profiles["example/shop"]; ok {
+		t.Error("ConfigMap profile kept after the ConfigMap was deleted")
+	}
+	if profiles["nginx"] != v1alpha1.BuiltinImageProfiles["nginx"] {
+		t.Errorf("nginx profile = %+v, want the built-in one", profiles["nginx"])
+	}
+}
@@ -0,0 +1,141 @@
+// pkg/controllers/dependencies.go
+// Ordering between Applications through spec.dependsOn
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// gateOnDependencies keeps the Application Pending until every Application it
+// depends on is Ready. A cycle through the Application keeps it Pending too:
+// editing any Application on the cycle can break it, so it is reported and
+// checked again rather than failed for good. waiting reports whether the
+// reconcile must stop here.
+func (r *ApplicationController) gateOnDependencies(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, bool, error) {
+	if len(app.Spec.DependsOn) == 0 {
+		return ctrl.Result{}, false, nil
+	}
+	logger := appLogger(ctx, app)
+
+	cycle, err := r.dependencyCycle(ctx, app)
+	if err != nil {
+		return ctrl.Result{}, true, err
+	}
+	if cycle != nil {
+		message := fmt.Sprintf("Dependency cycle: %s", strings.Join(cycle, " -> "))
+		if app.Status.Phase != v1alpha1.PhasePending || app.Status.Message != message {
+			logger.Info("Application dependencies form a cycle", "cycle", cycle)
+			r.recordEvent(app, corev1.EventTypeWarning, "DependencyCycle", message)
+			app.UpdateStatus(v1alpha1.PhasePending, message)
+			if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+				return ctrl.Result{}, true, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: r.Config.DependencyRequeue}, true, nil
+	}
+
+	unready, err := r.unreadyDependencies(ctx, app)
+	if err != nil {
+		return ctrl.Result{}, true, err
+	}
+	if len(unready) == 0 {
+		return ctrl.Result{}, false, nil
+	}
+	message := fmt.Sprintf("Waiting for dependencies: %s", strings.Join(unready, ", "))
+	if app.Status.Phase != v1alpha1.PhasePending || app.Status.Message != message {
+		logger.Info("Waiting for dependencies", "dependencies", unready)
+		app.UpdateStatus(v1alpha1.PhasePending, message)
+		if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+			return ctrl.Result{}, true, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.Config.DependencyRequeue}, true, nil
+}
+
+// unreadyDependencies describes each dependency that is not Ready yet
+func (r *ApplicationController) unreadyDependencies(ctx context.Context, app *v1alpha1.Application) ([]string, error) {
+	var unready []string
+	for _, name := range app.Spec.DependsOn {
+		dep := &v1alpha1.Application{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: name}, dep)
+		switch {
+		case errors.IsNotFound(err):
+			unready = append(unready, fmt.Sprintf("%s (not found)", name))
+		case err != nil:
+			return nil, fmt.Errorf("failed to get dependency %s: %w", name, err)
+		case !dep.IsReady():
+			phase := dep.Status.Phase
+			if phase == "" {
+				phase = v1alpha1.PhasePending
+			}
+			unready = append(unready, fmt.Sprintf("%s (%s)", name, phase))
+		}
+	}
+	return unready, nil
+}
+
+// dependencyCycle follows dependsOn from the Application and returns the path
+// back to it, or nil. Cycles that do not include the Application are left to
+// the Applications on them; missing dependencies end a path.
+func (r *ApplicationController) dependencyCycle(ctx context.Context, app *v1alpha1.Application) ([]string, error) {
+	visited := map[string]bool{}
+	var visit func(name string, deps []string, path []string) ([]string, error)
+	visit = func(name string, deps []string, path []string) ([]string, error) {
+		path = append(path, name)
+		for _, dep := range deps {
+			if dep == app.Name {
+				return append(path, dep), nil
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+
+			next := &v1alpha1.Application{}
+			err := r.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: dep}, next)
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dependency %s: %w", dep, err)
+			}
+			if cycle, err := visit(dep, next.Spec.DependsOn, path); cycle != nil || err != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+	return visit(app.Name, app.Spec.DependsOn, nil)
+}
+
+// dependentApplications maps an Application to the Applications in its
+// namespace that depend on it
+func (r *ApplicationController) dependentApplications(ctx context.Context, obj client.Object) []reconcile.Request {
+	apps := &v1alpha1.ApplicationList{}
+	if err := r.List(ctx, apps, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Applications for dependents", "application", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, app := range apps.Items {
+		for _, dep := range app.Spec.DependsOn {
+			if dep == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&app)})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestDependencyGatesProvisioning(t *testing.T) {
+	db := newTestApplication("db")
+	db.Status.Phase = v1alpha1.PhaseProvisioningInfra
+	app := newTestApplication("api")
+	app.Spec.DependsOn = []string{"db"}
+	r, _ := newTestController(t, db, app)
+
+	result, waiting, err := r.gateOnDependencies(testCtx, app)
+	if err != nil || !waiting || result.RequeueAfter == 0 {
+		t.Fatalf("gateOnDependencies = %v, %t, %v; want a requeue while db is not Ready", result, waiting, err)
+	}
+	if app.Status.Phase != v1alpha1.PhasePending || !strings.Contains(app.Status.Message, "db (ProvisioningInfrastructure)") {
+		t.Errorf("status = %s %q, want Pending waiting for db", app.Status.Phase, app.Status.Message)
+	}
+
+	db.Status.Phase = v1alpha1.PhaseReady
+	db.Status.ReadyReplicas = 1
+	if err := r.Status().Update(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+	if _, waiting, err := r.gateOnDependencies(testCtx, app); waiting || err != nil {
+		t.Errorf("gateOnDependencies = %t, %v; want to proceed once db is Ready", waiting, err)
+	}
+}
+
+func TestDependencyCycleStaysPending(t *testing.T) {
+	a := newTestApplication("a")
+	a.Spec.DependsOn = []string{"b"}
+	b := newTestApplication("b")
+	b.Spec.DependsOn = []string{"a"}
+	r, recorder := newTestController(t, a, b)
+
+	result, waiting, err := r.gateOnDependencies(testCtx, a)
+	if err != nil || !waiting || result.RequeueAfter == 0 {
+		t.Fatalf("gateOnDependencies = %v, %t, %v; want a requeue on a cycle", result, waiting, err)
+	}
+	if a.Status.Phase != v1alpha1.PhasePending || a.Status.Message != "Dependency cycle: a -> b -> a" {
+		t.Errorf("status = %s %q, want Pending reporting the cycle", a.Status.Phase, a.Status.Message)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "DependencyCycle") {
+		t.Errorf("events = %v, want DependencyCycle", events)
+	}
+
+	// Breaking the cycle on the other Application lets this one proceed
+	b.Spec.DependsOn = nil
+	if err := r.Update(testCtx, b); err != nil {
+		t.Fatal(err)
+	}
+	if cycle, err := r.dependencyCycle(testCtx, a); cycle != nil || err != nil {
+		t.Errorf("dependencyCycle = %v, %v after the cycle was broken", cycle, err)
+	}
+}
@@ -0,0 +1,211 @@
+// pkg/controllers/devtools.go
+// Web UIs for inspecting local infrastructure during development
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	pgAdminImage        = "dpage/pgadmin4:8.2"
+	redisCommanderImage = "ghcr.io/joeferner/redis-commander:0.8.1"
+
+	// Login for the local pgAdmin; the password is generated into the
+	// <app>-pgadmin Secret the first time pgAdmin is provisioned
+	pgAdminEmail       = "admin@orion.local"
+	pgAdminPasswordKey = "password"
+)
+
+// provisionDevTools creates pgAdmin and Redis Commander next to the local
+// PostgreSQL and Redis. Nothing is created for AWS or external components.
+func (r *ApplicationController) provisionDevTools(ctx context.Context, app *v1alpha1.Application) error {
+	if !app.Spec.Infrastructure.DevTools {
+		return nil
+	}
+	logger := componentLogger(ctx, app, componentDevTools)
+
+	if app.NeedsDatabase() && app.Status.DatabaseEnvironment == v1alpha1.EnvironmentLocal {
+		if err := r.provisionPgAdmin(ctx, app); err != nil {
+			return err
+		}
+		logger.Info("pgAdmin available", "endpoint", fmt.Sprintf("%s:80", app.ChildName("pgadmin")),
+			"email", pgAdminEmail, "passwordSecret", app.ChildName("pgadmin"))
+	}
+
+	if app.NeedsCache() && app.Status.RedisEnvironment == v1alpha1.EnvironmentLocal {
+		env := []corev1.EnvVar{
+			{Name: "REDIS_HOSTS", Value: fmt.Sprintf("local:%s", app.Status.RedisEndpoint)},
+		}
+		if err := r.createDevToolDeployment(ctx, app, "redis-commander", redisCommanderImage, 8081, env, nil, nil); err != nil {
+			return err
+		}
+		logger.Info("Redis Commander available", "endpoint", fmt.Sprintf("%s:8081", app.ChildName("redis-commander")))
+	}
+
+	return nil
+}
+
+// provisionPgAdmin runs pgAdmin with the local database pre-registered
+func (r *ApplicationController) provisionPgAdmin(ctx context.Context, app *v1alpha1.Application) error {
+	servers, err := json.Marshal(map[string]interface{}{
+		"Servers": map[string]interface{}{
+			"1": map[string]interface{}{
+				"Name":          app.Name,
+				"Group":         "Orion",
+				"Host":          app.GetPostgresName(),
+				"Port":          5432,
+				"MaintenanceDB": app.GetDatabaseName(),
+				"Username":      v1alpha1.LocalDatabaseUser,
+				"SSLMode":       "prefer",
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.ChildName("pgadmin"),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": componentDevTools, "managed-by": "orion-platform"},
+		},
+		Data: map[string]string{"servers.json": string(servers)},
+	}
+	if err := r.createOwned(ctx, app, configMap); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pgAdmin ConfigMap: %w", err)
+	}
+
+	if err := r.ensurePgAdminSecret(ctx, app); err != nil {
+		return err
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "PGADMIN_DEFAULT_EMAIL", Value: pgAdminEmail},
+		{
+			Name: "PGADMIN_DEFAULT_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: app.ChildName("pgadmin")},
+					Key:                  pgAdminPasswordKey,
+				},
+			},
+		},
+		{Name: "PGADMIN_CONFIG_SERVER_MODE", Value: "False"},
+		{Name: "PGADMIN_SERVER_JSON_FILE", Value: "/pgadmin4/orion/servers.json"},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: "servers",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+				},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{{Name: "servers", MountPath: "/pgadmin4/orion", ReadOnly: true}}
+
+	return r.createDevToolDeployment(ctx, app, "pgadmin", pgAdminImage, 80, env, volumes, mounts)
+}
+
+// ensurePgAdminSecret creates the <app>-pgadmin Secret with a random login
+// password. An existing Secret is kept, so the password survives reconciles
+// and can be replaced by hand.
+func (r *ApplicationController) ensurePgAdminSecret(ctx context.Context, app *v1alpha1.Application) error {
+	password := make([]byte, 16)
+	if _, err := rand.Read(password); err != nil {
+		return fmt.Errorf("failed to generate pgAdmin password: %w", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.ChildName("pgadmin"),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": componentDevTools, "managed-by": "orion-platform"},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{pgAdminPasswordKey: hex.EncodeToString(password)},
+	}
+	if err := r.createOwned(ctx, app, secret); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pgAdmin Secret: %w", err)
+	}
+	return nil
+}
+
+// createDevToolDeployment creates a single-replica UI Deployment and its Service
+// named <app>-<tool>
+func (r *ApplicationController) createDevToolDeployment(ctx context.Context, app *v1alpha1.Application, tool, image string, port int32,
+	env []corev1.EnvVar, volumes []corev1.Volume, mounts []corev1.VolumeMount) error {
+	name := app.ChildName(tool)
+	labels := map[string]string{"app": app.Name, "component": componentDevTools, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentDevTools, "tool": tool}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &[]int32{1}[0],
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(nil),
+					Tolerations:  infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:         tool,
+							Image:        app.InfraImage(image),
+							Env:          env,
+							Ports:        []corev1.ContainerPort{{ContainerPort: port}},
+							VolumeMounts: mounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, deployment); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create %s Deployment: %w", tool, err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       port,
+					TargetPort: intstr.FromInt32(port),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create %s Service: %w", tool, err)
+	}
+	return nil
+}
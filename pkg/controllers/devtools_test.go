@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func devToolsApp() *v1alpha1.Application {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.DevTools = true
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+	app.Status.RedisEnvironment = v1alpha1.EnvironmentLocal
+	return app
+}
+
+func TestDevToolsImagesArePinned(t *testing.T) {
+	app := devToolsApp()
+	r, _ := newTestController(t, app)
+	if err := r.provisionDevTools(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	for _, tool := range []string{"pgadmin", "redis-commander"} {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(testCtx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.ChildName(tool)}, deployment); err != nil {
+			t.Fatal(err)
+		}
+		image := deployment.Spec.Template.Spec.Containers[0].Image
+		if strings.HasSuffix(image, ":latest") || !strings.Contains(image, ":") {
+			t.Errorf("%s image = %q, want a pinned tag", tool, image)
+		}
+	}
+}
+
+func TestPgAdminPasswordIsGenerated(t *testing.T) {
+	app := devToolsApp()
+	r, _ := newTestController(t, app)
+	if err := r.provisionDevTools(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	key := client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.ChildName("pgadmin")}
+	secret := &corev1.Secret{}
+	if err := r.Get(testCtx, key, secret); err != nil {
+		t.Fatal(err)
+	}
+	password := secret.StringData[pgAdminPasswordKey]
+	if len(password) < 16 || password == "admin" {
+		t.Errorf("pgAdmin password = %q, want a generated one", password)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(testCtx, key, deployment); err != nil {
+		t.Fatal(err)
+	}
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if env.Name != "PGADMIN_DEFAULT_PASSWORD" {
+			continue
+		}
+		if env.Value != "" || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef.Name != key.Name {
+			t.Errorf("PGADMIN_DEFAULT_PASSWORD = %+v, want a reference to Secret %s", env, key.Name)
+		}
+	}
+
+	// A second reconcile keeps the first password
+	if err := r.provisionDevTools(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, secret); err != nil {
+		t.Fatal(err)
+	}
+	if got := secret.StringData[pgAdminPasswordKey]; got != password {
+		t.Errorf("pgAdmin password changed from %q to %q", password, got)
+	}
+}
@@ -0,0 +1,152 @@
+// pkg/controllers/drift.go
+// Reverts out-of-band edits to the application workload
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// templateDrift describes how a live pod template departs from the desired
+// one. Fields the desired template leaves unset are ignored, so API defaults
+// and annotations added by tools such as kubectl rollout restart are not drift.
+func templateDrift(desired, live *corev1.PodTemplateSpec) []string {
+	var changes []string
+	for _, want := range desired.Spec.Containers {
+		found := false
+		for _, have := range live.Spec.Containers {
+			if have.Name != want.Name {
+				continue
+			}
+			found = true
+			if have.Image != want.Image {
+				changes = append(changes, fmt.Sprintf("container %s image %s -> %s", want.Name, have.Image, want.Image))
+			}
+		}
+		if !found {
+			changes = append(changes, fmt.Sprintf("container %s missing", want.Name))
+		}
+	}
+	if len(changes) == 0 && !equality.Semantic.DeepDerivative(*desired, *live) {
+		changes = append(changes, "pod template changed")
+	}
+	return changes
+}
+
+// replicaDrift describes a replica count that differs from the desired one
+func replicaDrift(desired, live *int32) []string {
+	if desired == nil || live == nil || *desired == *live {
+		return nil
+	}
+	return []string{fmt.Sprintf("replicas %d -> %d", *live, *desired)}
+}
+
+// revisionHistoryDrift describes a revision history limit that differs from the desired one
+func revisionHistoryDrift(desired, live *int32) []string {
+	if desired == nil || live == nil || *desired == *live {
+		return nil
+	}
+	return []string{fmt.Sprintf("revisionHistoryLimit %d -> %d", *live, *desired)}
+}
+
+// correctWorkloadDrift compares the live application Deployment or StatefulSet
+// against the spec and re-applies the desired template and replica count when
+// they differ. CronJobs get the current job template, and a Job built from
+// changed configuration is re-run. It returns the corrected changes.
+// Blue/green and canary Deployments are left to their own rollout logic.
+func (r *ApplicationController) correctWorkloadDrift(ctx context.Context, app *v1alpha1.Application) ([]string, error) {
+	switch {
+	case app.GetKind() == v1alpha1.WorkloadCronJob:
+		desired, err := r.buildAppCronJob(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := r.updateCronJob(ctx, desired)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return changes, err
+
+	case app.GetKind() == v1alpha1.WorkloadJob:
+		desired, err := r.buildAppJob(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		replaced, err := r.replaceChangedJob(ctx, app, desired)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil || !replaced {
+			return nil, err
+		}
+		return []string{"job configuration changed"}, nil
+
+	case app.GetKind() == v1alpha1.WorkloadStatefulSet:
+		desired, err := r.buildAppStatefulSet(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		live := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		changes := append(templateDrift(&desired.Spec.Template, &live.Spec.Template),
+			replicaDrift(desired.Spec.Replicas, live.Spec.Replicas)...)
+		if len(changes) == 0 {
+			return nil, nil
+		}
+		live.Spec.Template = desired.Spec.Template
+		live.Spec.Replicas = desired.Spec.Replicas
+		if err := r.Update(ctx, live); err != nil {
+			return nil, fmt.Errorf("failed to correct statefulset drift: %w", err)
+		}
+		return changes, nil
+
+	case app.GetKind() == v1alpha1.WorkloadDeployment && !app.UsesBlueGreen() && !app.UsesCanary():
+		desired, err := r.buildAppDeployment(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		live := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		changes := append(templateDrift(&desired.Spec.Template, &live.Spec.Template),
+			replicaDrift(desired.Spec.Replicas, live.Spec.Replicas)...)
+		changes = append(changes, revisionHistoryDrift(desired.Spec.RevisionHistoryLimit, live.Spec.RevisionHistoryLimit)...)
+		if len(changes) == 0 {
+			return nil, nil
+		}
+		live.Spec.Template = desired.Spec.Template
+		live.Spec.Replicas = desired.Spec.Replicas
+		live.Spec.RevisionHistoryLimit = desired.Spec.RevisionHistoryLimit
+		if err := r.Update(ctx, live); err != nil {
+			return nil, fmt.Errorf("failed to correct deployment drift: %w", err)
+		}
+		return changes, nil
+	}
+	return nil, nil
+}
+
+// reportDrift logs and records an event for corrected workload drift
+func (r *ApplicationController) reportDrift(ctx context.Context, app *v1alpha1.Application, changes []string) {
+	summary := strings.Join(changes, "; ")
+	appLogger(ctx, app).Info("Workload drifted from spec - re-applied", "changes", summary)
+	r.recordEvent(app, corev1.EventTypeNormal, "DriftCorrected", summary)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestImageDriftReverted(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Finalizers = []string{cleanupFinalizer}
+	app.Status.Phase = v1alpha1.PhaseReady
+	r, recorder := newTestController(t, app)
+
+	desired, err := r.buildAppDeployment(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildAppDeployment: %v", err)
+	}
+	if err := r.Create(testCtx, desired); err != nil {
+		t.Fatal(err)
+	}
+	// Someone runs kubectl set image against the managed Deployment
+	live := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(desired), live); err != nil {
+		t.Fatal(err)
+	}
+	live.Spec.Template.Spec.Containers[0].Image = "nginx:latest"
+	if err := r.Update(testCtx, live); err != nil {
+		t.Fatal(err)
+	}
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+	if _, err := r.Reconcile(testCtx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(desired), live); err != nil {
+		t.Fatal(err)
+	}
+	if got := live.Spec.Template.Spec.Containers[0].Image; got != "nginx:1.25" {
+		t.Errorf("image = %q after reconcile, want the spec image nginx:1.25", got)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "DriftCorrected") {
+		t.Errorf("events = %v, want DriftCorrected", events)
+	}
+
+	// A second pass finds nothing left to correct
+	if changes, err := r.correctWorkloadDrift(testCtx, app); err != nil || len(changes) != 0 {
+		t.Errorf("correctWorkloadDrift = %v, %v after correction, want no changes", changes, err)
+	}
+}
+
+func TestRevisionHistoryLimit(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+
+	deployment, err := r.buildAppDeployment(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildAppDeployment: %v", err)
+	}
+	if got := deployment.Spec.RevisionHistoryLimit; got == nil || *got != v1alpha1.DefaultRevisionHistoryLimit {
+		t.Errorf("revisionHistoryLimit = %v, want the default %d", got, v1alpha1.DefaultRevisionHistoryLimit)
+	}
+	if err := r.Create(testCtx, deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	// A changed limit reaches the running Deployment
+	limit := int32(10)
+	app.Spec.RevisionHistoryLimit = &limit
+	changes, err := r.correctWorkloadDrift(testCtx, app)
+	if err != nil || len(changes) != 1 || changes[0] != "revisionHistoryLimit 3 -> 10" {
+		t.Errorf("correctWorkloadDrift = %v, %v; want the limit updated 3 -> 10", changes, err)
+	}
+	live := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(deployment), live); err != nil {
+		t.Fatal(err)
+	}
+	if got := live.Spec.RevisionHistoryLimit; got == nil || *got != 10 {
+		t.Errorf("live revisionHistoryLimit = %v, want 10", got)
+	}
+
+	negative := int32(-1)
+	app.Spec.RevisionHistoryLimit = &negative
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("negative revisionHistoryLimit accepted")
+	}
+}
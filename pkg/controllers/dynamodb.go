@@ -0,0 +1,174 @@
+// pkg/controllers/dynamodb.go
+// DynamoDB tables, backed by DynamoDB Local outside AWS
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// dynamoDBTable converts the spec into the table definition
+func dynamoDBTable(app *v1alpha1.Application) DynamoDBTable {
+	spec := app.Spec.Infrastructure.DynamoDB
+	table := DynamoDBTable{
+		Name:        app.GetDynamoDBTableName(),
+		HashKey:     spec.HashKey,
+		RangeKey:    spec.RangeKey,
+		BillingMode: app.GetDynamoDBBillingMode(),
+	}
+	if table.BillingMode == v1alpha1.DynamoDBBillingProvisioned {
+		table.ReadCapacityUnits = spec.ReadCapacityUnits
+		if table.ReadCapacityUnits == 0 {
+			table.ReadCapacityUnits = v1alpha1.DefaultDynamoDBCapacityUnits
+		}
+		table.WriteCapacityUnits = spec.WriteCapacityUnits
+		if table.WriteCapacityUnits == 0 {
+			table.WriteCapacityUnits = v1alpha1.DefaultDynamoDBCapacityUnits
+		}
+	}
+	return table
+}
+
+// createTableCommand renders the aws CLI invocation that creates the table.
+// The names come from the spec, so the command reads them from the
+// environment tableScriptEnv sets rather than carrying them in its text.
+func createTableCommand(table DynamoDBTable, endpoint string) string {
+	attrs := []string{`"AttributeName=$HASH_KEY,AttributeType=S"`}
+	keys := []string{`"AttributeName=$HASH_KEY,KeyType=HASH"`}
+	if table.RangeKey != "" {
+		attrs = append(attrs, `"AttributeName=$RANGE_KEY,AttributeType=S"`)
+		keys = append(keys, `"AttributeName=$RANGE_KEY,KeyType=RANGE"`)
+	}
+
+	cmd := fmt.Sprintf(`aws dynamodb create-table --endpoint-url %s --table-name "$TABLE" --attribute-definitions %s --key-schema %s --billing-mode %s`,
+		endpoint, strings.Join(attrs, " "), strings.Join(keys, " "), table.BillingMode)
+	if table.BillingMode == v1alpha1.DynamoDBBillingProvisioned {
+		cmd += fmt.Sprintf(" --provisioned-throughput ReadCapacityUnits=%d,WriteCapacityUnits=%d",
+			table.ReadCapacityUnits, table.WriteCapacityUnits)
+	}
+	return cmd
+}
+
+// tableScriptEnv passes the table and key names to createTableCommand
+func tableScriptEnv(table DynamoDBTable) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{Name: "TABLE", Value: table.Name},
+		{Name: "HASH_KEY", Value: table.HashKey},
+	}
+	if table.RangeKey != "" {
+		env = append(env, corev1.EnvVar{Name: "RANGE_KEY", Value: table.RangeKey})
+	}
+	return env
+}
+
+// provisionLocalDynamoDB runs DynamoDB Local and creates the table in it
+func (r *ApplicationController) provisionLocalDynamoDB(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentNoSQL)
+	logger.Info("Creating local DynamoDB")
+
+	labels := map[string]string{"app": app.Name, "component": componentNoSQL, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentNoSQL}
+
+	dynamo := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetDynamoDBName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &[]int32{1}[0],
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(app.Spec.Infrastructure.DynamoDB.NodeSelector),
+					Tolerations:  infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:  "dynamodb",
+							Image: app.InfraImage(v1alpha1.DynamoDBLocalImage),
+							Args:  []string{"-jar", "DynamoDBLocal.jar", "-sharedDb", "-inMemory"},
+							Ports: []corev1.ContainerPort{{ContainerPort: 8000}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, dynamo); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create DynamoDB Local Deployment: %w", err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetDynamoDBName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       8000,
+					TargetPort: intstr.FromInt(8000),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create DynamoDB Local Service: %w", err)
+	}
+
+	// Create the table once DynamoDB Local accepts connections
+	table := dynamoDBTable(app)
+	endpoint := fmt.Sprintf("http://%s:8000", app.GetDynamoDBName())
+	script := fmt.Sprintf("until aws dynamodb list-tables --endpoint-url %s >/dev/null; do sleep 2; done && "+
+		`(aws dynamodb describe-table --endpoint-url %s --table-name "$TABLE" >/dev/null 2>&1 || %s)`,
+		endpoint, endpoint, createTableCommand(table, endpoint))
+
+	tableJob := localAWSCLIJob(app, app.GetDynamoDBTableJobName(), componentNoSQL, script, tableScriptEnv(table),
+		r.infraNodeSelector(app.Spec.Infrastructure.DynamoDB.NodeSelector))
+
+	if err := r.createOwned(ctx, app, tableJob); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create DynamoDB table Job: %w", err)
+	}
+
+	app.Status.DynamoDBTableName = table.Name
+	app.Status.DynamoDBEndpoint = fmt.Sprintf("%s:8000", app.GetDynamoDBName())
+	app.Status.DynamoDBEnvironment = v1alpha1.EnvironmentLocal
+
+	logger.Info("Local DynamoDB created", "endpoint", app.Status.DynamoDBEndpoint, "table", table.Name)
+	return nil
+}
+
+// provisionAWSDynamoDB creates the table through the AWS client
+func (r *ApplicationController) provisionAWSDynamoDB(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentNoSQL)
+	logger.Info("Provisioning AWS DynamoDB table")
+
+	table := dynamoDBTable(app)
+	endpoint, err := r.awsClient().CreateDynamoDBTable(ctx, table)
+	if err != nil {
+		return fmt.Errorf("failed to create DynamoDB table %s: %w", table.Name, err)
+	}
+
+	app.Status.DynamoDBTableName = table.Name
+	app.Status.DynamoDBEndpoint = endpoint
+	app.Status.DynamoDBEnvironment = v1alpha1.EnvironmentAWS
+
+	logger.Info("AWS DynamoDB table ready", "table", table.Name, "billingMode", table.BillingMode)
+	return nil
+}
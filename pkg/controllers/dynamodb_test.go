@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestLocalDynamoDB(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.DynamoDB = &v1alpha1.DynamoDBSpec{Environment: v1alpha1.EnvironmentLocal, HashKey: "id", RangeKey: "created"}
+	r, _ := newTestController(t, app)
+	aws := &fakeAWSClient{}
+	r.AWS = aws
+
+	if err := r.provisionNoSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionNoSQL: %v", err)
+	}
+	if len(aws.tables) != 0 {
+		t.Errorf("AWS tables created for a local table: %+v", aws.tables)
+	}
+	key := client.ObjectKey{Name: app.GetDynamoDBName(), Namespace: "default"}
+	if err := r.Get(testCtx, key, &appsv1.Deployment{}); err != nil {
+		t.Errorf("DynamoDB Local Deployment: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.Service{}); err != nil {
+		t.Errorf("DynamoDB Local Service: %v", err)
+	}
+	job := &batchv1.Job{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetDynamoDBTableJobName(), Namespace: "default"}, job); err != nil {
+		t.Fatalf("table Job: %v", err)
+	}
+	env := job.Spec.Template.Spec.Containers[0].Env
+	for name, value := range map[string]string{"TABLE": "shop", "HASH_KEY": "id", "RANGE_KEY": "created"} {
+		if !hasEnv(env, name, value) {
+			t.Errorf("table Job env = %v, want %s=%s", env, name, value)
+		}
+	}
+
+	appEnv := r.buildEnvironmentVariables(app)
+	if !hasEnv(appEnv, "DYNAMODB_TABLE", "shop") || !hasEnv(appEnv, "DYNAMODB_ENDPOINT", "http://"+app.GetDynamoDBName()+":8000") {
+		t.Errorf("app env = %v, want the local table and endpoint", appEnv)
+	}
+}
+
+func TestAWSDynamoDB(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.DynamoDB = &v1alpha1.DynamoDBSpec{
+		Environment: v1alpha1.EnvironmentAWS,
+		TableName:   "orders",
+		HashKey:     "id",
+		BillingMode: v1alpha1.DynamoDBBillingProvisioned,
+	}
+	r, _ := newTestController(t, app)
+	aws := &fakeAWSClient{simulatedAWSClient: simulatedAWSClient{region: "eu-west-1"}}
+	r.AWS = aws
+
+	if err := r.provisionNoSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionNoSQL: %v", err)
+	}
+	if len(aws.tables) != 1 {
+		t.Fatalf("tables created = %+v, want one", aws.tables)
+	}
+	table := aws.tables[0]
+	if table.Name != "orders" || table.ReadCapacityUnits != v1alpha1.DefaultDynamoDBCapacityUnits {
+		t.Errorf("table = %+v, want orders with the default capacity", table)
+	}
+	if app.Status.DynamoDBEnvironment != v1alpha1.EnvironmentAWS {
+		t.Errorf("environment = %s, want aws", app.Status.DynamoDBEnvironment)
+	}
+	appEnv := r.buildEnvironmentVariables(app)
+	if !hasEnv(appEnv, "DYNAMODB_TABLE", "orders") || !hasEnv(appEnv, "DYNAMODB_ENDPOINT", "https://dynamodb.eu-west-1.amazonaws.com") {
+		t.Errorf("app env = %v, want the AWS table and endpoint", appEnv)
+	}
+}
@@ -0,0 +1,38 @@
+// pkg/controllers/env_interpolation.go
+// Substitutes ${token} references to infrastructure status in user env values
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// envTokens maps the supported tokens to the status field they read
+var envTokens = map[string]func(*v1alpha1.ApplicationStatus) string{
+	"databaseEndpoint": func(s *v1alpha1.ApplicationStatus) string { return s.DatabaseEndpoint },
+	"redisEndpoint":    func(s *v1alpha1.ApplicationStatus) string { return s.RedisEndpoint },
+	"s3Bucket":         func(s *v1alpha1.ApplicationStatus) string { return s.S3BucketName },
+}
+
+// interpolateEnv replaces the supported tokens in value with the matching
+// status field. Admission rejects unknown tokens and tokens of components the
+// Application does not request, so a token is only left as written while its
+// status field is still empty.
+func interpolateEnv(value string, status *v1alpha1.ApplicationStatus) string {
+	return v1alpha1.EnvReferencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		token := v1alpha1.EnvReferencePattern.FindStringSubmatch(match)[1]
+		if lookup, ok := envTokens[token]; ok {
+			if resolved := lookup(status); resolved != "" {
+				return resolved
+			}
+		}
+		return match
+	})
+}
+
+// userEnvVar builds the env var for a spec.env entry, interpolating status tokens
+func userEnvVar(app *v1alpha1.Application, name, value string) corev1.EnvVar {
+	return corev1.EnvVar{Name: name, Value: interpolateEnv(value, &app.Status)}
+}
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestEnvReferencesResolveWithoutEvents(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{}
+	app.Spec.Env = map[string]string{"DB_HOST": "${databaseEndpoint}:5432"}
+	r, recorder := newTestController(t, app)
+
+	// Before provisioning the reference is left as written, quietly
+	env := r.buildEnvironmentVariables(app)
+	if !hasEnv(env, "DB_HOST", "${databaseEndpoint}:5432") {
+		t.Errorf("env = %v, want DB_HOST left as written", env)
+	}
+	app.Status.DatabaseEndpoint = "web-postgres"
+	env = r.buildEnvironmentVariables(app)
+	if !hasEnv(env, "DB_HOST", "web-postgres:5432") {
+		t.Errorf("env = %v, want DB_HOST resolved", env)
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("events = %v, want none from building the environment", events)
+	}
+}
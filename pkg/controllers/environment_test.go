@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestDetectEnvironment(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured v1alpha1.Environment
+		env        map[string]string
+		want       v1alpha1.Environment
+	}{
+		{"local override", v1alpha1.EnvironmentLocal, map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}, v1alpha1.EnvironmentLocal},
+		{"aws override", v1alpha1.EnvironmentAWS, nil, v1alpha1.EnvironmentAWS},
+		{"auto with AWS credentials", v1alpha1.EnvironmentAuto, map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}, v1alpha1.EnvironmentAWS},
+		{"auto in a cloud cluster", v1alpha1.EnvironmentAuto, map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1", "AWS_REGION": "eu-west-1"}, v1alpha1.EnvironmentAWS},
+		{"auto in a local cluster", v1alpha1.EnvironmentAuto, map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"}, v1alpha1.EnvironmentLocal},
+		{"auto without hints", v1alpha1.EnvironmentAuto, nil, v1alpha1.EnvironmentLocal},
+		{"unset", "", nil, v1alpha1.EnvironmentLocal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "KUBERNETES_SERVICE_HOST", "AWS_REGION", "GCP_PROJECT"} {
+				t.Setenv(name, tt.env[name])
+			}
+			r, _ := newTestController(t)
+			r.Config.Environment = tt.configured
+			if got := r.detectEnvironment(); got != tt.want {
+				t.Errorf("detectEnvironment() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileResolvesAutoFromConfig(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentAuto}
+	app.Spec.Infrastructure.S3 = &v1alpha1.S3Spec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	r.Config.Environment = v1alpha1.EnvironmentAWS
+
+	stored := reconcileUntil(t, r, app, v1alpha1.PhaseProvisioningInfra, v1alpha1.PhaseDeploying)
+	if stored.Status.RedisEnvironment != v1alpha1.EnvironmentAWS {
+		t.Errorf("Redis environment = %s, want aws from --environment", stored.Status.RedisEnvironment)
+	}
+	if stored.Status.S3Environment != v1alpha1.EnvironmentLocal {
+		t.Errorf("S3 environment = %s, want the local override", stored.Status.S3Environment)
+	}
+}
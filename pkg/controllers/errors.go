@@ -0,0 +1,61 @@
+// pkg/controllers/errors.go
+// Classifies API errors into transient (retry) and permanent (fail) ones
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// permanentError marks a failure retrying cannot fix, such as a spec the
+// provisioner cannot act on; the Application fails until its spec changes
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// permanent marks err as not worth retrying
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// isPermanentError reports whether err, or any error it wraps, cannot succeed
+// on a later attempt: marked permanent, a storage shrink or disabled
+// infrastructure
+func isPermanentError(err error) bool {
+	var p *permanentError
+	if errors.As(err, &p) || isStorageShrink(err) {
+		return true
+	}
+	_, disabled := asProviderDisabled(err)
+	return disabled
+}
+
+// isRetryableError reports whether err is likely to succeed on a later attempt,
+// e.g. timeouts, conflicts, throttling or an unavailable API server or webhook
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsConflict(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,151 @@
+// pkg/controllers/exporters.go
+// Prometheus exporter sidecars for the local database and cache
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Names of the exporter sidecar containers
+const (
+	postgresExporterName = "postgres-exporter"
+	redisExporterName    = "redis-exporter"
+)
+
+// postgresExporterContainer scrapes the database container over localhost
+func postgresExporterContainer(app *v1alpha1.Application) corev1.Container {
+	dsn := fmt.Sprintf("postgresql://%s:%s@localhost:5432/%s?sslmode=disable",
+		v1alpha1.LocalDatabaseUser, v1alpha1.LocalDatabasePassword, app.GetDatabaseName())
+	return exporterContainer(postgresExporterName, app.InfraImage(v1alpha1.PostgresExporterImage), v1alpha1.PostgresExporterPort,
+		corev1.EnvVar{Name: "DATA_SOURCE_NAME", Value: dsn})
+}
+
+// redisExporterContainer scrapes the Redis container over localhost
+func redisExporterContainer(app *v1alpha1.Application) corev1.Container {
+	return exporterContainer(redisExporterName, app.InfraImage(v1alpha1.RedisExporterImage), v1alpha1.RedisExporterPort,
+		corev1.EnvVar{Name: "REDIS_ADDR", Value: "redis://localhost:6379"})
+}
+
+func exporterContainer(name, image string, port int32, env ...corev1.EnvVar) corev1.Container {
+	return corev1.Container{
+		Name:  name,
+		Image: image,
+		Env:   env,
+		Ports: []corev1.ContainerPort{{
+			Name:          metricsPortName,
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+	}
+}
+
+// exposeExporter adds an exporter's metrics port, with scrape annotations, to
+// the component's Service. Ports must be named once there is more than one, so
+// the existing port is named mainPort.
+func exposeExporter(service *corev1.Service, mainPort string, port int32) {
+	service.Spec.Ports[0].Name = mainPort
+	service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+		Name:       metricsPortName,
+		Port:       port,
+		TargetPort: intstr.FromString(metricsPortName),
+		Protocol:   corev1.ProtocolTCP,
+	})
+	service.Annotations = mergeAnnotations(service.Annotations, map[string]string{
+		prometheusScrapeAnnotation: "true",
+		prometheusPathAnnotation:   "/metrics",
+		prometheusPortAnnotation:   fmt.Sprintf("%d", port),
+	})
+}
+
+// updateInfraServicePorts brings an existing infrastructure Service's ports
+// and scrape annotations in line with desired, so the metrics port follows the
+// exporter setting
+func (r *ApplicationController) updateInfraServicePorts(ctx context.Context, desired *corev1.Service) error {
+	existing := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get service %s: %w", desired.Name, err)
+	}
+	changed := !equality.Semantic.DeepEqual(existing.Spec.Ports, desired.Spec.Ports)
+	existing.Spec.Ports = desired.Spec.Ports
+	if !mergeScrapeAnnotations(existing, desired) && !changed {
+		return nil
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update service %s: %w", desired.Name, err)
+	}
+	return nil
+}
+
+// hasContainer reports whether the pod spec runs a container with the name
+func hasContainer(spec *corev1.PodSpec, name string) bool {
+	for _, c := range spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// syncExporterSidecar adds or removes the named exporter container on live so
+// it matches desired, and reports whether live changed. The other containers
+// are left to their own update paths.
+func syncExporterSidecar(live, desired *corev1.PodSpec, name string) bool {
+	if hasContainer(live, name) == hasContainer(desired, name) {
+		return false
+	}
+	containers := make([]corev1.Container, 0, len(live.Containers)+1)
+	for _, c := range live.Containers {
+		if c.Name != name {
+			containers = append(containers, c)
+		}
+	}
+	for _, c := range desired.Containers {
+		if c.Name == name {
+			containers = append(containers, c)
+		}
+	}
+	live.Containers = containers
+	return true
+}
+
+// updatePostgresExporter adds or removes the exporter sidecar on the live
+// database StatefulSet; this restarts the database
+func (r *ApplicationController) updatePostgresExporter(ctx context.Context, desired *appsv1.StatefulSet) error {
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get PostgreSQL StatefulSet: %w", err)
+	}
+	if !syncExporterSidecar(&existing.Spec.Template.Spec, &desired.Spec.Template.Spec, postgresExporterName) {
+		return nil
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update PostgreSQL StatefulSet exporter: %w", err)
+	}
+	return nil
+}
+
+// updateRedisExporter adds or removes the exporter sidecar on the live Redis
+// Deployment; this restarts Redis
+func (r *ApplicationController) updateRedisExporter(ctx context.Context, desired *appsv1.Deployment) error {
+	existing := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get Redis Deployment: %w", err)
+	}
+	if !syncExporterSidecar(&existing.Spec.Template.Spec, &desired.Spec.Template.Spec, redisExporterName) {
+		return nil
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update Redis Deployment exporter: %w", err)
+	}
+	return nil
+}
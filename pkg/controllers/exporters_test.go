@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestRedisExporterFollowsSpec(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, Version: "7"}
+	r, _ := newTestController(t, app)
+	key := client.ObjectKey{Name: app.GetRedisName(), Namespace: "default"}
+
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	drift, err := r.detectInfraDrift(testCtx, app)
+	if err != nil || len(drift) != 0 {
+		t.Fatalf("detectInfraDrift = %v, %v; want no drift", drift, err)
+	}
+
+	// Enabling the exporter on the running Redis is drift, and applying it
+	// adds the sidecar and the metrics port
+	app.Spec.Infrastructure.Redis.Exporter = true
+	if drift, _ := r.detectInfraDrift(testCtx, app); len(drift) != 1 || !drift[0].disruptive {
+		t.Fatalf("detectInfraDrift = %v, want one disruptive exporter change", drift)
+	}
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	redis := &appsv1.Deployment{}
+	if err := r.Get(testCtx, key, redis); err != nil {
+		t.Fatal(err)
+	}
+	if !hasContainer(&redis.Spec.Template.Spec, redisExporterName) {
+		t.Error("exporter sidecar missing")
+	}
+	service := &corev1.Service{}
+	if err := r.Get(testCtx, key, service); err != nil {
+		t.Fatal(err)
+	}
+	if !hasServicePort(service, v1alpha1.RedisExporterPort) || service.Annotations[prometheusScrapeAnnotation] != "true" {
+		t.Errorf("Service ports %v annotations %v, want the metrics port scraped", service.Spec.Ports, service.Annotations)
+	}
+
+	// Disabling it takes both away again
+	app.Spec.Infrastructure.Redis.Exporter = false
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	if err := r.Get(testCtx, key, redis); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, service); err != nil {
+		t.Fatal(err)
+	}
+	if hasContainer(&redis.Spec.Template.Spec, redisExporterName) || hasServicePort(service, v1alpha1.RedisExporterPort) {
+		t.Error("exporter still present after disabling it")
+	}
+}
+
+func TestPostgresExporterSidecar(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, Version: "15", Exporter: true}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	key := client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}
+	postgres := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, key, postgres); err != nil {
+		t.Fatal(err)
+	}
+	if !hasContainer(&postgres.Spec.Template.Spec, postgresExporterName) {
+		t.Error("exporter sidecar missing")
+	}
+	service := &corev1.Service{}
+	if err := r.Get(testCtx, key, service); err != nil {
+		t.Fatal(err)
+	}
+	if !hasServicePort(service, v1alpha1.PostgresExporterPort) {
+		t.Errorf("Service ports %v, want the metrics port", service.Spec.Ports)
+	}
+}
+
+// hasServicePort reports whether the Service exposes port
+func hasServicePort(service *corev1.Service, port int32) bool {
+	for _, p := range service.Spec.Ports {
+		if p.Port == port {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// pkg/controllers/health.go
+// Readiness based on recent reconcile outcomes and cache sync
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// cacheSyncTimeout bounds how long a readiness probe waits on the informers
+const cacheSyncTimeout = 2 * time.Second
+
+// ReconcileHealth tracks whether reconciles have been succeeding. It is safe
+// for concurrent reconciles and probes.
+type ReconcileHealth struct {
+	mu           sync.Mutex
+	lastSuccess  time.Time
+	failingSince time.Time
+}
+
+// NewReconcileHealth returns a tracker with no reconciles recorded yet
+func NewReconcileHealth() *ReconcileHealth {
+	return &ReconcileHealth{}
+}
+
+// Record notes the outcome of one reconcile
+func (h *ReconcileHealth) Record(err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if err == nil {
+		h.lastSuccess = now
+		h.failingSince = time.Time{}
+		return
+	}
+	if h.failingSince.IsZero() {
+		h.failingSince = now
+	}
+}
+
+// LastSuccess returns when a reconcile last succeeded; zero if none has
+func (h *ReconcileHealth) LastSuccess() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastSuccess
+}
+
+// failingFor returns how long reconciles have failed without a success in between
+func (h *ReconcileHealth) failingFor() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.failingSince.IsZero() {
+		return 0
+	}
+	return time.Since(h.failingSince)
+}
+
+// Checker reports unready once reconciles have failed for longer than
+// threshold with no success in between; zero disables the reconcile check. An
+// idle controller with nothing to reconcile stays ready.
+func (h *ReconcileHealth) Checker(threshold time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		if threshold <= 0 {
+			return nil
+		}
+		if failing := h.failingFor(); failing > threshold {
+			last := "never"
+			if success := h.LastSuccess(); !success.IsZero() {
+				last = success.UTC().Format(time.RFC3339)
+			}
+			return fmt.Errorf("reconciles failing for %s (last success: %s)", failing.Round(time.Second), last)
+		}
+		return nil
+	}
+}
+
+// CacheSyncChecker reports unready until the manager's informer caches have synced
+func CacheSyncChecker(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches not synced")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+func TestReconcileHealthChecker(t *testing.T) {
+	h := NewReconcileHealth()
+	check := h.Checker(time.Minute)
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	if err := check(req); err != nil {
+		t.Errorf("idle controller unready: %v", err)
+	}
+
+	// Failures shorter than the threshold keep the controller ready
+	h.Record(errors.New("conflict"))
+	if err := check(req); err != nil {
+		t.Errorf("unready right after the first failure: %v", err)
+	}
+
+	// Failing for longer than the threshold flips readiness
+	h.mu.Lock()
+	h.failingSince = time.Now().Add(-2 * time.Minute)
+	h.mu.Unlock()
+	err := check(req)
+	if err == nil || !strings.Contains(err.Error(), "last success: never") {
+		t.Fatalf("check = %v, want unready with no success recorded", err)
+	}
+
+	// One success restores readiness and is reported on the next failure streak
+	h.Record(nil)
+	if err := check(req); err != nil {
+		t.Errorf("unready after a successful reconcile: %v", err)
+	}
+	h.Record(errors.New("conflict"))
+	h.mu.Lock()
+	h.failingSince = time.Now().Add(-2 * time.Minute)
+	h.mu.Unlock()
+	if err := check(req); err == nil || strings.Contains(err.Error(), "never") {
+		t.Errorf("check = %v, want unready naming the last success", err)
+	}
+
+	if err := h.Checker(0)(req); err != nil {
+		t.Errorf("disabled check failed: %v", err)
+	}
+}
+
+// syncCache reports a fixed cache sync result
+type syncCache struct {
+	cache.Cache
+	synced bool
+}
+
+func (c syncCache) WaitForCacheSync(ctx context.Context) bool {
+	return c.synced
+}
+
+func TestCacheSyncChecker(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	if err := CacheSyncChecker(syncCache{synced: false})(req); err == nil {
+		t.Error("ready before the caches synced")
+	}
+	if err := CacheSyncChecker(syncCache{synced: true})(req); err != nil {
+		t.Errorf("unready with synced caches: %v", err)
+	}
+}
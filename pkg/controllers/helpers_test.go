@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// testScheme registers the core types and Applications
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// newTestController returns a controller backed by a fake client holding objs,
+// with a recorder that keeps the emitted events
+func newTestController(t *testing.T, objs ...client.Object) (*ApplicationController, *record.FakeRecorder) {
+	t.Helper()
+	scheme := testScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.Application{}).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	return &ApplicationController{
+		Client:   c,
+		Scheme:   scheme,
+		Config:   DefaultReconcileConfig(),
+		Recorder: recorder,
+	}, recorder
+}
+
+// newTestApplication returns a minimal Application in the default namespace
+func newTestApplication(name string) *v1alpha1.Application {
+	return &v1alpha1.Application{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Application"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name), Generation: 1},
+		Spec:       v1alpha1.ApplicationSpec{Image: "nginx:1.25"},
+	}
+}
+
+// drainEvents returns the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+var testCtx = context.Background()
+
+// fakeAWSClient records the calls the controller makes to AWS
+type fakeAWSClient struct {
+	simulatedAWSClient
+	deletedBuckets []string
+	tables         []DynamoDBTable
+	queues         []SQSQueue
+}
+
+func (c *fakeAWSClient) CreateDynamoDBTable(ctx context.Context, table DynamoDBTable) (string, error) {
+	c.tables = append(c.tables, table)
+	return c.simulatedAWSClient.CreateDynamoDBTable(ctx, table)
+}
+
+func (c *fakeAWSClient) CreateSQSQueue(ctx context.Context, queue SQSQueue) (string, error) {
+	c.queues = append(c.queues, queue)
+	return c.simulatedAWSClient.CreateSQSQueue(ctx, queue)
+}
+
+func (c *fakeAWSClient) DeleteBucket(ctx context.Context, bucket string) error {
+	c.deletedBuckets = append(c.deletedBuckets, bucket)
+	return nil
+}
+
+// reconcileUntil reconciles app until its stored phase is one of phases, or
+// fails the test after a bounded number of passes; it returns the stored
+// Application
+func reconcileUntil(t *testing.T, r *ApplicationController, app *v1alpha1.Application, phases ...v1alpha1.ApplicationPhase) *v1alpha1.Application {
+	t.Helper()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+	stored := &v1alpha1.Application{}
+	for pass := 0; pass < 10; pass++ {
+		if _, err := r.Reconcile(testCtx, req); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+			t.Fatalf("get Application: %v", err)
+		}
+		for _, phase := range phases {
+			if stored.Status.Phase == phase {
+				return stored
+			}
+		}
+	}
+	t.Fatalf("phase = %s (%s), want one of %v", stored.Status.Phase, stored.Status.Message, phases)
+	return nil
+}
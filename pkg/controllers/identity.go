@@ -0,0 +1,137 @@
+// pkg/controllers/identity.go
+// Carrying volumes over to a renamed Application through its identity annotation
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// identityLabel carries the Application's identity annotation onto the
+// volumes it creates, which outlive the Application
+const identityLabel = "platform.orion.dev/identity"
+
+// setIdentityLabel marks obj with the Application's identity, if it has one
+func setIdentityLabel(app *v1alpha1.Application, obj client.Object) {
+	identity := app.GetIdentity()
+	if identity == "" {
+		return
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[identityLabel] = identity
+	obj.SetLabels(labels)
+}
+
+// ensureIdentityLabel labels an existing claim with the Application's identity
+func (r *ApplicationController) ensureIdentityLabel(ctx context.Context, app *v1alpha1.Application, pvc *corev1.PersistentVolumeClaim) error {
+	identity := app.GetIdentity()
+	if identity == "" {
+		return nil
+	}
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pvc), existing); err != nil {
+		return fmt.Errorf("failed to get PVC %s: %w", pvc.Name, err)
+	}
+	if existing.Labels[identityLabel] == identity {
+		return nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	setIdentityLabel(app, existing)
+	if err := r.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("failed to label PVC %s with identity: %w", pvc.Name, err)
+	}
+	return nil
+}
+
+// databaseClaimName returns the PVC the local PostgreSQL mounts: one already
+// adopted by this Application through its identity, or <app>-postgres-pvc
+func (r *ApplicationController) databaseClaimName(ctx context.Context, app *v1alpha1.Application) (string, error) {
+	name := app.GetPostgresClaimName()
+	if app.GetIdentity() == "" {
+		return name, nil
+	}
+	claims, err := r.identityClaims(ctx, app, componentDatabase)
+	if err != nil {
+		return "", err
+	}
+	for _, pvc := range claims {
+		if pvc.Labels["app"] == app.Name {
+			return pvc.Name, nil
+		}
+	}
+	return name, nil
+}
+
+// identityClaims lists the managed PVCs of one component carrying the
+// Application's identity in its target namespace
+func (r *ApplicationController) identityClaims(ctx context.Context, app *v1alpha1.Application, component string) ([]corev1.PersistentVolumeClaim, error) {
+	list := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, list, client.InNamespace(app.GetTargetNamespace()), client.MatchingLabels{
+		identityLabel: app.GetIdentity(),
+		"component":   component,
+		"managed-by":  "orion-platform",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list PVCs for identity %s: %w", app.GetIdentity(), err)
+	}
+	return list.Items, nil
+}
+
+// adoptDatabaseClaim hands the database volume left behind by a previous
+// Application with the same identity over to app, so a rename keeps its data.
+// It returns the adopted claim's name, or "" when there is nothing to adopt.
+// A claim whose Application still exists is never taken.
+func (r *ApplicationController) adoptDatabaseClaim(ctx context.Context, app *v1alpha1.Application) (string, error) {
+	if app.GetIdentity() == "" {
+		return "", nil
+	}
+	claims, err := r.identityClaims(ctx, app, componentDatabase)
+	if err != nil {
+		return "", err
+	}
+	for i := range claims {
+		pvc := &claims[i]
+		previous := pvc.Labels["app"]
+		if previous == app.Name {
+			return pvc.Name, nil
+		}
+
+		// The previous owner lives in the Application's namespace, or the one
+		// its owner labels name for cross-namespace deployments
+		namespace := app.Namespace
+		if owner, ok := labelOwner(pvc); ok {
+			namespace = owner.Namespace
+		}
+		other := &v1alpha1.Application{}
+		err := r.Get(ctx, client.ObjectKey{Name: previous, Namespace: namespace}, other)
+		if err == nil && other.DeletionTimestamp.IsZero() {
+			return "", fmt.Errorf("identity %s is still in use by Application %s/%s", app.GetIdentity(), namespace, previous)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get Application %s/%s: %w", namespace, previous, err)
+		}
+
+		pvc.Labels["app"] = app.Name
+		if _, ok := labelOwner(pvc); ok {
+			setOwnerLabels(app, pvc)
+		}
+		if err := r.Update(ctx, pvc); err != nil {
+			return "", fmt.Errorf("failed to adopt PVC %s: %w", pvc.Name, err)
+		}
+		app.Status.AddManagedResource(r.managedResourceRef(pvc))
+		componentLogger(ctx, app, componentDatabase).Info("Adopted volume by identity", "pvc", pvc.Name, "previousApplication", previous)
+		r.recordEvent(app, corev1.EventTypeNormal, "AdoptedByIdentity",
+			fmt.Sprintf("Adopted PVC %s from Application %s (identity %s)", pvc.Name, previous, app.GetIdentity()))
+		return pvc.Name, nil
+	}
+	return "", nil
+}
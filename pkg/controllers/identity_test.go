@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// newIdentityTestApplication returns an Application with a local PostgreSQL
+// and the given identity
+func newIdentityTestApplication(name, identity string) *v1alpha1.Application {
+	app := newTestApplication(name)
+	app.Annotations = map[string]string{v1alpha1.IdentityAnnotation: identity}
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	return app
+}
+
+func TestAdoptDatabaseClaimByIdentity(t *testing.T) {
+	old := newIdentityTestApplication("orders", "orders-db")
+	renamed := newIdentityTestApplication("orders-v2", "orders-db")
+	r, recorder := newTestController(t, renamed)
+
+	// The old Application provisioned its database and was then deleted
+	if err := r.provisionLocalPostgreSQL(testCtx, old); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL(old): %v", err)
+	}
+	claimKey := client.ObjectKey{Name: old.GetPostgresClaimName(), Namespace: "default"}
+
+	if err := r.provisionLocalPostgreSQL(testCtx, renamed); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL(renamed): %v", err)
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(testCtx, claimKey, pvc); err != nil {
+		t.Fatalf("original claim gone: %v", err)
+	}
+	if pvc.Labels["app"] != renamed.Name || pvc.Labels[identityLabel] != "orders-db" {
+		t.Errorf("claim labels = %v, want it handed to %s", pvc.Labels, renamed.Name)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Name: renamed.GetPostgresClaimName(), Namespace: "default"}, &corev1.PersistentVolumeClaim{}); err == nil {
+		t.Error("a new claim was created instead of adopting the old one")
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: renamed.GetPostgresName(), Namespace: "default"}, sts); err != nil {
+		t.Fatal(err)
+	}
+	mounted := ""
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			mounted = v.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if mounted != claimKey.Name {
+		t.Errorf("PostgreSQL mounts claim %q, want the adopted %q", mounted, claimKey.Name)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "AdoptedByIdentity") {
+		t.Errorf("events = %v, want AdoptedByIdentity", events)
+	}
+}
+
+func TestAdoptDatabaseClaimInUse(t *testing.T) {
+	old := newIdentityTestApplication("orders", "orders-db")
+	renamed := newIdentityTestApplication("orders-v2", "orders-db")
+	r, _ := newTestController(t, old, renamed)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, old); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL(old): %v", err)
+	}
+	err := r.provisionLocalPostgreSQL(testCtx, renamed)
+	if err == nil || !strings.Contains(err.Error(), "still in use") {
+		t.Fatalf("provisionLocalPostgreSQL = %v, want the identity reported in use", err)
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: old.GetPostgresClaimName(), Namespace: "default"}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if pvc.Labels["app"] != old.Name {
+		t.Errorf("claim taken from a live Application: labels = %v", pvc.Labels)
+	}
+}
@@ -0,0 +1,286 @@
+// pkg/controllers/infra_drift.go
+// Detects and applies infrastructure spec changes on running applications
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// redisArgs renders the redis-server arguments for the requested memory limit
+func redisArgs(app *v1alpha1.Application) []string {
+	if app.Spec.Infrastructure.Redis == nil || app.Spec.Infrastructure.Redis.Memory == "" {
+		return nil
+	}
+	memory := resource.MustParse(app.Spec.Infrastructure.Redis.Memory)
+	return []string{"--maxmemory", fmt.Sprintf("%d", memory.Value())}
+}
+
+// infraChange is one difference between the spec and the live infrastructure
+type infraChange struct {
+	description string
+	// disruptive changes resize volumes or restart pods and wait for the
+	// maintenance window
+	disruptive bool
+}
+
+// splitInfraChanges separates the changes that can be applied now from those
+// deferred to the maintenance window
+func splitInfraChanges(changes []infraChange, inWindow bool) (apply, deferred []string) {
+	for _, c := range changes {
+		if c.disruptive && !inWindow {
+			deferred = append(deferred, c.description)
+		} else {
+			apply = append(apply, c.description)
+		}
+	}
+	return apply, deferred
+}
+
+// detectInfraDrift compares the desired local infrastructure against the live
+// objects and describes every change that provisioning can safely apply
+func (r *ApplicationController) detectInfraDrift(ctx context.Context, app *v1alpha1.Application) ([]infraChange, error) {
+	var changes []infraChange
+
+	if app.NeedsDatabase() && app.IsLocalDatabase() {
+		claimName, err := r.databaseClaimName(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		err = r.Get(ctx, client.ObjectKey{Name: claimName, Namespace: app.GetTargetNamespace()}, pvc)
+		switch {
+		case errors.IsNotFound(err):
+			changes = append(changes, infraChange{description: "PostgreSQL volume missing"})
+		case err != nil:
+			return nil, fmt.Errorf("failed to get PostgreSQL PVC: %w", err)
+		default:
+			desired := resource.MustParse(app.GetDatabaseStorage())
+			current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			if desired.Cmp(current) < 0 {
+				return nil, &storageShrinkError{claim: claimName, current: current, desired: desired}
+			}
+			if desired.Cmp(current) > 0 {
+				changes = append(changes, infraChange{
+					description: fmt.Sprintf("PostgreSQL storage %s -> %s", current.String(), desired.String()),
+					disruptive:  true,
+				})
+			}
+		}
+	}
+
+	if app.NeedsDatabase() && app.IsLocalDatabase() {
+		postgres := &appsv1.StatefulSet{}
+		err := r.Get(ctx, client.ObjectKey{Name: app.GetPostgresName(), Namespace: app.GetTargetNamespace()}, postgres)
+		switch {
+		case errors.IsNotFound(err):
+			changes = append(changes, infraChange{description: "PostgreSQL statefulset missing"})
+		case err != nil:
+			return nil, fmt.Errorf("failed to get PostgreSQL StatefulSet: %w", err)
+		case postgresConfigDrift(app, postgres):
+			changes = append(changes, infraChange{description: "PostgreSQL parameters changed", disruptive: true})
+		case postgresStrategyDrift(app, postgres):
+			changes = append(changes, infraChange{description: "PostgreSQL update strategy changed"})
+		case hasContainer(&postgres.Spec.Template.Spec, postgresExporterName) != app.Spec.Infrastructure.PostgreSQL.Exporter:
+			changes = append(changes, infraChange{
+				description: fmt.Sprintf("PostgreSQL exporter %s", toggled(app.Spec.Infrastructure.PostgreSQL.Exporter)),
+				disruptive:  true,
+			})
+		}
+	}
+
+	if app.NeedsDatabase() && app.IsLocalDatabase() {
+		switch {
+		case app.UsesPooler():
+			pooler := &appsv1.Deployment{}
+			err := r.Get(ctx, client.ObjectKey{Name: app.GetPgBouncerName(), Namespace: app.GetTargetNamespace()}, pooler)
+			switch {
+			case errors.IsNotFound(err):
+				changes = append(changes, infraChange{description: "PgBouncer deployment missing"})
+			case err != nil:
+				return nil, fmt.Errorf("failed to get PgBouncer Deployment: %w", err)
+			case pgbouncerDrift(pooler, r.buildPgBouncerDeployment(app)):
+				changes = append(changes, infraChange{description: "PgBouncer configuration changed"})
+			}
+		case app.Status.DatabasePoolerEndpoint != "":
+			changes = append(changes, infraChange{description: "PgBouncer disabled"})
+		}
+		if read := app.GetDatabaseReadReplicas() > 0; read != (app.Status.DatabaseReadEndpoint != "") {
+			changes = append(changes, infraChange{description: fmt.Sprintf("PostgreSQL read endpoint %s", toggled(read))})
+		}
+	}
+
+	if app.NeedsCache() && app.IsLocalRedis() {
+		redis := &appsv1.Deployment{}
+		err := r.Get(ctx, client.ObjectKey{Name: app.GetRedisName(), Namespace: app.GetTargetNamespace()}, redis)
+		switch {
+		case errors.IsNotFound(err):
+			changes = append(changes, infraChange{description: "Redis deployment missing"})
+		case err != nil:
+			return nil, fmt.Errorf("failed to get Redis Deployment: %w", err)
+		default:
+			desired := redisArgs(app)
+			if current := redis.Spec.Template.Spec.Containers[0].Args; !reflect.DeepEqual(current, desired) {
+				changes = append(changes, infraChange{
+					description: fmt.Sprintf("Redis args %v -> %v", current, desired),
+					disruptive:  true,
+				})
+			}
+			if exporter := app.Spec.Infrastructure.Redis.Exporter; hasContainer(&redis.Spec.Template.Spec, redisExporterName) != exporter {
+				changes = append(changes, infraChange{
+					description: fmt.Sprintf("Redis exporter %s", toggled(exporter)),
+					disruptive:  true,
+				})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// toggled describes a switched-on or switched-off option
+func toggled(on bool) string {
+	if on {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// storageShrinkError reports a requested database size below the live volume
+type storageShrinkError struct {
+	claim            string
+	current, desired resource.Quantity
+}
+
+func (e *storageShrinkError) Error() string {
+	return fmt.Sprintf("postgresql storage %s is smaller than volume %s (%s); volumes can only grow, restore the size to at least %s",
+		e.desired.String(), e.claim, e.current.String(), e.current.String())
+}
+
+// isStorageShrink reports whether err is, or wraps, a storageShrinkError
+func isStorageShrink(err error) bool {
+	var shrink *storageShrinkError
+	return stderrors.As(err, &shrink)
+}
+
+// checkDatabaseStorageShrink fails when the requested database storage is
+// smaller than the existing volume, before anything tries to apply it
+func (r *ApplicationController) checkDatabaseStorageShrink(ctx context.Context, app *v1alpha1.Application, claimName string) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKey{Name: claimName, Namespace: app.GetTargetNamespace()}, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PostgreSQL PVC: %w", err)
+	}
+	desired := resource.MustParse(app.GetDatabaseStorage())
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if desired.Cmp(current) < 0 {
+		return &storageShrinkError{claim: claimName, current: current, desired: desired}
+	}
+	return nil
+}
+
+// expandPVC grows an existing claim to the desired request by patching it,
+// since volume claim templates cannot change. Shrinking is not supported by
+// Kubernetes so smaller requests are left alone.
+func (r *ApplicationController) expandPVC(ctx context.Context, desired *corev1.PersistentVolumeClaim) error {
+	logger := log.FromContext(ctx)
+
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get PVC %s: %w", desired.Name, err)
+	}
+
+	want := desired.Spec.Resources.Requests[corev1.ResourceStorage]
+	have := existing.Spec.Resources.Requests[corev1.ResourceStorage]
+	if want.Cmp(have) <= 0 {
+		return nil
+	}
+	if err := r.checkVolumeExpansion(ctx, existing); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Spec.Resources.Requests[corev1.ResourceStorage] = want
+	if err := r.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("failed to expand PVC %s: %w", desired.Name, err)
+	}
+
+	logger.Info("Expanded PVC", "pvc", desired.Name, "from", have.String(), "to", want.String())
+	return nil
+}
+
+// checkVolumeExpansion fails with a clear message when the claim's StorageClass
+// does not allow expansion, rather than leaving the API server to reject the patch
+func (r *ApplicationController) checkVolumeExpansion(ctx context.Context, pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil
+	}
+	name := *pvc.Spec.StorageClassName
+	class := &storagev1.StorageClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, class); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get StorageClass %s: %w", name, err)
+	}
+	if class.AllowVolumeExpansion == nil || !*class.AllowVolumeExpansion {
+		return fmt.Errorf("cannot expand PVC %s: StorageClass %s does not allow volume expansion", pvc.Name, name)
+	}
+	return nil
+}
+
+// expandClaimTemplatePVCs grows the claims a StatefulSet created from one of
+// its volume claim templates, one per replica
+func (r *ApplicationController) expandClaimTemplatePVCs(ctx context.Context, sts *appsv1.StatefulSet, template corev1.PersistentVolumeClaim) error {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	for i := int32(0); i < replicas; i++ {
+		desired := template.DeepCopy()
+		desired.Name = fmt.Sprintf("%s-%s-%d", template.Name, sts.Name, i)
+		desired.Namespace = sts.Namespace
+		if err := r.expandPVC(ctx, desired); err != nil {
+			if errors.IsNotFound(err) {
+				// Not created yet; the StatefulSet will use the new size
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRedisArgs brings the live Redis container arguments in line with the spec
+func (r *ApplicationController) updateRedisArgs(ctx context.Context, desired *appsv1.Deployment) error {
+	existing := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get Redis Deployment: %w", err)
+	}
+
+	want := desired.Spec.Template.Spec.Containers[0].Args
+	if reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Args, want) {
+		return nil
+	}
+
+	existing.Spec.Template.Spec.Containers[0].Args = want
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update Redis Deployment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// newDriftTestApplication returns a Ready application whose local PostgreSQL
+// and Redis are already provisioned
+func newDriftTestApplication(t *testing.T) (*ApplicationController, *v1alpha1.Application) {
+	t.Helper()
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, LocalStorage: "2Gi"}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, Memory: "128Mi"}
+	app.Finalizers = []string{cleanupFinalizer}
+	app.Status.Phase = v1alpha1.PhaseReady
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	changes, err := r.detectInfraDrift(testCtx, app)
+	if err != nil {
+		t.Fatalf("detectInfraDrift: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("changes = %+v right after provisioning, want none", changes)
+	}
+	return r, app
+}
+
+func TestDetectInfraDriftStorageBump(t *testing.T) {
+	r, app := newDriftTestApplication(t)
+	app.Spec.Infrastructure.PostgreSQL.LocalStorage = "5Gi"
+
+	changes, err := r.detectInfraDrift(testCtx, app)
+	if err != nil {
+		t.Fatalf("detectInfraDrift: %v", err)
+	}
+	if len(changes) != 1 || changes[0].description != "PostgreSQL storage 2Gi -> 5Gi" || !changes[0].disruptive {
+		t.Errorf("changes = %+v, want one disruptive storage change 2Gi -> 5Gi", changes)
+	}
+}
+
+func TestRedisMemoryChangeWhileReady(t *testing.T) {
+	r, app := newDriftTestApplication(t)
+	app.Spec.Infrastructure.Redis.Memory = "256Mi"
+	if err := r.Update(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	result, err := r.Reconcile(testCtx, req)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Requeue || stored.Status.Phase != v1alpha1.PhasePending {
+		t.Fatalf("result = %+v, phase = %s, want an immediate requeue through Pending", result, stored.Status.Phase)
+	}
+	if !strings.Contains(stored.Status.Message, "Redis args") {
+		t.Errorf("message = %q, want it to name the Redis change", stored.Status.Message)
+	}
+	if stored.Status.InfrastructureReady {
+		t.Error("infrastructure still marked ready while the change is applied")
+	}
+}
+
+func TestRedisMemoryChangeInsideWindow(t *testing.T) {
+	r, app := newDriftTestApplication(t)
+	app.Spec.Infrastructure.Redis.Memory = "256Mi"
+	// A two-hour window that opened a minute ago
+	app.Spec.Infrastructure.MaintenanceWindow = &v1alpha1.MaintenanceWindow{
+		Start:    time.Now().UTC().Add(-time.Minute).Format("15:04"),
+		Duration: "2h",
+	}
+	if err := r.Update(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	result, err := r.Reconcile(testCtx, req)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Requeue || stored.Status.Phase != v1alpha1.PhasePending {
+		t.Errorf("result = %+v, phase = %s, want the change applied inside the window", result, stored.Status.Phase)
+	}
+	if len(stored.Status.DeferredChanges) != 0 {
+		t.Errorf("deferred changes = %v inside the window, want none", stored.Status.DeferredChanges)
+	}
+}
+
+func TestRedisMemoryChangeDeferredOutsideWindow(t *testing.T) {
+	r, app := newDriftTestApplication(t)
+	app.Spec.Infrastructure.Redis.Memory = "256Mi"
+	// A one-hour window that opens two hours from now is closed
+	app.Spec.Infrastructure.MaintenanceWindow = &v1alpha1.MaintenanceWindow{
+		Start:    time.Now().UTC().Add(2 * time.Hour).Format("15:04"),
+		Duration: "1h",
+	}
+	if err := r.Update(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+	if _, err := r.Reconcile(testCtx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Phase == v1alpha1.PhasePending {
+		t.Error("disruptive change applied outside the maintenance window")
+	}
+	if len(stored.Status.DeferredChanges) != 1 || !strings.HasPrefix(stored.Status.DeferredChanges[0], "Redis args") {
+		t.Errorf("deferred changes = %v, want the Redis change", stored.Status.DeferredChanges)
+	}
+	if wait := r.readyRequeue(stored, time.Now(), stored.Status.DeferredChanges); wait > 2*time.Hour {
+		t.Errorf("requeue after %s, want a return by the time the window opens", wait)
+	}
+}
+
+func TestDatabaseStorageShrinkRejected(t *testing.T) {
+	r, app := newDriftTestApplication(t)
+	claim := app.GetPostgresClaimName()
+
+	app.Spec.Infrastructure.PostgreSQL.LocalStorage = "1Gi"
+	err := r.checkDatabaseStorageShrink(testCtx, app, claim)
+	if !isStorageShrink(err) {
+		t.Fatalf("checkDatabaseStorageShrink = %v, want a storage shrink error", err)
+	}
+	if _, err := r.detectInfraDrift(testCtx, app); !isStorageShrink(err) {
+		t.Errorf("detectInfraDrift = %v, want the shrink reported", err)
+	}
+
+	app.Spec.Infrastructure.PostgreSQL.LocalStorage = "5Gi"
+	if err := r.checkDatabaseStorageShrink(testCtx, app, claim); err != nil {
+		t.Errorf("storage growth rejected: %v", err)
+	}
+}
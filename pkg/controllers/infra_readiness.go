@@ -0,0 +1,109 @@
+// pkg/controllers/infra_readiness.go
+// Gates InfrastructureReady on the local infrastructure pods actually running
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// unreadyInfrastructure returns the locally provisioned components whose
+// workloads do not yet report all replicas ready. AWS and external components
+// are not checked.
+func (r *ApplicationController) unreadyInfrastructure(ctx context.Context, app *v1alpha1.Application) ([]string, error) {
+	var unready []string
+
+	check := func(component string, ready func() (bool, error)) error {
+		ok, err := ready()
+		if err != nil {
+			return fmt.Errorf("failed to check %s readiness: %w", component, err)
+		}
+		if !ok {
+			unready = append(unready, component)
+		}
+		return nil
+	}
+
+	if app.NeedsDatabase() && app.Status.DatabaseEnvironment == v1alpha1.EnvironmentLocal {
+		if err := check(componentDatabase, func() (bool, error) {
+			return r.statefulSetReady(ctx, app.GetTargetNamespace(), app.GetPostgresName())
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if app.NeedsStreaming() && app.Status.KafkaEnvironment == v1alpha1.EnvironmentLocal {
+		if err := check(componentStreaming, func() (bool, error) {
+			return r.statefulSetReady(ctx, app.GetTargetNamespace(), app.GetKafkaName())
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	local := []struct {
+		needed    bool
+		env       v1alpha1.Environment
+		component string
+		name      string
+	}{
+		{app.NeedsCache(), app.Status.RedisEnvironment, componentCache, app.GetRedisName()},
+		{app.NeedsStorage(), app.Status.S3Environment, componentStorage, app.GetS3Name()},
+		{app.NeedsDynamoDB(), app.Status.DynamoDBEnvironment, componentNoSQL, app.GetDynamoDBName()},
+		{app.NeedsQueue(), app.Status.SQSEnvironment, componentQueue, app.GetSQSName()},
+		{app.UsesPooler(), app.Status.DatabaseEnvironment, componentPooler, app.GetPgBouncerName()},
+	}
+	for _, l := range local {
+		if !l.needed || l.env != v1alpha1.EnvironmentLocal {
+			continue
+		}
+		name := l.name
+		if err := check(l.component, func() (bool, error) {
+			return r.deploymentReady(ctx, app.GetTargetNamespace(), name)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return unready, nil
+}
+
+// statefulSetReady reports whether every desired replica is ready; a missing
+// StatefulSet is simply not ready yet
+func (r *ApplicationController) statefulSetReady(ctx context.Context, namespace, name string) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, sts); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return replicasReady(sts.Spec.Replicas, sts.Status.ReadyReplicas), nil
+}
+
+// deploymentReady reports whether every desired replica is ready; a missing
+// Deployment is simply not ready yet
+func (r *ApplicationController) deploymentReady(ctx context.Context, namespace, name string) (bool, error) {
+	deploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deploy); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return replicasReady(deploy.Spec.Replicas, deploy.Status.ReadyReplicas), nil
+}
+
+func replicasReady(desired *int32, ready int32) bool {
+	want := int32(1)
+	if desired != nil {
+		want = *desired
+	}
+	return ready >= want
+}
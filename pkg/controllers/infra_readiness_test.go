@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestInfrastructureReadyWaitsForLocalPods(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(app), app); err != nil {
+		t.Fatal(err)
+	}
+
+	// A spec edit since the read: the status write still has to land
+	edited := app.DeepCopy()
+	edited.Labels = map[string]string{"edited": "true"}
+	if err := r.Update(testCtx, edited); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.provisionInfrastructure(testCtx, app); err != nil {
+		t.Fatalf("provisionInfrastructure: %v", err)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(app), stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.InfrastructureReady {
+		t.Fatal("infrastructure marked ready before the Redis pods are")
+	}
+	if !strings.Contains(stored.Status.Message, componentCache) {
+		t.Errorf("stored message = %q, want it to name the cache", stored.Status.Message)
+	}
+
+	redis := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: app.GetRedisName()}, redis); err != nil {
+		t.Fatal(err)
+	}
+	redis.Status.ReadyReplicas = 1
+	if err := r.Status().Update(testCtx, redis); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.provisionInfrastructure(testCtx, app); err != nil {
+		t.Fatalf("provisionInfrastructure: %v", err)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(app), stored); err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Status.InfrastructureReady {
+		t.Error("infrastructure not marked ready once the Redis pods are")
+	}
+}
+
+func TestFailedComponentRetriedAlone(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	// Redis cannot be created until failRedis is cleared; every write to the
+	// PostgreSQL StatefulSet is counted
+	failRedis, postgresWrites := true, 0
+	count := func(obj client.Object) {
+		if obj.GetName() == app.GetPostgresName() {
+			postgresWrites++
+		}
+	}
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if failRedis && obj.GetName() == app.GetRedisName() {
+				return errors.New("quota exceeded")
+			}
+			count(obj)
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			count(obj)
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+
+	if err := r.provisionInfrastructure(testCtx, app); err == nil || !strings.Contains(err.Error(), componentCache) {
+		t.Fatalf("provisionInfrastructure = %v, want the cache to fail", err)
+	}
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: app.GetPostgresName()}, sts); err != nil {
+		t.Fatalf("PostgreSQL not provisioned alongside the failed cache: %v", err)
+	}
+	sts.Status.ReadyReplicas = 1
+	if err := r.Status().Update(testCtx, sts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Redis still fails; PostgreSQL is now recorded ready on its own
+	if err := r.provisionInfrastructure(testCtx, app); err == nil {
+		t.Fatal("provisionInfrastructure succeeded while Redis still fails")
+	}
+	if !app.Status.ComponentReady(componentDatabase) || app.Status.ComponentReady(componentCache) {
+		t.Fatalf("components = %+v, want the database ready and the cache not", app.Status.Components)
+	}
+	if app.Status.InfrastructureReady {
+		t.Error("infrastructure marked ready with a failed component")
+	}
+
+	// Once Redis can be created only it is provisioned
+	failRedis, postgresWrites = false, 0
+	if err := r.provisionInfrastructure(testCtx, app); err != nil {
+		t.Fatalf("provisionInfrastructure: %v", err)
+	}
+	if postgresWrites != 0 {
+		t.Errorf("PostgreSQL written %d times on retry, want it skipped", postgresWrites)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: app.GetRedisName()}, &appsv1.Deployment{}); err != nil {
+		t.Errorf("Redis not provisioned on retry: %v", err)
+	}
+	if !app.Status.ComponentReady(componentDatabase) {
+		t.Errorf("components = %+v, want the database still ready", app.Status.Components)
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestInfrastructureOnly(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.InfrastructureOnly = true
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentExternal, Endpoint: "cache.example.com:6379"}
+	r, _ := newTestController(t, app)
+
+	stored := reconcileUntil(t, r, app, v1alpha1.PhaseInfrastructureReady, v1alpha1.PhaseFailed)
+	if stored.Status.Phase != v1alpha1.PhaseInfrastructureReady {
+		t.Fatalf("phase = %s (%s), want InfrastructureReady", stored.Status.Phase, stored.Status.Message)
+	}
+	if stored.Status.RedisEndpoint != "cache.example.com:6379" {
+		t.Errorf("redis endpoint = %q, want it published in status", stored.Status.RedisEndpoint)
+	}
+
+	key := client.ObjectKey{Name: app.Name, Namespace: "default"}
+	if err := r.Get(testCtx, key, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("application Deployment created for an infrastructureOnly Application: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Errorf("application Service created for an infrastructureOnly Application: %v", err)
+	}
+
+	// The endpoints reach the externally deployed app through the connection secret
+	secret := &corev1.Secret{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetConnectionSecretName(), Namespace: "default"}, secret); err != nil {
+		t.Fatalf("get connection secret: %v", err)
+	}
+	if got := secret.StringData["REDIS_URL"]; got != "redis://cache.example.com:6379" {
+		t.Errorf("REDIS_URL = %q, want redis://cache.example.com:6379", got)
+	}
+}
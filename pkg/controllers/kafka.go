@@ -0,0 +1,211 @@
+// pkg/controllers/kafka.go
+// Kafka brokers for streaming workloads (single-node KRaft when local)
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	kafkaPort           = 9092
+	kafkaControllerPort = 9093
+
+	// The JVM heap is capped well below the container limit so a local broker
+	// cannot starve the node
+	kafkaHeapOpts = "-Xms256m -Xmx512m"
+)
+
+// kafkaResources bounds the local broker, which is by far the heaviest local component
+var kafkaResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("250m"),
+		corev1.ResourceMemory: resource.MustParse("768Mi"),
+	},
+	Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	},
+}
+
+// kafkaBrokerEnv configures the apache/kafka image as a combined broker and
+// controller, so no ZooKeeper is needed
+func kafkaBrokerEnv(app *v1alpha1.Application) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "KAFKA_NODE_ID", Value: "1"},
+		{Name: "KAFKA_PROCESS_ROLES", Value: "broker,controller"},
+		{Name: "KAFKA_LISTENERS", Value: fmt.Sprintf("PLAINTEXT://:%d,CONTROLLER://:%d", kafkaPort, kafkaControllerPort)},
+		{Name: "KAFKA_ADVERTISED_LISTENERS", Value: fmt.Sprintf("PLAINTEXT://%s:%d", app.GetKafkaName(), kafkaPort)},
+		{Name: "KAFKA_CONTROLLER_LISTENER_NAMES", Value: "CONTROLLER"},
+		{Name: "KAFKA_LISTENER_SECURITY_PROTOCOL_MAP", Value: "CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT"},
+		{Name: "KAFKA_CONTROLLER_QUORUM_VOTERS", Value: fmt.Sprintf("1@localhost:%d", kafkaControllerPort)},
+		{Name: "KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR", Value: "1"},
+		{Name: "KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR", Value: "1"},
+		{Name: "KAFKA_TRANSACTION_STATE_LOG_MIN_ISR", Value: "1"},
+		{Name: "KAFKA_NUM_PARTITIONS", Value: fmt.Sprintf("%d", app.GetKafkaPartitions())},
+		{Name: "KAFKA_LOG_DIRS", Value: "/var/lib/kafka/data"},
+		{Name: "KAFKA_HEAP_OPTS", Value: kafkaHeapOpts},
+	}
+}
+
+// createTopicsScript waits for the broker and creates each topic; --if-not-exists
+// makes reruns harmless
+func createTopicsScript(brokers string, topics []string, partitions int32) string {
+	cmds := []string{fmt.Sprintf("until /opt/kafka/bin/kafka-topics.sh --bootstrap-server %s --list >/dev/null; do sleep 2; done", brokers)}
+	for _, topic := range topics {
+		cmds = append(cmds, fmt.Sprintf(
+			"/opt/kafka/bin/kafka-topics.sh --bootstrap-server %s --create --if-not-exists --topic %s --partitions %d --replication-factor 1",
+			brokers, topic, partitions))
+	}
+	return strings.Join(cmds, " && ")
+}
+
+// provisionLocalKafka runs a single KRaft broker and creates the requested topics
+func (r *ApplicationController) provisionLocalKafka(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentStreaming)
+	logger.Info("Creating local Kafka (KRaft)")
+
+	spec := app.Spec.Infrastructure.Kafka
+	image := app.InfraImage(fmt.Sprintf("apache/kafka:%s", app.GetKafkaVersion()))
+	brokers := fmt.Sprintf("%s:%d", app.GetKafkaName(), kafkaPort)
+	labels := map[string]string{"app": app.Name, "component": componentStreaming, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentStreaming}
+
+	kafka := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetKafkaName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &[]int32{1}[0],
+			ServiceName: app.GetKafkaName(),
+			Selector:    &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector:    r.infraNodeSelector(spec.NodeSelector),
+					Tolerations:     r.withSpotToleration(infraTolerations(app), spec.Preemptible),
+					Affinity:        r.infraAffinity(spec.Preemptible),
+					SecurityContext: infraPodSecurityContext(kafkaFSGroup),
+					Containers: []corev1.Container{
+						{
+							Name:  "kafka",
+							Image: image,
+							Env:   kafkaBrokerEnv(app),
+							Ports: []corev1.ContainerPort{
+								{Name: "broker", ContainerPort: kafkaPort},
+								{Name: "controller", ContainerPort: kafkaControllerPort},
+							},
+							Resources: kafkaResources,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "kafka-data", MountPath: "/var/lib/kafka/data"},
+							},
+						},
+					},
+				},
+			},
+			// Claims from the template are not owned by the Application and
+			// survive its deletion, like the PostgreSQL volume
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "kafka-data",
+						Labels: labels,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{app.GetKafkaAccessMode()},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse(v1alpha1.DefaultKafkaStorage),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := r.createOrRecreateStatefulSet(ctx, app, kafka); err != nil {
+		return fmt.Errorf("failed to create Kafka StatefulSet: %w", err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetKafkaName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       kafkaPort,
+					TargetPort: intstr.FromInt(kafkaPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create Kafka Service: %w", err)
+	}
+
+	if len(spec.Topics) > 0 {
+		job := kafkaTopicsJob(app, image, createTopicsScript(brokers, spec.Topics, app.GetKafkaPartitions()), r.infraNodeSelector(spec.NodeSelector))
+		if err := r.createOwned(ctx, app, job); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Kafka topic Job: %w", err)
+		}
+	}
+
+	app.Status.KafkaBrokers = brokers
+	app.Status.KafkaEnvironment = v1alpha1.EnvironmentLocal
+
+	logger.Info("Local Kafka created", "brokers", brokers, "topics", spec.Topics)
+	return nil
+}
+
+// kafkaTopicsJob runs the topic creation script with the broker's own CLI tools
+func kafkaTopicsJob(app *v1alpha1.Application, image, script string, nodeSelector map[string]string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetKafkaTopicsJobName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": componentStreaming, "managed-by": "orion-platform"},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{6}[0],
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": app.Name, "component": componentStreaming + "-setup"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeSelector:  nodeSelector,
+					Tolerations:   infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:    "kafka-topics",
+							Image:   image,
+							Command: []string{"/bin/sh", "-c", script},
+							Env:     []corev1.EnvVar{{Name: "KAFKA_HEAP_OPTS", Value: "-Xmx128m"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
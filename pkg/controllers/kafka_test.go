@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestLocalKafka(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.Kafka = &v1alpha1.KafkaSpec{Environment: v1alpha1.EnvironmentLocal, Partitions: 3, Topics: []string{"orders", "payments"}}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalKafka(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalKafka: %v", err)
+	}
+	brokers := app.GetKafkaName() + ":9092"
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetKafkaName(), Namespace: "default"}, sts); err != nil {
+		t.Fatalf("get StatefulSet: %v", err)
+	}
+	broker := sts.Spec.Template.Spec.Containers[0]
+	if !hasEnv(broker.Env, "KAFKA_ADVERTISED_LISTENERS", "PLAINTEXT://"+brokers) || !hasEnv(broker.Env, "KAFKA_NUM_PARTITIONS", "3") {
+		t.Errorf("broker env = %v, want the advertised listener and partition count", broker.Env)
+	}
+	if len(sts.Spec.VolumeClaimTemplates) != 1 || sts.Spec.VolumeClaimTemplates[0].Name != "kafka-data" {
+		t.Errorf("claim templates = %+v, want kafka-data", sts.Spec.VolumeClaimTemplates)
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetKafkaTopicsJobName(), Namespace: "default"}, job); err != nil {
+		t.Fatalf("get topic Job: %v", err)
+	}
+	script := job.Spec.Template.Spec.Containers[0].Command[2]
+	for _, topic := range []string{"orders", "payments"} {
+		if !strings.Contains(script, "--create --if-not-exists --topic "+topic+" --partitions 3") {
+			t.Errorf("topic script = %q, want %s created with 3 partitions", script, topic)
+		}
+	}
+	if job.Spec.Template.Spec.Containers[0].Image != broker.Image {
+		t.Errorf("topic Job image = %q, want the broker image %q", job.Spec.Template.Spec.Containers[0].Image, broker.Image)
+	}
+
+	if app.Status.KafkaBrokers != brokers || app.Status.KafkaEnvironment != v1alpha1.EnvironmentLocal {
+		t.Errorf("status = %s (%s), want the local brokers", app.Status.KafkaBrokers, app.Status.KafkaEnvironment)
+	}
+	if env := r.buildEnvironmentVariables(app); !hasEnv(env, "KAFKA_BROKERS", brokers) {
+		t.Errorf("app env = %v, want KAFKA_BROKERS", env)
+	}
+}
+
+func TestLocalKafkaWithoutTopics(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.Kafka = &v1alpha1.KafkaSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalKafka(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalKafka: %v", err)
+	}
+	err := r.Get(testCtx, client.ObjectKey{Name: app.GetKafkaTopicsJobName(), Namespace: "default"}, &batchv1.Job{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("topic Job created without topics: %v", err)
+	}
+}
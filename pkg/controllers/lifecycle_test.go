@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	if template.Spec.TerminationGracePeriodSeconds != nil || template.Spec.Containers[0].Lifecycle != nil {
+		t.Errorf("grace period %v, lifecycle %+v without either in the spec; want the defaults",
+			template.Spec.TerminationGracePeriodSeconds, template.Spec.Containers[0].Lifecycle)
+	}
+
+	grace := int64(45)
+	app.Spec.TerminationGracePeriodSeconds = &grace
+	app.Spec.PreStop = &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "10"}}}
+	template, err = r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	if got := template.Spec.TerminationGracePeriodSeconds; got == nil || *got != 45 {
+		t.Errorf("terminationGracePeriodSeconds = %v, want 45", got)
+	}
+	lifecycle := template.Spec.Containers[0].Lifecycle
+	if lifecycle == nil || lifecycle.PreStop == nil || lifecycle.PreStop.Exec == nil || lifecycle.PreStop.Exec.Command[1] != "10" {
+		t.Fatalf("lifecycle = %+v, want the preStop exec hook", lifecycle)
+	}
+	if lifecycle.PostStart != nil {
+		t.Errorf("postStart = %+v without one in the spec", lifecycle.PostStart)
+	}
+	lifecycle.PreStop.Exec.Command[1] = "20"
+	if app.Spec.PreStop.Exec.Command[1] != "10" {
+		t.Error("preStop in the spec shares memory with the pod template")
+	}
+
+	grace = -1
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("negative terminationGracePeriodSeconds accepted")
+	}
+}
+
+func TestPostStartHook(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.PostStart = &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/warm-cache"}}}
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	lifecycle := template.Spec.Containers[0].Lifecycle
+	if lifecycle == nil || lifecycle.PostStart == nil || lifecycle.PostStart.Exec == nil ||
+		lifecycle.PostStart.Exec.Command[0] != "/bin/warm-cache" {
+		t.Fatalf("lifecycle = %+v, want the postStart exec hook", lifecycle)
+	}
+	if lifecycle.PreStop != nil {
+		t.Errorf("preStop = %+v without one in the spec", lifecycle.PreStop)
+	}
+	lifecycle.PostStart.Exec.Command[0] = "changed"
+	if app.Spec.PostStart.Exec.Command[0] != "/bin/warm-cache" {
+		t.Error("postStart in the spec shares memory with the pod template")
+	}
+}
+
+func TestValidatePostStart(t *testing.T) {
+	exec := &corev1.ExecAction{Command: []string{"/bin/warm-cache"}}
+	httpGet := &corev1.HTTPGetAction{Path: "/warm", Port: intstr.FromInt32(8080)}
+	tests := []struct {
+		name    string
+		hook    corev1.LifecycleHandler
+		wantErr bool
+	}{
+		{"exec", corev1.LifecycleHandler{Exec: exec}, false},
+		{"httpGet", corev1.LifecycleHandler{HTTPGet: httpGet}, false},
+		{"exec and httpGet", corev1.LifecycleHandler{Exec: exec, HTTPGet: httpGet}, true},
+		{"no handler", corev1.LifecycleHandler{}, true},
+		{"tcpSocket", corev1.LifecycleHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Spec.PostStart = &tt.hook
+			if err := app.ValidateSpec(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSpec() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// pkg/controllers/logging.go
+// Consistent structured logging for the controller
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Standard log keys shared by every controller log line. The namespace is not
+// among them: controller-runtime already logs it for every reconcile.
+const (
+	logKeyApplication = "application"
+	logKeyPhase       = "phase"
+	logKeyComponent   = "component"
+)
+
+// Component values, matching the "component" label on provisioned resources
+const (
+	componentDatabase     = "database"
+	componentDatabaseRead = "database-read"
+	componentCache        = "cache"
+	componentStorage      = "storage"
+	componentNoSQL        = "nosql"
+	componentQueue        = "queue"
+	componentDevTools     = "devtools"
+	componentStreaming    = "streaming"
+	componentPooler       = "pooler"
+)
+
+// appLogger decorates the context logger with the Application's standard keys
+func appLogger(ctx context.Context, app *v1alpha1.Application) logr.Logger {
+	return log.FromContext(ctx).WithValues(
+		logKeyApplication, app.Name,
+		logKeyPhase, app.Status.Phase,
+	)
+}
+
+// componentLogger additionally tags the infrastructure component being handled
+func componentLogger(ctx context.Context, app *v1alpha1.Application, component string) logr.Logger {
+	return appLogger(ctx, app).WithValues(logKeyComponent, component)
+}
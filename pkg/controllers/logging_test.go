@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestAppLoggerDoesNotRepeatNamespace(t *testing.T) {
+	var lines []string
+	sink := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+	// controller-runtime puts the request namespace on the reconcile logger
+	ctx := log.IntoContext(context.Background(), sink.WithValues("namespace", "default"))
+
+	app := newTestApplication("web")
+	componentLogger(ctx, app, componentDatabase).Info("provisioning")
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want 1", len(lines))
+	}
+	if n := strings.Count(lines[0], `"namespace"`); n != 1 {
+		t.Errorf("namespace logged %d times: %s", n, lines[0])
+	}
+	for _, key := range []string{logKeyApplication, logKeyPhase, logKeyComponent} {
+		if !strings.Contains(lines[0], `"`+key+`"`) {
+			t.Errorf("%s missing from %s", key, lines[0])
+		}
+	}
+}
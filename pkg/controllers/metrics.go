@@ -0,0 +1,74 @@
+// pkg/controllers/metrics.go
+// Prometheus scrape annotations for applications exposing metrics
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Annotations understood by the common Prometheus kubernetes_sd scrape configs
+const (
+	prometheusScrapeAnnotation = "prometheus.io/scrape"
+	prometheusPathAnnotation   = "prometheus.io/path"
+	prometheusPortAnnotation   = "prometheus.io/port"
+
+	metricsPortName = "metrics"
+)
+
+// prometheusAnnotations returns the scrape annotations, or nil when metrics are disabled
+func prometheusAnnotations(app *v1alpha1.Application) map[string]string {
+	if !app.MetricsEnabled() {
+		return nil
+	}
+	return map[string]string{
+		prometheusScrapeAnnotation: "true",
+		prometheusPathAnnotation:   app.GetMetricsPath(),
+		prometheusPortAnnotation:   fmt.Sprintf("%d", app.GetMetricsPort()),
+	}
+}
+
+// metricsContainerPort is the extra container port when metrics are not served
+// on the application port
+func metricsContainerPort(app *v1alpha1.Application) []corev1.ContainerPort {
+	if !app.HasSeparateMetricsPort() {
+		return nil
+	}
+	return []corev1.ContainerPort{{
+		Name:          metricsPortName,
+		ContainerPort: app.GetMetricsPort(),
+		Protocol:      corev1.ProtocolTCP,
+	}}
+}
+
+// metricsServicePort exposes a separate metrics port on the application Service
+func metricsServicePort(app *v1alpha1.Application) []corev1.ServicePort {
+	if !app.HasSeparateMetricsPort() {
+		return nil
+	}
+	return []corev1.ServicePort{{
+		Name:       metricsPortName,
+		Port:       app.GetMetricsPort(),
+		TargetPort: intstr.FromInt32(app.GetMetricsPort()),
+		Protocol:   corev1.ProtocolTCP,
+	}}
+}
+
+// mergeAnnotations copies extra into annotations, allocating when needed
+func mergeAnnotations(annotations, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string, len(extra))
+	}
+	for k, v := range extra {
+		annotations[k] = v
+	}
+	return annotations
+}
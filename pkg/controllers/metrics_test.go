@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestPrometheusScrape(t *testing.T) {
+	tests := []struct {
+		name         string
+		metrics      *v1alpha1.MetricsSpec
+		wantPort     string
+		wantPath     string
+		wantSeparate bool
+	}{
+		{"disabled", nil, "", "", false},
+		{"application port", &v1alpha1.MetricsSpec{Enabled: true}, "8080", "/metrics", false},
+		{"separate port", &v1alpha1.MetricsSpec{Enabled: true, Port: 9090, Path: "/prom"}, "9090", "/prom", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.Port = 8080
+			app.Spec.Metrics = tt.metrics
+			r, _ := newTestController(t, app)
+
+			template, err := r.buildPodTemplate(testCtx, app)
+			if err != nil {
+				t.Fatalf("buildPodTemplate: %v", err)
+			}
+			service, err := r.buildAppService(testCtx, app)
+			if err != nil {
+				t.Fatalf("buildAppService: %v", err)
+			}
+			for kind, annotations := range map[string]map[string]string{"pod": template.Annotations, "Service": service.Annotations} {
+				if tt.wantPort == "" {
+					if _, ok := annotations[prometheusScrapeAnnotation]; ok {
+						t.Errorf("%s annotations = %v with metrics disabled", kind, annotations)
+					}
+					continue
+				}
+				if annotations[prometheusScrapeAnnotation] != "true" || annotations[prometheusPortAnnotation] != tt.wantPort ||
+					annotations[prometheusPathAnnotation] != tt.wantPath {
+					t.Errorf("%s annotations = %v, want scraping on %s%s", kind, annotations, tt.wantPort, tt.wantPath)
+				}
+			}
+
+			hasContainerPort := false
+			for _, p := range template.Spec.Containers[0].Ports {
+				if p.Name == metricsPortName && p.ContainerPort == 9090 && p.Protocol == corev1.ProtocolTCP {
+					hasContainerPort = true
+				}
+			}
+			if hasContainerPort != tt.wantSeparate {
+				t.Errorf("container ports = %+v, want a metrics port %t", template.Spec.Containers[0].Ports, tt.wantSeparate)
+			}
+			if hasServicePort(service, 9090) != tt.wantSeparate {
+				t.Errorf("Service ports = %+v, want a metrics port %t", service.Spec.Ports, tt.wantSeparate)
+			}
+		})
+	}
+}
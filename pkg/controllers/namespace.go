@@ -0,0 +1,251 @@
+// pkg/controllers/namespace.go
+// Deploying an Application's resources into a separate target namespace
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Owner references cannot cross namespaces, so resources in a target namespace
+// point back at their Application with these labels instead
+const (
+	ownerNameLabel      = "platform.orion.dev/owner-name"
+	ownerNamespaceLabel = "platform.orion.dev/owner-namespace"
+)
+
+// cleanupFinalizer holds an Application until the resources it created in its
+// target namespace have been deleted and its data's deletion policies applied
+const cleanupFinalizer = "platform.orion.dev/cleanup"
+
+// setOwnerLabels marks obj as belonging to app across namespaces
+func setOwnerLabels(app *v1alpha1.Application, obj client.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ownerNameLabel] = app.Name
+	labels[ownerNamespaceLabel] = app.Namespace
+	obj.SetLabels(labels)
+}
+
+// labelOwner returns the Application named by obj's owner labels, if any
+func labelOwner(obj metav1.Object) (types.NamespacedName, bool) {
+	labels := obj.GetLabels()
+	name, namespace := labels[ownerNameLabel], labels[ownerNamespaceLabel]
+	if name == "" || namespace == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Name: name, Namespace: namespace}, true
+}
+
+// enqueueLabelOwner maps a resource in a target namespace to its Application
+func enqueueLabelOwner(_ context.Context, obj client.Object) []reconcile.Request {
+	owner, ok := labelOwner(obj)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: owner}}
+}
+
+// ensureTargetNamespace makes sure the namespace the application deploys into
+// exists, creating it when the controller is allowed to. Only a namespace the
+// controller may never manage is a permanent error; a missing namespace may
+// still be created, and RBAC fixed.
+func (r *ApplicationController) ensureTargetNamespace(ctx context.Context, app *v1alpha1.Application) error {
+	name := app.GetTargetNamespace()
+	if ns := r.Config.WatchNamespace; ns != "" && name != ns {
+		return permanent(fmt.Errorf("target namespace %s is outside the watched namespace %s", name, ns))
+	}
+	if !app.DeploysToOtherNamespace() {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	err := r.Get(ctx, client.ObjectKey{Name: name}, ns)
+	if err == nil {
+		return nil
+	}
+	if errors.IsForbidden(err) {
+		return fmt.Errorf("cannot read target namespace %s: the controller's ClusterRole needs get on namespaces: %w", name, err)
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get target namespace %s: %w", name, err)
+	}
+	if !r.Config.CreateNamespaces {
+		return fmt.Errorf("target namespace %s does not exist; create it, or run the controller with --create-namespaces (needs create on namespaces)", name)
+	}
+
+	ns = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"managed-by": "orion-platform"},
+		},
+	}
+	if err := r.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+		if errors.IsForbidden(err) {
+			return fmt.Errorf("cannot create target namespace %s: the controller's ClusterRole needs create on namespaces: %w", name, err)
+		}
+		return fmt.Errorf("failed to create target namespace %s: %w", name, err)
+	}
+	appLogger(ctx, app).Info("Created target namespace", "targetNamespace", name)
+	r.recordEvent(app, corev1.EventTypeNormal, "NamespaceCreated", fmt.Sprintf("Created target namespace %s", name))
+	return nil
+}
+
+// ensureCleanupFinalizer adds the cleanup finalizer to cross-namespace
+// Applications and those with a provisioned bucket or database volume. It
+// reports whether the Application was updated.
+func (r *ApplicationController) ensureCleanupFinalizer(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	needed := app.DeploysToOtherNamespace() || managesBucket(app) || managesDatabaseClaim(app)
+	if !needed || controllerutil.ContainsFinalizer(app, cleanupFinalizer) {
+		return false, nil
+	}
+	controllerutil.AddFinalizer(app, cleanupFinalizer)
+	if err := r.Update(ctx, app); err != nil {
+		return false, fmt.Errorf("failed to add finalizer: %w", err)
+	}
+	return true, nil
+}
+
+// finalizeApplication deletes what a cross-namespace Application created in its
+// target namespace, which garbage collection cannot reach, applies the bucket
+// and database volume deletion policies, then releases the finalizer. Failed
+// attempts are counted in status; once they exceed the configured attempts or
+// timeout, --orphan-on-cleanup-failure releases the finalizer anyway and
+// reports what was left behind.
+func (r *ApplicationController) finalizeApplication(ctx context.Context, app *v1alpha1.Application) error {
+	if !controllerutil.ContainsFinalizer(app, cleanupFinalizer) {
+		return nil
+	}
+	logger := appLogger(ctx, app)
+
+	remaining, err := r.deleteTargetNamespaceResources(ctx, app)
+	if err != nil {
+		app.Status.CleanupAttempts++
+		app.Status.Message = fmt.Sprintf("Cleanup failed (attempt %d): %v", app.Status.CleanupAttempts, err)
+		elapsed := time.Since(app.DeletionTimestamp.Time).Round(time.Second)
+		logger.Error(err, "Cleanup of target namespace failed", "attempt", app.Status.CleanupAttempts, "deletingFor", elapsed)
+		r.recordEvent(app, corev1.EventTypeWarning, "CleanupFailed",
+			fmt.Sprintf("attempt %d, deleting for %s: %v", app.Status.CleanupAttempts, elapsed, err))
+
+		if !r.cleanupExhausted(app) {
+			if statusErr := r.writeStatus(ctx, app); statusErr != nil {
+				logger.Error(statusErr, "Failed to record cleanup attempt")
+			}
+			return err
+		}
+		if !r.Config.OrphanOnCleanupFailure {
+			if statusErr := r.writeStatus(ctx, app); statusErr != nil {
+				logger.Error(statusErr, "Failed to record cleanup attempt")
+			}
+			return fmt.Errorf("cleanup still failing after %d attempts; run the controller with --orphan-on-cleanup-failure to release the Application: %w",
+				app.Status.CleanupAttempts, err)
+		}
+
+		orphaned := make([]string, 0, len(remaining))
+		for _, ref := range remaining {
+			orphaned = append(orphaned, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))
+		}
+		logger.Info("Giving up on cleanup and orphaning resources", "targetNamespace", app.GetTargetNamespace(), "orphaned", orphaned)
+		r.recordEvent(app, corev1.EventTypeWarning, "ResourcesOrphaned",
+			fmt.Sprintf("Released after %d failed cleanup attempts; left in %s: %s",
+				app.Status.CleanupAttempts, app.GetTargetNamespace(), strings.Join(orphaned, ", ")))
+	} else if app.DeploysToOtherNamespace() {
+		logger.Info("Cleaned up target namespace resources", "targetNamespace", app.GetTargetNamespace())
+	}
+
+	if err := r.releaseBucket(ctx, app); err != nil {
+		return err
+	}
+	if err := r.releaseDatabaseClaim(ctx, app); err != nil {
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(app, cleanupFinalizer)
+	if err := r.Update(ctx, app); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// cleanupExhausted reports whether cleanup has used up its attempts or time
+func (r *ApplicationController) cleanupExhausted(app *v1alpha1.Application) bool {
+	if max := r.Config.CleanupMaxAttempts; max > 0 && app.Status.CleanupAttempts >= int32(max) {
+		return true
+	}
+	timeout := r.Config.CleanupTimeout
+	return timeout > 0 && time.Since(app.DeletionTimestamp.Time) >= timeout
+}
+
+// deleteTargetNamespaceResources deletes every object in the target namespace
+// carrying the Application's owner labels. On failure it returns the objects
+// that may still exist: those it could not delete, or the whole inventory in
+// the target namespace when listing failed.
+func (r *ApplicationController) deleteTargetNamespaceResources(ctx context.Context, app *v1alpha1.Application) ([]v1alpha1.ManagedResourceRef, error) {
+	inventory := func() []v1alpha1.ManagedResourceRef {
+		var refs []v1alpha1.ManagedResourceRef
+		for _, ref := range app.Status.ManagedResources {
+			if ref.Namespace == app.GetTargetNamespace() {
+				refs = append(refs, ref)
+			}
+		}
+		return refs
+	}
+
+	lists := []client.ObjectList{
+		&appsv1.DeploymentList{},
+		&appsv1.StatefulSetList{},
+		&batchv1.CronJobList{},
+		&batchv1.JobList{},
+		&corev1.ServiceList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.PersistentVolumeClaimList{},
+		&networkingv1.IngressList{},
+		&corev1.ResourceQuotaList{},
+		&corev1.LimitRangeList{},
+	}
+	var failed []v1alpha1.ManagedResourceRef
+	var firstErr error
+	for _, list := range lists {
+		if err := r.List(ctx, list, client.InNamespace(app.GetTargetNamespace()),
+			client.MatchingLabels{ownerNameLabel: app.Name, ownerNamespaceLabel: app.Namespace}); err != nil {
+			return inventory(), fmt.Errorf("failed to list resources for cleanup: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return inventory(), err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if err := r.Delete(ctx, obj, client.PropagationPolicy("Background")); err != nil && !errors.IsNotFound(err) {
+				failed = append(failed, r.managedResourceRef(obj))
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+				}
+			}
+		}
+	}
+	return failed, firstErr
+}
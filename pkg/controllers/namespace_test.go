@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEnsureTargetNamespace(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.TargetNamespace = "shop"
+	r, _ := newTestController(t, app)
+
+	err := r.ensureTargetNamespace(testCtx, app)
+	if err == nil || isPermanentError(err) {
+		t.Fatalf("missing namespace: err = %v, want a retryable error", err)
+	}
+
+	r.Config.CreateNamespaces = true
+	if err := r.ensureTargetNamespace(testCtx, app); err != nil {
+		t.Fatalf("ensureTargetNamespace: %v", err)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Name: "shop"}, &corev1.Namespace{}); err != nil {
+		t.Errorf("namespace not created: %v", err)
+	}
+
+	r.Config.WatchNamespace = "default"
+	if err := r.ensureTargetNamespace(testCtx, app); !isPermanentError(err) {
+		t.Errorf("namespace outside the watched one: err = %v, want a permanent error", err)
+	}
+}
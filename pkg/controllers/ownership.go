@@ -0,0 +1,131 @@
+// pkg/controllers/ownership.go
+// Owner references tying child resources to their Application
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// scheme returns the scheme used for owner references
+func (r *ApplicationController) scheme() *runtime.Scheme {
+	if r.Scheme != nil {
+		return r.Scheme
+	}
+	return r.Client.Scheme()
+}
+
+// setOwner makes the Application the controller of obj. In a target namespace,
+// where owner references cannot point, owner labels are used instead.
+func (r *ApplicationController) setOwner(app *v1alpha1.Application, obj client.Object) error {
+	if obj.GetNamespace() != app.Namespace {
+		setOwnerLabels(app, obj)
+		return nil
+	}
+	return controllerutil.SetControllerReference(app, obj, r.scheme())
+}
+
+// createOwned creates obj with the Application as its controller, so changes to
+// it are watched and it is garbage collected with the Application
+func (r *ApplicationController) createOwned(ctx context.Context, app *v1alpha1.Application, obj client.Object) error {
+	if err := r.setOwner(app, obj); err != nil {
+		return fmt.Errorf("failed to set owner on %s: %w", obj.GetName(), err)
+	}
+	return r.createTracked(ctx, app, obj)
+}
+
+// createTracked creates obj and records it in the Application's inventory. An
+// object that already exists is recorded too; the AlreadyExists error is still
+// returned for the caller to handle.
+func (r *ApplicationController) createTracked(ctx context.Context, app *v1alpha1.Application, obj client.Object) error {
+	err := r.Create(ctx, obj)
+	if err == nil || errors.IsAlreadyExists(err) {
+		app.Status.AddManagedResource(r.managedResourceRef(obj))
+	}
+	return err
+}
+
+// managedResourceRef identifies obj for the status inventory
+func (r *ApplicationController) managedResourceRef(obj client.Object) v1alpha1.ManagedResourceRef {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, r.scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	return v1alpha1.ManagedResourceRef{Kind: kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+}
+
+// ownedBy reports whether obj is controlled by app through its owner reference
+// or owner labels. Unlike managedBy it does not trust the managed-by label
+// alone, which anyone can set: it decides what pruning may delete.
+func ownedBy(app *v1alpha1.Application, obj metav1.Object) bool {
+	if owner, ok := labelOwner(obj); ok {
+		return owner.Name == app.Name && owner.Namespace == app.Namespace
+	}
+	owner := metav1.GetControllerOf(obj)
+	return owner != nil && owner.UID == app.UID
+}
+
+// managedBy reports whether obj belongs to app: controlled by it, or carrying
+// the controller's label from before owner references were set
+func managedBy(app *v1alpha1.Application, obj metav1.Object) bool {
+	if owner, ok := labelOwner(obj); ok {
+		return owner.Name == app.Name && owner.Namespace == app.Namespace
+	}
+	if owner := metav1.GetControllerOf(obj); owner != nil {
+		return owner.UID == app.UID
+	}
+	return obj.GetLabels()["managed-by"] == "orion-platform"
+}
+
+// claimExisting checks an object found under one of the application's names.
+// Objects that already belong to app pass through. Anything else is rejected
+// unless the Application carries the adopt annotation, in which case it is
+// given the controller's labels and owner reference and updated in place.
+func (r *ApplicationController) claimExisting(ctx context.Context, app *v1alpha1.Application, existing client.Object) error {
+	if managedBy(app, existing) {
+		return nil
+	}
+	kind := r.managedResourceRef(existing).Kind
+	if !app.AdoptsExisting() {
+		return fmt.Errorf("%s %s already exists and is not managed by this Application; set the %s=true annotation to adopt it",
+			kind, existing.GetName(), v1alpha1.AdoptAnnotation)
+	}
+
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["app"] = app.Name
+	labels["managed-by"] = "orion-platform"
+	existing.SetLabels(labels)
+	if err := r.setOwner(app, existing); err != nil {
+		return fmt.Errorf("failed to adopt %s %s: %w", kind, existing.GetName(), err)
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to adopt %s %s: %w", kind, existing.GetName(), err)
+	}
+
+	app.Status.AddManagedResource(r.managedResourceRef(existing))
+	r.recordEvent(app, corev1.EventTypeNormal, "Adopted", fmt.Sprintf("Adopted existing %s %s", kind, existing.GetName()))
+	appLogger(ctx, app).Info("Adopted existing resource", "kind", kind, "name", existing.GetName())
+	return nil
+}
+
+// claimByKey fetches the object named by obj's key and claims it
+func (r *ApplicationController) claimByKey(ctx context.Context, app *v1alpha1.Application, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return fmt.Errorf("failed to get %s: %w", obj.GetName(), err)
+	}
+	return r.claimExisting(ctx, app, obj)
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func hasManagedResource(status v1alpha1.ApplicationStatus, kind, name string) bool {
+	for _, ref := range status.ManagedResources {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestManagedResourceInventory(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	r.Config.Prune = true
+
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	for _, kind := range []string{"Deployment", "Service"} {
+		if !hasManagedResource(app.Status, kind, app.GetRedisName()) {
+			t.Errorf("inventory = %+v, want %s/%s", app.Status.ManagedResources, kind, app.GetRedisName())
+		}
+	}
+	// Provisioning again finds the objects and does not list them twice
+	count := len(app.Status.ManagedResources)
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalRedis: %v", err)
+	}
+	if len(app.Status.ManagedResources) != count {
+		t.Errorf("inventory grew from %d to %d entries on a second pass", count, len(app.Status.ManagedResources))
+	}
+
+	app.Spec.Infrastructure.Redis = nil
+	if _, err := r.pruneInfrastructure(testCtx, app); err != nil {
+		t.Fatalf("pruneInfrastructure: %v", err)
+	}
+	for _, kind := range []string{"Deployment", "Service"} {
+		if hasManagedResource(app.Status, kind, app.GetRedisName()) {
+			t.Errorf("pruned %s/%s still in the inventory %+v", kind, app.GetRedisName(), app.Status.ManagedResources)
+		}
+	}
+}
+
+func TestAdoptExistingDeployment(t *testing.T) {
+	for _, adopt := range []bool{false, true} {
+		t.Run(fmt.Sprintf("adopt=%t", adopt), func(t *testing.T) {
+			app := newTestApplication("web")
+			if adopt {
+				app.Annotations = map[string]string{v1alpha1.AdoptAnnotation: "true"}
+			}
+			existing := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "shop"}},
+			}
+			r, recorder := newTestController(t, app, existing)
+
+			err := r.createOrUpdateDeployment(testCtx, app)
+			stored := &appsv1.Deployment{}
+			if getErr := r.Get(testCtx, client.ObjectKeyFromObject(existing), stored); getErr != nil {
+				t.Fatal(getErr)
+			}
+			if !adopt {
+				if err == nil || !strings.Contains(err.Error(), v1alpha1.AdoptAnnotation) {
+					t.Errorf("createOrUpdateDeployment = %v, want a conflict naming the adopt annotation", err)
+				}
+				if metav1.GetControllerOf(stored) != nil {
+					t.Error("unmanaged Deployment given an owner without the adopt annotation")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createOrUpdateDeployment: %v", err)
+			}
+			if owner := metav1.GetControllerOf(stored); owner == nil || owner.UID != app.UID {
+				t.Errorf("owner = %+v, want the Application", owner)
+			}
+			if stored.Labels["managed-by"] != "orion-platform" || stored.Labels["team"] != "shop" {
+				t.Errorf("labels = %v, want the controller's labels added to the user's", stored.Labels)
+			}
+			if !hasEvent(drainEvents(recorder), "Adopted") {
+				t.Error("no Adopted event")
+			}
+			if !hasManagedResource(app.Status, "Deployment", "web") {
+				t.Errorf("inventory = %+v, want the adopted Deployment", app.Status.ManagedResources)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// pkg/controllers/pgbouncer.go
+// PgBouncer connection pooler in front of the local PostgreSQL
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	pgbouncerImage = "bitnami/pgbouncer:1.22.0"
+	// pgbouncerPort is where the image listens; the Service exposes it on 5432
+	// so clients see a regular PostgreSQL endpoint
+	pgbouncerPort = 6432
+)
+
+// provisionPgBouncer runs PgBouncer as <app>-pgbouncer in front of the local
+// PostgreSQL Service and records it as the endpoint applications connect to.
+// An existing Deployment and Service are brought in line with the spec.
+func (r *ApplicationController) provisionPgBouncer(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentPooler)
+	name := app.GetPgBouncerName()
+	labels := map[string]string{"app": app.Name, "component": componentPooler, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentPooler}
+
+	deployment := r.buildPgBouncerDeployment(app)
+	if err := r.createOwned(ctx, app, deployment); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create PgBouncer Deployment: %w", err)
+		}
+		if err := r.updatePgBouncer(ctx, deployment); err != nil {
+			return err
+		}
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       5432,
+					TargetPort: intstr.FromInt32(pgbouncerPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create PgBouncer Service: %w", err)
+		}
+		if err := r.updateInfraServicePorts(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	app.Status.DatabasePoolerEndpoint = fmt.Sprintf("%s:5432", name)
+	logger.Info("PgBouncer created", "endpoint", app.Status.DatabasePoolerEndpoint, "poolSize", app.GetPoolSize())
+	return nil
+}
+
+// buildPgBouncerDeployment builds the <app>-pgbouncer Deployment
+func (r *ApplicationController) buildPgBouncerDeployment(app *v1alpha1.Application) *appsv1.Deployment {
+	pg := app.Spec.Infrastructure.PostgreSQL
+	labels := map[string]string{"app": app.Name, "component": componentPooler, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentPooler}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetPgBouncerName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &[]int32{1}[0],
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(pg.NodeSelector),
+					Tolerations:  infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:  "pgbouncer",
+							Image: app.InfraImage(pgbouncerImage),
+							Env: []corev1.EnvVar{
+								{Name: "POSTGRESQL_HOST", Value: app.GetPostgresName()},
+								{Name: "POSTGRESQL_PORT", Value: "5432"},
+								{Name: "POSTGRESQL_USERNAME", Value: v1alpha1.LocalDatabaseUser},
+								{Name: "POSTGRESQL_PASSWORD", Value: v1alpha1.LocalDatabasePassword},
+								{Name: "POSTGRESQL_DATABASE", Value: app.GetDatabaseName()},
+								{Name: "PGBOUNCER_DATABASE", Value: app.GetDatabaseName()},
+								{Name: "PGBOUNCER_PORT", Value: fmt.Sprintf("%d", pgbouncerPort)},
+								{Name: "PGBOUNCER_POOL_MODE", Value: "transaction"},
+								{Name: "PGBOUNCER_DEFAULT_POOL_SIZE", Value: fmt.Sprintf("%d", app.GetPoolSize())},
+							},
+							Ports: []corev1.ContainerPort{{ContainerPort: pgbouncerPort}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// pgbouncerDrift reports whether the live PgBouncer pod template differs from
+// the spec in the fields the controller sets
+func pgbouncerDrift(live, desired *appsv1.Deployment) bool {
+	l, d := &live.Spec.Template.Spec, &desired.Spec.Template.Spec
+	return l.Containers[0].Image != d.Containers[0].Image ||
+		!equality.Semantic.DeepEqual(l.Containers[0].Env, d.Containers[0].Env) ||
+		!equality.Semantic.DeepEqual(l.NodeSelector, d.NodeSelector) ||
+		!equality.Semantic.DeepEqual(l.Tolerations, d.Tolerations)
+}
+
+// updatePgBouncer brings the live PgBouncer Deployment in line with the spec,
+// such as a changed pool size, rolling its pod
+func (r *ApplicationController) updatePgBouncer(ctx context.Context, desired *appsv1.Deployment) error {
+	existing := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get PgBouncer Deployment: %w", err)
+	}
+	if !pgbouncerDrift(existing, desired) {
+		return nil
+	}
+	l, d := &existing.Spec.Template.Spec, &desired.Spec.Template.Spec
+	l.Containers[0].Image = d.Containers[0].Image
+	l.Containers[0].Env = d.Containers[0].Env
+	l.NodeSelector = d.NodeSelector
+	l.Tolerations = d.Tolerations
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update PgBouncer Deployment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestPgBouncerFollowsSpec(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, Pooler: true, PoolSize: 10}
+	r, _ := newTestController(t, app)
+	if err := r.provisionPgBouncer(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Spec.Infrastructure.PostgreSQL.PoolSize = 40
+	live := &appsv1.Deployment{}
+	key := client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.GetPgBouncerName()}
+	if err := r.Get(testCtx, key, live); err != nil {
+		t.Fatal(err)
+	}
+	if !pgbouncerDrift(live, r.buildPgBouncerDeployment(app)) {
+		t.Fatal("pool size change not detected as drift")
+	}
+
+	if err := r.provisionPgBouncer(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, live); err != nil {
+		t.Fatal(err)
+	}
+	if !hasEnv(live.Spec.Template.Spec.Containers[0].Env, "PGBOUNCER_DEFAULT_POOL_SIZE", "40") {
+		t.Errorf("env = %v, want the new pool size", live.Spec.Template.Spec.Containers[0].Env)
+	}
+	if pgbouncerDrift(live, r.buildPgBouncerDeployment(app)) {
+		t.Error("drift remains after the update")
+	}
+}
@@ -0,0 +1,49 @@
+// pkg/controllers/ports.go
+// Application ports beyond the main one, and their protocols
+
+package controllers
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// mainPortName names the application port once a Service has more than one:
+// "http" for TCP, otherwise the lower-cased protocol
+func mainPortName(app *v1alpha1.Application) string {
+	if app.GetProtocol() == corev1.ProtocolTCP {
+		return "http"
+	}
+	return strings.ToLower(string(app.GetProtocol()))
+}
+
+// additionalContainerPorts renders spec.additionalPorts on the app container
+func additionalContainerPorts(app *v1alpha1.Application) []corev1.ContainerPort {
+	ports := make([]corev1.ContainerPort, 0, len(app.Spec.AdditionalPorts))
+	for _, p := range app.Spec.AdditionalPorts {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: p.Port,
+			Protocol:      p.GetProtocol(),
+		})
+	}
+	return ports
+}
+
+// additionalServicePorts exposes spec.additionalPorts on a Service
+func additionalServicePorts(app *v1alpha1.Application) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0, len(app.Spec.AdditionalPorts))
+	for _, p := range app.Spec.AdditionalPorts {
+		ports = append(ports, corev1.ServicePort{
+			Name:       p.Name,
+			Port:       p.GetServicePort(),
+			TargetPort: intstr.FromString(p.Name),
+			Protocol:   p.GetProtocol(),
+		})
+	}
+	return ports
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestUDPApplicationPorts(t *testing.T) {
+	app := newTestApplication("dns")
+	app.Spec.Image = "coredns/coredns:1.11.1"
+	app.Spec.Port = 53
+	app.Spec.Protocol = string(corev1.ProtocolUDP)
+	app.Spec.HealthCheckPath = "/health"
+	app.Spec.AdditionalPorts = []v1alpha1.AppPort{{Name: "dns-tcp", Port: 53, Protocol: string(corev1.ProtocolTCP), ServicePort: 53}}
+	r, _ := newTestController(t, app)
+
+	container := r.buildAppContainer(app)
+	if container.ReadinessProbe != nil {
+		t.Errorf("readiness probe = %+v on a UDP application port", container.ReadinessProbe)
+	}
+	if container.Ports[0].Protocol != corev1.ProtocolUDP {
+		t.Errorf("main container port protocol = %s, want UDP", container.Ports[0].Protocol)
+	}
+
+	if err := r.createOrUpdateService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	service := &corev1.Service{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: "dns"}, service); err != nil {
+		t.Fatal(err)
+	}
+	protocols := map[corev1.Protocol]bool{}
+	for _, p := range service.Spec.Ports {
+		protocols[p.Protocol] = true
+	}
+	if !protocols[corev1.ProtocolUDP] || !protocols[corev1.ProtocolTCP] {
+		t.Errorf("service ports = %+v, want both UDP and TCP", service.Spec.Ports)
+	}
+}
@@ -0,0 +1,128 @@
+// pkg/controllers/postgres_config.go
+// Tuned server parameters for the local PostgreSQL
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// postgresConfigHash records the applied parameters on the pod template, so
+// the StatefulSet rolls when they change
+const postgresConfigHash = "platform.orion.dev/postgres-config-hash"
+
+// postgresArgs passes each parameter to the server as -c name=value. The
+// image's own postgresql.conf stays in effect for everything else, and the
+// settings only change with the pod template, so a pod restarted outside the
+// maintenance window keeps running the applied ones.
+func postgresArgs(params map[string]string) []string {
+	if len(params) == 0 {
+		return nil
+	}
+	args := []string{"postgres"}
+	for _, key := range sortedKeys(params) {
+		args = append(args, "-c", fmt.Sprintf("%s=%s", key, params[key]))
+	}
+	return args
+}
+
+// postgresConfigChecksum identifies a set of server arguments
+func postgresConfigChecksum(args []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(args, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// applyPostgresConfig passes the tuned parameters to the postgres container
+// and records their checksum on the pod template
+func applyPostgresConfig(sts *appsv1.StatefulSet, params map[string]string) {
+	args := postgresArgs(params)
+	if args == nil {
+		return
+	}
+	sts.Spec.Template.Spec.Containers[0].Args = args
+	sts.Spec.Template.Annotations = mergeAnnotations(sts.Spec.Template.Annotations,
+		map[string]string{postgresConfigHash: postgresConfigChecksum(args)})
+}
+
+// updatePostgresConfig rolls the live database StatefulSet onto the desired
+// parameters when their checksum differs; this restarts the database. The
+// postgresql.conf ConfigMap earlier versions mounted is removed once no
+// template refers to it.
+func (r *ApplicationController) updatePostgresConfig(ctx context.Context, app *v1alpha1.Application, desired *appsv1.StatefulSet) error {
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get PostgreSQL StatefulSet: %w", err)
+	}
+	want := desired.Spec.Template.Annotations[postgresConfigHash]
+	if existing.Spec.Template.Annotations[postgresConfigHash] != want ||
+		!reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Args, desired.Spec.Template.Spec.Containers[0].Args) {
+		existing.Spec.Template.Spec.Volumes = desired.Spec.Template.Spec.Volumes
+		existing.Spec.Template.Spec.Containers[0].VolumeMounts = desired.Spec.Template.Spec.Containers[0].VolumeMounts
+		existing.Spec.Template.Spec.Containers[0].Args = desired.Spec.Template.Spec.Containers[0].Args
+		if want == "" {
+			delete(existing.Spec.Template.Annotations, postgresConfigHash)
+		} else {
+			existing.Spec.Template.Annotations = mergeAnnotations(existing.Spec.Template.Annotations,
+				map[string]string{postgresConfigHash: want})
+		}
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update PostgreSQL StatefulSet: %w", err)
+		}
+	}
+
+	legacy := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: app.GetPostgresConfigName(), Namespace: app.GetTargetNamespace()}}
+	if err := r.Delete(ctx, legacy); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PostgreSQL config ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// updatePostgresStrategy switches the live database StatefulSet to the desired
+// update strategy. Changing the strategy alone restarts nothing.
+func (r *ApplicationController) updatePostgresStrategy(ctx context.Context, desired *appsv1.StatefulSet) error {
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		// Just created with the desired strategy and not in the cache yet
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PostgreSQL StatefulSet: %w", err)
+	}
+	if existing.Spec.UpdateStrategy.Type == desired.Spec.UpdateStrategy.Type {
+		return nil
+	}
+	existing.Spec.UpdateStrategy = desired.Spec.UpdateStrategy
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update PostgreSQL StatefulSet strategy: %w", err)
+	}
+	return nil
+}
+
+// postgresStrategyDrift reports whether the live StatefulSet uses another
+// update strategy than the spec asks for
+func postgresStrategyDrift(app *v1alpha1.Application, live *appsv1.StatefulSet) bool {
+	return live.Spec.UpdateStrategy.Type != app.GetDatabaseUpdateStrategy()
+}
+
+// postgresConfigDrift reports whether the live StatefulSet runs with other
+// parameters than the spec asks for
+func postgresConfigDrift(app *v1alpha1.Application, live *appsv1.StatefulSet) bool {
+	want := ""
+	if args := postgresArgs(app.Spec.Infrastructure.PostgreSQL.Parameters); args != nil {
+		want = postgresConfigChecksum(args)
+	}
+	return live.Spec.Template.Annotations[postgresConfigHash] != want
+}
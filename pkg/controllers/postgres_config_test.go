@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestPostgresParametersAsServerArgs(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{
+		Environment: v1alpha1.EnvironmentLocal,
+		Version:     "15",
+		Parameters:  map[string]string{"work_mem": "64MB", "max_connections": "200"},
+	}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	postgres := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}, postgres); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"postgres", "-c", "max_connections=200", "-c", "work_mem=64MB"}
+	if args := postgres.Spec.Template.Spec.Containers[0].Args; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if postgresConfigDrift(app, postgres) {
+		t.Error("freshly provisioned StatefulSet reported parameter drift")
+	}
+
+	app.Spec.Infrastructure.PostgreSQL.Parameters["work_mem"] = "128MB"
+	if !postgresConfigDrift(app, postgres) {
+		t.Error("changed parameter not reported as drift")
+	}
+}
+
+func TestPostgresParametersAllowlist(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{
+		Parameters: map[string]string{"data_directory": "/tmp"},
+	}
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("disallowed parameter accepted")
+	}
+}
+
+func TestPostgresUpdateStrategy(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	key := client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}
+	strategy := func() appsv1.StatefulSetUpdateStrategyType {
+		t.Helper()
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(testCtx, key, sts); err != nil {
+			t.Fatal(err)
+		}
+		return sts.Spec.UpdateStrategy.Type
+	}
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	if got := strategy(); got != appsv1.RollingUpdateStatefulSetStrategyType {
+		t.Errorf("update strategy = %s, want RollingUpdate by default", got)
+	}
+
+	// Switching to OnDelete reaches the existing StatefulSet
+	app.Spec.Infrastructure.PostgreSQL.UpdateStrategy = string(appsv1.OnDeleteStatefulSetStrategyType)
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	if got := strategy(); got != appsv1.OnDeleteStatefulSetStrategyType {
+		t.Errorf("update strategy = %s, want OnDelete", got)
+	}
+
+	app.Spec.Infrastructure.PostgreSQL.UpdateStrategy = "Recreate"
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("unsupported update strategy accepted")
+	}
+}
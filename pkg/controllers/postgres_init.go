@@ -0,0 +1,86 @@
+// pkg/controllers/postgres_init.go
+// Initialization SQL for the local PostgreSQL
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	// postgresInitDir is where the postgres image looks for first-boot scripts.
+	// They only run while the data directory is empty, so re-reconciling never
+	// re-applies them.
+	postgresInitDir = "/docker-entrypoint-initdb.d"
+	postgresInitKey = "init.sql"
+)
+
+// ensurePostgresInitConfigMap returns the ConfigMap holding the initialization
+// SQL, creating or refreshing the generated one for inline initSQL. It returns
+// "" when no initialization SQL is configured.
+func (r *ApplicationController) ensurePostgresInitConfigMap(ctx context.Context, app *v1alpha1.Application) (string, error) {
+	pg := app.Spec.Infrastructure.PostgreSQL
+	if pg.InitSQLConfigMap != "" {
+		return pg.InitSQLConfigMap, nil
+	}
+	if pg.InitSQL == "" {
+		return "", nil
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetPostgresInitName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "component": "database", "managed-by": "orion-platform"},
+		},
+		Data: map[string]string{postgresInitKey: pg.InitSQL},
+	}
+
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create PostgreSQL init ConfigMap: %w", err)
+		}
+		// Keep the script current for the next fresh data directory
+		existing := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+			return "", fmt.Errorf("failed to get PostgreSQL init ConfigMap: %w", err)
+		}
+		if err := r.claimExisting(ctx, app, existing); err != nil {
+			return "", err
+		}
+		if existing.Data[postgresInitKey] != pg.InitSQL {
+			existing.Data = desired.Data
+			if err := r.Update(ctx, existing); err != nil {
+				return "", fmt.Errorf("failed to update PostgreSQL init ConfigMap: %w", err)
+			}
+		}
+	}
+
+	return desired.Name, nil
+}
+
+// postgresInitVolume mounts the init ConfigMap read-only at postgresInitDir
+func postgresInitVolume(configMapName string) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: "postgres-init",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	}
+	mount := corev1.VolumeMount{
+		Name:      "postgres-init",
+		MountPath: postgresInitDir,
+		ReadOnly:  true,
+	}
+	return volume, mount
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestPostgresInitSQLMountedAndOwned(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{
+		Environment: v1alpha1.EnvironmentLocal,
+		Version:     "15",
+		InitSQL:     "CREATE TABLE orders (id serial primary key);",
+	}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetPostgresInitName(), Namespace: "default"}, cm); err != nil {
+		t.Fatalf("init ConfigMap not created: %v", err)
+	}
+	if cm.Data[postgresInitKey] != app.Spec.Infrastructure.PostgreSQL.InitSQL {
+		t.Errorf("init ConfigMap data = %v", cm.Data)
+	}
+	if owner := metav1.GetControllerOf(cm); owner == nil || owner.UID != app.UID {
+		t.Errorf("init ConfigMap owner = %v, want the Application", owner)
+	}
+
+	postgres := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}, postgres); err != nil {
+		t.Fatal(err)
+	}
+	mounted := false
+	for _, m := range postgres.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || m.MountPath == postgresInitDir
+	}
+	if !mounted {
+		t.Errorf("init ConfigMap not mounted at %s", postgresInitDir)
+	}
+}
+
+func TestPostgresInitRefusesUnmanagedConfigMap(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, InitSQL: "SELECT 1;"}
+	foreign := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: app.GetPostgresInitName(), Namespace: "default"}}
+	r, _ := newTestController(t, app, foreign)
+
+	if _, err := r.ensurePostgresInitConfigMap(testCtx, app); err == nil {
+		t.Error("init SQL written into a ConfigMap the Application does not manage")
+	}
+}
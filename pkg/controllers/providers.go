@@ -0,0 +1,86 @@
+// pkg/controllers/providers.go
+// Controller-wide restrictions on which infrastructure may be provisioned
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// infraProviders describes each infrastructure component by its spec field name,
+// the name --disabled-components takes
+var infraProviders = []struct {
+	name   string
+	needed func(*v1alpha1.Application) bool
+	local  func(*v1alpha1.Application) bool
+}{
+	{"postgresql", (*v1alpha1.Application).NeedsDatabase, (*v1alpha1.Application).IsLocalDatabase},
+	{"redis", (*v1alpha1.Application).NeedsCache, (*v1alpha1.Application).IsLocalRedis},
+	{"s3", (*v1alpha1.Application).NeedsStorage, (*v1alpha1.Application).IsLocalS3},
+	{"dynamodb", (*v1alpha1.Application).NeedsDynamoDB, (*v1alpha1.Application).IsLocalDynamoDB},
+	{"sqs", (*v1alpha1.Application).NeedsQueue, (*v1alpha1.Application).IsLocalSQS},
+	{"kafka", (*v1alpha1.Application).NeedsStreaming, (*v1alpha1.Application).IsLocalKafka},
+}
+
+// ValidateDisabledComponents checks --disabled-components against the
+// component names of the infrastructure spec
+func ValidateDisabledComponents(names []string) error {
+	known := map[string]bool{}
+	list := make([]string, 0, len(infraProviders))
+	for _, p := range infraProviders {
+		known[p.name] = true
+		list = append(list, p.name)
+	}
+	for _, name := range names {
+		if !known[name] {
+			return fmt.Errorf("unknown component %q (%s)", name, strings.Join(list, ", "))
+		}
+	}
+	return nil
+}
+
+// providerDisabledError rejects infrastructure the controller is configured not
+// to provision; retrying cannot help until the spec or the flags change
+type providerDisabledError struct {
+	reasons []string
+}
+
+func (e *providerDisabledError) Error() string {
+	return fmt.Sprintf("infrastructure disabled on this controller: %s", strings.Join(e.reasons, "; "))
+}
+
+// asProviderDisabled returns the providerDisabledError wrapped in err, if any
+func asProviderDisabled(err error) (*providerDisabledError, bool) {
+	var disabled *providerDisabledError
+	ok := errors.As(err, &disabled)
+	return disabled, ok
+}
+
+// checkProviders rejects components listed in --disabled-components and, with
+// --disable-local-provisioning, components that would run in the cluster
+func (r *ApplicationController) checkProviders(app *v1alpha1.Application) error {
+	disabled := map[string]bool{}
+	for _, name := range r.Config.DisabledComponents {
+		disabled[name] = true
+	}
+	var reasons []string
+	for _, p := range infraProviders {
+		if !p.needed(app) {
+			continue
+		}
+		switch {
+		case disabled[p.name]:
+			reasons = append(reasons, fmt.Sprintf("%s is disabled", p.name))
+		case r.Config.DisableLocalProvisioning && p.local(app):
+			reasons = append(reasons, fmt.Sprintf("%s cannot be provisioned locally", p.name))
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return &providerDisabledError{reasons: reasons}
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestValidateDisabledComponents(t *testing.T) {
+	if err := ValidateDisabledComponents([]string{"s3", "redis"}); err != nil {
+		t.Errorf("known components rejected: %v", err)
+	}
+	if err := ValidateDisabledComponents([]string{"minio"}); err == nil {
+		t.Error("unknown component accepted")
+	}
+}
+
+func TestCheckProviders(t *testing.T) {
+	tests := []struct {
+		name        string
+		disabled    []string
+		noLocal     bool
+		environment v1alpha1.Environment
+		wantErr     bool
+	}{
+		{"allowed", []string{"s3"}, false, v1alpha1.EnvironmentLocal, false},
+		{"component disabled", []string{"redis"}, false, v1alpha1.EnvironmentAWS, true},
+		{"local provisioning disabled", nil, true, v1alpha1.EnvironmentLocal, true},
+		{"AWS with local provisioning disabled", nil, true, v1alpha1.EnvironmentAWS, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: tt.environment}
+			r, _ := newTestController(t, app)
+			r.Config.DisabledComponents = tt.disabled
+			r.Config.DisableLocalProvisioning = tt.noLocal
+
+			err := r.checkProviders(app)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkProviders = %v, want error %t", err, tt.wantErr)
+			}
+			if _, ok := asProviderDisabled(err); tt.wantErr && !ok {
+				t.Errorf("error %v is not a providerDisabledError", err)
+			}
+		})
+	}
+}
+
+func TestDisabledComponentFailsApplication(t *testing.T) {
+	for _, disabled := range []string{"redis", "s3"} {
+		t.Run(disabled, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+			r, recorder := newTestController(t, app)
+			r.Config.DisabledComponents = []string{disabled}
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+
+			if _, err := r.Reconcile(testCtx, req); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			stored := &v1alpha1.Application{}
+			if err := r.Get(testCtx, req.NamespacedName, stored); err != nil {
+				t.Fatal(err)
+			}
+			redisErr := r.Get(testCtx, client.ObjectKey{Name: app.GetRedisName(), Namespace: "default"}, &appsv1.Deployment{})
+			events := drainEvents(recorder)
+
+			if disabled == "redis" {
+				if stored.Status.Phase != v1alpha1.PhaseFailed {
+					t.Errorf("phase = %s, want Failed", stored.Status.Phase)
+				}
+				if !hasEvent(events, "InfrastructureDisabled") {
+					t.Errorf("events = %v, want InfrastructureDisabled", events)
+				}
+				if !apierrors.IsNotFound(redisErr) {
+					t.Errorf("Redis provisioned although disabled: %v", redisErr)
+				}
+				return
+			}
+			if stored.Status.Phase == v1alpha1.PhaseFailed {
+				t.Errorf("phase = Failed (%s) with only s3 disabled", stored.Status.Message)
+			}
+			if redisErr != nil {
+				t.Errorf("Redis not provisioned: %v", redisErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// pkg/controllers/prune.go
+// Deletes infrastructure whose spec was removed from the Application
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// desiredComponents returns which locally provisioned infrastructure components
+// the Application's spec still asks for
+func desiredComponents(app *v1alpha1.Application) map[string]bool {
+	return map[string]bool{
+		componentDatabase:     app.NeedsDatabase() && app.IsLocalDatabase(),
+		componentDatabaseRead: app.NeedsDatabase() && app.IsLocalDatabase() && app.GetDatabaseReadReplicas() > 0,
+		componentCache:        app.NeedsCache() && app.IsLocalRedis(),
+		componentStorage:      app.NeedsStorage() && app.IsLocalS3(),
+		componentNoSQL:        app.NeedsDynamoDB() && app.IsLocalDynamoDB(),
+		componentQueue:        app.NeedsQueue() && app.IsLocalSQS(),
+		componentStreaming:    app.NeedsStreaming() && app.IsLocalKafka(),
+		componentPooler:       app.NeedsDatabase() && app.IsLocalDatabase() && app.UsesPooler(),
+		componentDevTools: app.Spec.Infrastructure.DevTools &&
+			((app.NeedsDatabase() && app.IsLocalDatabase()) || (app.NeedsCache() && app.IsLocalRedis())),
+	}
+}
+
+// pruneInfrastructure deletes managed infrastructure resources belonging to
+// components that are no longer in the spec (or no longer local). PVCs are
+// never pruned so removing a component cannot destroy its data. It returns the
+// deleted resources as "Kind/name".
+func (r *ApplicationController) pruneInfrastructure(ctx context.Context, app *v1alpha1.Application) ([]string, error) {
+	if !r.Config.Prune {
+		return nil, nil
+	}
+	logger := appLogger(ctx, app)
+	desired := desiredComponents(app)
+
+	lists := []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"Deployment", &appsv1.DeploymentList{}},
+		{"StatefulSet", &appsv1.StatefulSetList{}},
+		{"Job", &batchv1.JobList{}},
+		{"Service", &corev1.ServiceList{}},
+		{"ConfigMap", &corev1.ConfigMapList{}},
+		{"Secret", &corev1.SecretList{}},
+	}
+
+	var pruned []string
+	for _, l := range lists {
+		if err := r.List(ctx, l.list, client.InNamespace(app.GetTargetNamespace()),
+			client.MatchingLabels{"app": app.Name, "managed-by": "orion-platform"}); err != nil {
+			return pruned, fmt.Errorf("failed to list %ss for pruning: %w", l.kind, err)
+		}
+		items, err := meta.ExtractList(l.list)
+		if err != nil {
+			return pruned, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			component := obj.GetLabels()["component"]
+			wanted, known := desired[component]
+			if !known || wanted || !ownedBy(app, obj) {
+				continue
+			}
+			if err := r.Delete(ctx, obj, client.PropagationPolicy("Background")); err != nil && !errors.IsNotFound(err) {
+				return pruned, fmt.Errorf("failed to prune %s/%s: %w", l.kind, obj.GetName(), err)
+			}
+			app.Status.RemoveManagedResource(v1alpha1.ManagedResourceRef{Kind: l.kind, Name: obj.GetName(), Namespace: obj.GetNamespace()})
+			ref := fmt.Sprintf("%s/%s", l.kind, obj.GetName())
+			logger.Info("Pruned infrastructure resource", logKeyComponent, component, "resource", ref)
+			pruned = append(pruned, ref)
+		}
+	}
+
+	if len(pruned) > 0 {
+		r.recordEvent(app, corev1.EventTypeNormal, "Pruned", fmt.Sprintf("Deleted %d resources for removed infrastructure", len(pruned)))
+	}
+	return pruned, nil
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPruneOnlyDeletesOwnedResources(t *testing.T) {
+	app := newTestApplication("web")
+	labels := map[string]string{"app": "web", "component": componentCache, "managed-by": "orion-platform"}
+	owned := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-redis", Namespace: "default", Labels: labels,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "platform.orion.dev/v1alpha1", Kind: "Application", Name: "web", UID: app.UID,
+			Controller: &[]bool{true}[0],
+		}},
+	}}
+	// A user resource that merely carries the controller's labels
+	unowned := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "user-redis", Namespace: "default", Labels: labels}}
+	r, _ := newTestController(t, app, owned, unowned)
+	r.Config.Prune = true
+
+	pruned, err := r.pruneInfrastructure(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 || pruned[0] != "Deployment/web-redis" {
+		t.Errorf("pruned = %v, want only Deployment/web-redis", pruned)
+	}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(unowned), &appsv1.Deployment{}); err != nil {
+		t.Errorf("unowned Deployment was deleted: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+// pkg/controllers/quota.go
+// Caps an Application's consumption of its target namespace with a ResourceQuota
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Container defaults the LimitRange sets under a compute quota. Most pods the
+// controller creates (the infrastructure, setup Jobs) set no resources, and a
+// quota on requests or limits makes the API server reject such pods.
+var (
+	defaultContainerRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+	defaultContainerLimits = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+)
+
+// reconcileResourceQuota keeps the target namespace's ResourceQuota in line
+// with spec.quota, and deletes it once the budget is removed from the spec
+func (r *ApplicationController) reconcileResourceQuota(ctx context.Context, app *v1alpha1.Application) error {
+	if err := r.reconcileLimitRange(ctx, app); err != nil {
+		return err
+	}
+	logger := appLogger(ctx, app)
+	key := client.ObjectKey{Name: app.GetQuotaName(), Namespace: app.GetTargetNamespace()}
+
+	if len(app.Spec.Quota) == 0 {
+		existing := &corev1.ResourceQuota{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !managedBy(app, existing) {
+			return nil
+		}
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete resource quota: %w", err)
+		}
+		app.Status.RemoveManagedResource(r.managedResourceRef(existing))
+		logger.Info("Deleted ResourceQuota no longer in spec")
+		return nil
+	}
+
+	desired := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: corev1.ResourceQuotaSpec{Hard: app.Spec.Quota.DeepCopy()},
+	}
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create resource quota: %w", err)
+		}
+		existing := &corev1.ResourceQuota{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return fmt.Errorf("failed to get resource quota: %w", err)
+		}
+		if err := r.claimExisting(ctx, app, existing); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec.Hard, desired.Spec.Hard) {
+			existing.Spec.Hard = desired.Spec.Hard
+			if err := r.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update resource quota: %w", err)
+			}
+			logger.Info("Updated ResourceQuota", "quota", key.Name)
+		}
+		return nil
+	}
+
+	logger.Info("Created ResourceQuota", "quota", key.Name, "namespace", key.Namespace)
+	return nil
+}
+
+// containerDefaults picks the LimitRange defaults for the compute resources
+// the quota constrains: a default request for requests.* (or the bare name)
+// and a default limit for limits.*. Nothing is set for other resources, so a
+// container with only a large request is not held to a small default limit.
+func containerDefaults(quota corev1.ResourceList) (requests, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if _, ok := quota[name]; ok {
+			requests[name] = defaultContainerRequests[name]
+		}
+		if _, ok := quota["requests."+name]; ok {
+			requests[name] = defaultContainerRequests[name]
+		}
+		if _, ok := quota["limits."+name]; ok {
+			limits[name] = defaultContainerLimits[name]
+		}
+	}
+	return requests, limits
+}
+
+// reconcileLimitRange gives containers without resources the defaults a
+// compute quota requires, and removes the LimitRange when there is none
+func (r *ApplicationController) reconcileLimitRange(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+	key := client.ObjectKey{Name: app.GetLimitRangeName(), Namespace: app.GetTargetNamespace()}
+	requests, limits := containerDefaults(app.Spec.Quota)
+
+	if len(requests) == 0 && len(limits) == 0 {
+		existing := &corev1.LimitRange{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !managedBy(app, existing) {
+			return nil
+		}
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete limit range: %w", err)
+		}
+		app.Status.RemoveManagedResource(r.managedResourceRef(existing))
+		logger.Info("Deleted LimitRange no longer needed by the quota")
+		return nil
+	}
+
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+	if len(requests) > 0 {
+		item.DefaultRequest = requests
+	}
+	if len(limits) > 0 {
+		item.Default = limits
+	}
+	desired := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+	}
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create limit range: %w", err)
+		}
+		existing := &corev1.LimitRange{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return fmt.Errorf("failed to get limit range: %w", err)
+		}
+		if err := r.claimExisting(ctx, app, existing); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+			existing.Spec = desired.Spec
+			if err := r.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update limit range: %w", err)
+			}
+			logger.Info("Updated LimitRange", "limitRange", key.Name)
+		}
+		return nil
+	}
+
+	logger.Info("Created LimitRange", "limitRange", key.Name, "namespace", key.Namespace)
+	return nil
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestContainerDefaults(t *testing.T) {
+	requests, limits := containerDefaults(corev1.ResourceList{
+		"requests.cpu":  resource.MustParse("2"),
+		"limits.memory": resource.MustParse("4Gi"),
+		"pods":          resource.MustParse("10"),
+	})
+	if _, ok := requests[corev1.ResourceCPU]; !ok || len(requests) != 1 {
+		t.Errorf("requests = %v, want only cpu", requests)
+	}
+	if _, ok := limits[corev1.ResourceMemory]; !ok || len(limits) != 1 {
+		t.Errorf("limits = %v, want only memory", limits)
+	}
+
+	requests, limits = containerDefaults(corev1.ResourceList{"pods": resource.MustParse("10")})
+	if len(requests) != 0 || len(limits) != 0 {
+		t.Errorf("non-compute quota got defaults %v / %v", requests, limits)
+	}
+}
+
+func TestReconcileResourceQuota(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.TargetNamespace = "shop"
+	app.Spec.Quota = corev1.ResourceList{
+		"requests.cpu":      resource.MustParse("2"),
+		"limits.memory":     resource.MustParse("4Gi"),
+		corev1.ResourcePods: resource.MustParse("10"),
+	}
+	r, _ := newTestController(t, app)
+
+	if err := r.reconcileResourceQuota(testCtx, app); err != nil {
+		t.Fatalf("reconcileResourceQuota: %v", err)
+	}
+	quota := &corev1.ResourceQuota{}
+	key := client.ObjectKey{Name: app.GetQuotaName(), Namespace: "shop"}
+	if err := r.Get(testCtx, key, quota); err != nil {
+		t.Fatalf("ResourceQuota not created: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(quota.Spec.Hard, app.Spec.Quota) {
+		t.Errorf("quota hard = %v, want %v", quota.Spec.Hard, app.Spec.Quota)
+	}
+	if !ownedBy(app, quota) {
+		t.Errorf("quota labels %v do not name the Application as its owner", quota.Labels)
+	}
+
+	// A changed budget is applied to the existing quota
+	app.Spec.Quota = corev1.ResourceList{corev1.ResourcePods: resource.MustParse("20")}
+	if err := r.reconcileResourceQuota(testCtx, app); err != nil {
+		t.Fatalf("reconcileResourceQuota: %v", err)
+	}
+	if err := r.Get(testCtx, key, quota); err != nil {
+		t.Fatalf("get ResourceQuota: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(quota.Spec.Hard, app.Spec.Quota) {
+		t.Errorf("quota hard after update = %v, want %v", quota.Spec.Hard, app.Spec.Quota)
+	}
+
+	// Removing the budget deletes the quota
+	app.Spec.Quota = nil
+	if err := r.reconcileResourceQuota(testCtx, app); err != nil {
+		t.Fatalf("reconcileResourceQuota: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.ResourceQuota{}); !errors.IsNotFound(err) {
+		t.Errorf("ResourceQuota still present: %v", err)
+	}
+}
+
+func TestReconcileResourceQuotaAddsLimitRange(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.TargetNamespace = "shop"
+	app.Spec.Quota = corev1.ResourceList{"limits.cpu": resource.MustParse("4")}
+	r, _ := newTestController(t, app)
+
+	if err := r.reconcileResourceQuota(testCtx, app); err != nil {
+		t.Fatalf("reconcileResourceQuota: %v", err)
+	}
+	limitRange := &corev1.LimitRange{}
+	key := client.ObjectKey{Name: app.GetLimitRangeName(), Namespace: "shop"}
+	if err := r.Get(testCtx, key, limitRange); err != nil {
+		t.Fatalf("LimitRange not created: %v", err)
+	}
+	item := limitRange.Spec.Limits[0]
+	if item.Default.Cpu().String() != "1" || item.DefaultRequest != nil {
+		t.Errorf("LimitRange item = %+v, want a cpu default limit only", item)
+	}
+
+	// Dropping the compute keys removes the LimitRange
+	app.Spec.Quota = corev1.ResourceList{"pods": resource.MustParse("10")}
+	if err := r.reconcileResourceQuota(testCtx, app); err != nil {
+		t.Fatalf("reconcileResourceQuota: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.LimitRange{}); !errors.IsNotFound(err) {
+		t.Errorf("LimitRange still present: %v", err)
+	}
+}
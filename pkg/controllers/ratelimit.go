@@ -0,0 +1,70 @@
+// pkg/controllers/ratelimit.go
+// Per-Application reconcile cooldown
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// minIntervalRateLimiter spaces out rate-limited requeues of the same item:
+// those after a reconcile error or a Result with Requeue set. Watch events and
+// RequeueAfter bypass the rate limiter and are not throttled by it. The
+// cooldown is dropped when the item is forgotten after a successful reconcile.
+type minIntervalRateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[interface{}]time.Time
+}
+
+func newMinIntervalRateLimiter(interval time.Duration) *minIntervalRateLimiter {
+	return &minIntervalRateLimiter{
+		interval: interval,
+		next:     map[interface{}]time.Time{},
+	}
+}
+
+// When returns how long the item must wait before its next reconcile
+func (l *minIntervalRateLimiter) When(item interface{}) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var delay time.Duration
+	if next, ok := l.next[item]; ok && next.After(now) {
+		delay = next.Sub(now)
+	}
+	l.next[item] = now.Add(delay + l.interval)
+	return delay
+}
+
+// Forget drops the item's cooldown; the workqueue forgets an item once it
+// reconciles without a rate-limited requeue, so deleted Applications do not
+// stay in the map
+func (l *minIntervalRateLimiter) Forget(item interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.next, item)
+}
+
+// NumRequeues is tracked by the failure rate limiter this is combined with
+func (l *minIntervalRateLimiter) NumRequeues(item interface{}) int {
+	return 0
+}
+
+// newReconcileRateLimiter combines the default controller backoff with the
+// per-Application cooldown
+func newReconcileRateLimiter(interval time.Duration) workqueue.RateLimiter {
+	if interval <= 0 {
+		return workqueue.DefaultControllerRateLimiter()
+	}
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.DefaultControllerRateLimiter(),
+		newMinIntervalRateLimiter(interval),
+	)
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinIntervalRateLimiter(t *testing.T) {
+	l := newMinIntervalRateLimiter(time.Minute)
+
+	if delay := l.When("a"); delay != 0 {
+		t.Errorf("first When = %v, want 0", delay)
+	}
+	if delay := l.When("a"); delay < 59*time.Second {
+		t.Errorf("second When = %v, want about the interval", delay)
+	}
+	if delay := l.When("b"); delay != 0 {
+		t.Errorf("When for another item = %v, want 0", delay)
+	}
+
+	// Forget drops the entry even while the cooldown is running
+	l.Forget("a")
+	l.Forget("b")
+	if len(l.next) != 0 {
+		t.Errorf("entries after Forget = %v, want none", l.next)
+	}
+	if delay := l.When("a"); delay != 0 {
+		t.Errorf("When after Forget = %v, want 0", delay)
+	}
+}
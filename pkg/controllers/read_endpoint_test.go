@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestLocalReadEndpointOnlyWithReadReplicas(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	app.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+	app.Status.DatabaseEndpoint = app.GetPostgresName() + ":5432"
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionPostgresReadService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status.DatabaseReadEndpoint != "" {
+		t.Errorf("read endpoint = %q without read replicas", app.Status.DatabaseReadEndpoint)
+	}
+	for _, env := range connectionEnvVars(app.GetConnectionInfo()) {
+		if env.Name == "DATABASE_READ_URL" {
+			t.Error("DATABASE_READ_URL set for a single-instance database")
+		}
+	}
+
+	app.Spec.Infrastructure.PostgreSQL.ReadReplicas = 1
+	if err := r.provisionPostgresReadService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if want := app.GetPostgresReadName() + ":5432"; app.Status.DatabaseReadEndpoint != want {
+		t.Errorf("read endpoint = %q, want %q", app.Status.DatabaseReadEndpoint, want)
+	}
+	service := &corev1.Service{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.GetPostgresReadName()}, service); err != nil {
+		t.Fatalf("read Service not created: %v", err)
+	}
+	if service.Spec.Selector["component"] != componentDatabase {
+		t.Errorf("read Service selector = %v, want the database pods", service.Spec.Selector)
+	}
+	found := false
+	for _, env := range connectionEnvVars(app.GetConnectionInfo()) {
+		found = found || env.Name == "DATABASE_READ_URL"
+	}
+	if !found {
+		t.Error("DATABASE_READ_URL missing with a read endpoint")
+	}
+	if !desiredComponents(app)[componentDatabaseRead] {
+		t.Error("read Service would be pruned while read replicas are requested")
+	}
+}
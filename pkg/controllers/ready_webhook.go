@@ -0,0 +1,150 @@
+// pkg/controllers/ready_webhook.go
+// Notifies an external endpoint when an Application becomes Ready or Failed
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// readyWebhookTimeout bounds each notification, including with an injected client
+const readyWebhookTimeout = 10 * time.Second
+
+// readyWebhookPayload is the JSON body POSTed to spec.readyWebhook.url
+type readyWebhookPayload struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Phase     string            `json:"phase"`
+	Message   string            `json:"message,omitempty"`
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+}
+
+// notifiedPhases are the phases whose arrival is reported to the webhook
+var notifiedPhases = map[v1alpha1.ApplicationPhase]bool{
+	v1alpha1.PhaseReady:               true,
+	v1alpha1.PhaseInfrastructureReady: true,
+	v1alpha1.PhaseFailed:              true,
+}
+
+// webhookClient returns the HTTP client used for notifications
+func (r *ApplicationController) webhookClient() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	return &http.Client{Timeout: readyWebhookTimeout}
+}
+
+// notifyPhaseTransition POSTs to the ready webhook when the reconcile moved the
+// stored phase from previous into a notified phase. Staying in a phase, or a
+// transition whose status write failed, sends nothing, so periodic reconciles
+// of a Ready Application stay quiet and a retried transition notifies once.
+func (r *ApplicationController) notifyPhaseTransition(ctx context.Context, app *v1alpha1.Application, previous, persisted v1alpha1.ApplicationPhase) {
+	hook := app.Spec.ReadyWebhook
+	if hook == nil || persisted == previous || !notifiedPhases[persisted] {
+		return
+	}
+	logger := appLogger(ctx, app)
+
+	payload := readyWebhookPayload{
+		Name:      app.Name,
+		Namespace: app.Namespace,
+		Phase:     string(persisted),
+		Message:   app.Status.Message,
+		Endpoints: statusEndpoints(app.Status),
+	}
+	if !app.Spec.InfrastructureOnly {
+		payload.Endpoints["serviceEndpoint"] = fmt.Sprintf("%s.%s.svc:80", app.Name, app.GetTargetNamespace())
+	}
+	if err := r.postWebhook(ctx, app, hook, payload); err != nil {
+		logger.Error(err, "Ready webhook failed", "phase", persisted)
+		r.recordEvent(app, corev1.EventTypeWarning, "WebhookFailed", err.Error())
+		return
+	}
+	logger.Info("Sent ready webhook", "phase", persisted)
+}
+
+// webhookHostAllowed reports whether --webhook-allowed-hosts lets
+// notifications reach host, keeping Application authors from making the
+// controller call arbitrary in-cluster or metadata endpoints
+func (r *ApplicationController) webhookHostAllowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range r.Config.WebhookAllowedHosts {
+		allowed = strings.ToLower(strings.TrimPrefix(allowed, "."))
+		if allowed == "*" || host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookHeaders returns the inline headers with those from the headers
+// Secret on top
+func (r *ApplicationController) webhookHeaders(ctx context.Context, app *v1alpha1.Application, hook *v1alpha1.WebhookNotification) (map[string]string, error) {
+	headers := map[string]string{}
+	for name, value := range hook.Headers {
+		headers[name] = value
+	}
+	if hook.HeadersSecretName == "" {
+		return headers, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: hook.HeadersSecretName, Namespace: app.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to read webhook headers secret %s: %w", hook.HeadersSecretName, err)
+	}
+	for name, value := range secret.Data {
+		headers[name] = string(value)
+	}
+	return headers, nil
+}
+
+// postWebhook sends payload as JSON and treats any non-2xx reply as a failure
+func (r *ApplicationController) postWebhook(ctx context.Context, app *v1alpha1.Application, hook *v1alpha1.WebhookNotification, payload readyWebhookPayload) error {
+	target, err := url.Parse(hook.URL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if !r.webhookHostAllowed(target.Hostname()) {
+		return fmt.Errorf("webhook host %s is not in --webhook-allowed-hosts", target.Hostname())
+	}
+	headers, err := r.webhookHeaders(ctx, app, hook)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, readyWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := r.webhookClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
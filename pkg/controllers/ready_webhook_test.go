@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// webhookRecorder is a fake endpoint keeping the requests it received
+type webhookRecorder struct {
+	mu       sync.Mutex
+	payloads []readyWebhookPayload
+	headers  []http.Header
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var payload readyWebhookPayload
+	_ = json.NewDecoder(req.Body).Decode(&payload)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.payloads = append(w.payloads, payload)
+	w.headers = append(w.headers, req.Header.Clone())
+}
+
+func (w *webhookRecorder) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.payloads)
+}
+
+func newWebhookTest(t *testing.T, objs ...*corev1.Secret) (*ApplicationController, *v1alpha1.Application, *webhookRecorder) {
+	t.Helper()
+	hook := &webhookRecorder{}
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+
+	app := newTestApplication("shop")
+	app.Spec.ReadyWebhook = &v1alpha1.WebhookNotification{URL: server.URL}
+	r, _ := newTestController(t, app)
+	for _, obj := range objs {
+		if err := r.Create(testCtx, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.HTTPClient = server.Client()
+	r.Config.WebhookAllowedHosts = []string{"127.0.0.1"}
+	return r, app, hook
+}
+
+func TestReadyWebhookOncePerTransition(t *testing.T) {
+	r, app, hook := newWebhookTest(t)
+
+	app.Status.Phase = v1alpha1.PhaseReady
+	r.notifyPhaseTransition(testCtx, app, v1alpha1.PhaseDeploying, v1alpha1.PhaseReady)
+	// The next periodic reconcile starts and ends Ready
+	r.notifyPhaseTransition(testCtx, app, v1alpha1.PhaseReady, v1alpha1.PhaseReady)
+
+	if hook.count() != 1 || hook.payloads[0].Phase != string(v1alpha1.PhaseReady) {
+		t.Errorf("payloads = %+v, want one Ready notification", hook.payloads)
+	}
+}
+
+func TestReadyWebhookNeedsPersistedPhase(t *testing.T) {
+	r, app, hook := newWebhookTest(t)
+
+	// The Application was never stored, so the status write fails
+	ctx, persisted := withPersistedPhase(testCtx, v1alpha1.PhaseDeploying)
+	missing := app.DeepCopy()
+	missing.Name = "missing"
+	missing.Status.Phase = v1alpha1.PhaseReady
+	if err := r.writeStatus(ctx, missing); err == nil {
+		t.Fatal("status write of a missing Application succeeded")
+	}
+	r.notifyPhaseTransition(ctx, missing, v1alpha1.PhaseDeploying, *persisted)
+	if hook.count() != 0 {
+		t.Errorf("notified %d times for an unwritten phase", hook.count())
+	}
+
+	app.Status.Phase = v1alpha1.PhaseReady
+	if err := r.writeStatus(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	r.notifyPhaseTransition(ctx, app, v1alpha1.PhaseDeploying, *persisted)
+	if hook.count() != 1 {
+		t.Errorf("notified %d times after the write, want 1", hook.count())
+	}
+}
+
+func TestReadyWebhookAllowedHosts(t *testing.T) {
+	r, app, hook := newWebhookTest(t)
+	r.Config.WebhookAllowedHosts = []string{"hooks.example.com"}
+
+	r.notifyPhaseTransition(testCtx, app, v1alpha1.PhaseDeploying, v1alpha1.PhaseReady)
+	if hook.count() != 0 {
+		t.Error("webhook sent to a host outside the allowlist")
+	}
+
+	for host, want := range map[string]bool{
+		"hooks.example.com":     true,
+		"ci.hooks.example.com":  true,
+		"evilhooks.example.com": false,
+		"169.254.169.254":       false,
+	} {
+		if got := r.webhookHostAllowed(host); got != want {
+			t.Errorf("webhookHostAllowed(%s) = %t, want %t", host, got, want)
+		}
+	}
+}
+
+func TestReadyWebhookHeadersFromSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hook-auth", Namespace: "default"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer s3cret")},
+	}
+	r, app, hook := newWebhookTest(t, secret)
+	app.Spec.ReadyWebhook.HeadersSecretName = "hook-auth"
+
+	r.notifyPhaseTransition(testCtx, app, v1alpha1.PhaseDeploying, v1alpha1.PhaseReady)
+	if hook.count() != 1 || hook.headers[0].Get("Authorization") != "Bearer s3cret" {
+		t.Errorf("headers = %v, want the Authorization header from the Secret", hook.headers)
+	}
+}
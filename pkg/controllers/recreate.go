@@ -0,0 +1,89 @@
+// pkg/controllers/recreate.go
+// Recreates infrastructure StatefulSets whose immutable fields changed
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// statefulSetImmutableChanges describes the differences between desired and
+// live that the API server refuses to apply in place
+func statefulSetImmutableChanges(desired, live *appsv1.StatefulSet) []string {
+	var changes []string
+	if !equality.Semantic.DeepEqual(desired.Spec.Selector, live.Spec.Selector) {
+		changes = append(changes, "selector")
+	}
+	if desired.Spec.ServiceName != live.Spec.ServiceName {
+		changes = append(changes, fmt.Sprintf("serviceName %q -> %q", live.Spec.ServiceName, desired.Spec.ServiceName))
+	}
+	if p := desired.Spec.PodManagementPolicy; p != "" && p != live.Spec.PodManagementPolicy {
+		changes = append(changes, fmt.Sprintf("podManagementPolicy %s -> %s", live.Spec.PodManagementPolicy, p))
+	}
+	if len(desired.Spec.VolumeClaimTemplates) != len(live.Spec.VolumeClaimTemplates) {
+		return append(changes, "volumeClaimTemplates")
+	}
+	for i, want := range desired.Spec.VolumeClaimTemplates {
+		have := live.Spec.VolumeClaimTemplates[i]
+		switch {
+		case want.Name != have.Name:
+			changes = append(changes, fmt.Sprintf("volumeClaimTemplate %s -> %s", have.Name, want.Name))
+		case !equality.Semantic.DeepEqual(want.Spec.AccessModes, have.Spec.AccessModes):
+			changes = append(changes, fmt.Sprintf("volumeClaimTemplate %s accessModes %v -> %v", want.Name, have.Spec.AccessModes, want.Spec.AccessModes))
+		case !want.Spec.Resources.Requests.Storage().Equal(*have.Spec.Resources.Requests.Storage()):
+			changes = append(changes, fmt.Sprintf("volumeClaimTemplate %s storage %s -> %s", want.Name,
+				have.Spec.Resources.Requests.Storage(), want.Spec.Resources.Requests.Storage()))
+		}
+	}
+	return changes
+}
+
+// createOrRecreateStatefulSet creates an infrastructure StatefulSet. When one
+// exists with different immutable fields it is deleted with orphan propagation,
+// keeping its pods' volumes, and created again on a later reconcile - but only
+// with --allow-recreate; otherwise the change is reported as an error.
+func (r *ApplicationController) createOrRecreateStatefulSet(ctx context.Context, app *v1alpha1.Application, desired *appsv1.StatefulSet) error {
+	err := r.createOwned(ctx, app, desired)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	live := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		return fmt.Errorf("failed to get statefulset %s: %w", desired.Name, err)
+	}
+	if live.DeletionTimestamp != nil {
+		return fmt.Errorf("statefulset %s is still being deleted for recreation", desired.Name)
+	}
+	changes := statefulSetImmutableChanges(desired, live)
+	if len(changes) == 0 {
+		return nil
+	}
+	summary := strings.Join(changes, "; ")
+	if !r.Config.AllowRecreate {
+		return fmt.Errorf("statefulset %s cannot be updated in place (%s); run the controller with --allow-recreate or revert the change", desired.Name, summary)
+	}
+	if !managedBy(app, live) {
+		return fmt.Errorf("statefulset %s is not managed by this Application; refusing to recreate it", desired.Name)
+	}
+
+	// Orphaning leaves the pods and their claims behind; the new StatefulSet
+	// adopts both through its selector and claim names
+	if err := r.Delete(ctx, live, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete statefulset %s for recreation: %w", desired.Name, err)
+	}
+	componentLogger(ctx, app, live.Labels["component"]).Info("Deleted StatefulSet to recreate it", "statefulset", desired.Name, "changes", summary)
+	r.recordEvent(app, corev1.EventTypeNormal, "Recreating", fmt.Sprintf("StatefulSet %s: %s", desired.Name, summary))
+	return fmt.Errorf("statefulset %s is being recreated", desired.Name)
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// newRecreateTestApplication provisions a local PostgreSQL and then changes
+// the immutable serviceName of its StatefulSet, as an older controller would
+// have left it
+func newRecreateTestApplication(t *testing.T) (*ApplicationController, *v1alpha1.Application, func() *appsv1.StatefulSet) {
+	t.Helper()
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	key := client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}
+	get := func() *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(testCtx, key, sts); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			t.Fatal(err)
+		}
+		return sts
+	}
+	sts := get()
+	sts.Spec.ServiceName = "legacy"
+	if err := r.Update(testCtx, sts); err != nil {
+		t.Fatal(err)
+	}
+	return r, app, get
+}
+
+func TestRecreateRejectedByDefault(t *testing.T) {
+	r, app, get := newRecreateTestApplication(t)
+
+	err := r.provisionLocalPostgreSQL(testCtx, app)
+	if err == nil || !strings.Contains(err.Error(), "--allow-recreate") {
+		t.Fatalf("provisionLocalPostgreSQL = %v, want the immutable change rejected", err)
+	}
+	if sts := get(); sts == nil || sts.Spec.ServiceName != "legacy" {
+		t.Errorf("StatefulSet = %+v, want it left in place", sts)
+	}
+}
+
+func TestRecreatePreservesClaim(t *testing.T) {
+	r, app, get := newRecreateTestApplication(t)
+	r.Config.AllowRecreate = true
+	pvcKey := client.ObjectKey{Name: app.GetPostgresClaimName(), Namespace: "default"}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(testCtx, pvcKey, pvc); err != nil {
+		t.Fatalf("get PostgreSQL claim: %v", err)
+	}
+	uid := pvc.UID
+
+	// The first pass orphan-deletes the StatefulSet, the next creates it again
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err == nil || !strings.Contains(err.Error(), "being recreated") {
+		t.Fatalf("provisionLocalPostgreSQL = %v, want the StatefulSet recreated", err)
+	}
+	if sts := get(); sts != nil {
+		t.Fatalf("StatefulSet still present after the orphan delete: %+v", sts.ObjectMeta)
+	}
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	if sts := get(); sts == nil || sts.Spec.ServiceName == "legacy" {
+		t.Errorf("StatefulSet = %+v, want it recreated from the spec", sts)
+	}
+	if err := r.Get(testCtx, pvcKey, pvc); err != nil || pvc.UID != uid {
+		t.Errorf("PostgreSQL claim = %s, %v after recreation, want the original %s kept", pvc.UID, err, uid)
+	}
+}
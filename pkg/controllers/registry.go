@@ -0,0 +1,317 @@
+// pkg/controllers/registry.go
+// Resolves image tags to digests through the registry HTTP API
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// ImageResolver resolves an image reference to its manifest digest
+// (sha256:...). creds is nil for anonymous access.
+type ImageResolver interface {
+	ResolveDigest(ctx context.Context, image string, creds *RegistryCredentials) (string, error)
+}
+
+// RegistryCredentials authenticate digest lookups against a private registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// manifestMediaTypes are accepted so multi-arch images resolve to their index digest
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryResolver talks to registries using the Docker Registry v2 API and
+// its bearer token flow, anonymously unless credentials are given
+type registryResolver struct {
+	client *http.Client
+}
+
+func newRegistryResolver() *registryResolver {
+	return &registryResolver{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// parseImageReference splits an image into registry host, repository and tag,
+// applying Docker Hub defaults
+func parseImageReference(image string) (registry, repository, tag string) {
+	registry = "registry-1.docker.io"
+	name := image
+	if i := strings.Index(image, "/"); i > 0 {
+		host := image[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			registry, name = host, image[i+1:]
+		}
+	}
+	tag = "latest"
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	if registry == "registry-1.docker.io" && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return registry, name, tag
+}
+
+func (c *registryResolver) ResolveDigest(ctx context.Context, image string, creds *RegistryCredentials) (string, error) {
+	registry, repository, tag := parseImageReference(image)
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+
+	resp, err := c.headManifest(ctx, url, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		authorization := ""
+		if scheme, _, _ := strings.Cut(challenge, " "); strings.EqualFold(scheme, "Basic") && creds != nil {
+			authorization = "Basic " + basicAuth(creds)
+		} else {
+			token, err := c.token(ctx, challenge, creds)
+			if err != nil {
+				return "", err
+			}
+			authorization = "Bearer " + token
+		}
+		if resp, err = c.headManifest(ctx, url, authorization); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, image)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry did not return a digest for %s", image)
+	}
+	return digest, nil
+}
+
+func (c *registryResolver) headManifest(ctx context.Context, url, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry: %w", err)
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// basicAuth encodes credentials for an Authorization: Basic header
+func basicAuth(creds *RegistryCredentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+}
+
+// token fetches a pull token from the realm in a Bearer challenge, signing in
+// with creds when given
+func (c *registryResolver) token(ctx context.Context, challenge string, creds *RegistryCredentials) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry auth challenge %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok {
+			values[k] = strings.Trim(v, `"`)
+		}
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("registry auth challenge has no realm")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"], nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	for _, k := range []string{"service", "scope"} {
+		if values[k] != "" {
+			q.Set(k, values[k])
+		}
+	}
+	req.URL.RawQuery = q.Encode()
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// imageResolver returns the configured resolver, falling back to the registry API
+func (r *ApplicationController) imageResolver() ImageResolver {
+	if r.Registry != nil {
+		return r.Registry
+	}
+	return newRegistryResolver()
+}
+
+// resolveImageDigest pins the application image to a digest when the spec asks
+// for it. A resolution already recorded for the current image is reused; a
+// registry error falls back to deploying the tag as written, reported in an
+// event. Private registries are queried with the pull secrets of the service
+// account the pods run as.
+func (r *ApplicationController) resolveImageDigest(ctx context.Context, app *v1alpha1.Application) {
+	if !app.Spec.ResolveDigest || strings.Contains(app.Spec.Image, "@") {
+		app.Status.ResolvedImage = ""
+		app.Status.ResolvedImageSource = ""
+		return
+	}
+	if app.Status.ResolvedImageSource == app.Spec.Image && app.Status.ResolvedImage != "" {
+		return
+	}
+
+	logger := appLogger(ctx, app)
+	digest, err := r.lookupDigest(ctx, app)
+	if err != nil {
+		logger.Error(err, "Failed to resolve image digest, deploying the tag", "image", app.Spec.Image)
+		r.recordEvent(app, corev1.EventTypeWarning, "DigestResolutionFailed",
+			fmt.Sprintf("%s is deployed unpinned: %v", app.Spec.Image, err))
+		app.Status.ResolvedImage = ""
+		app.Status.ResolvedImageSource = ""
+		return
+	}
+
+	app.Status.ResolvedImage = fmt.Sprintf("%s@%s", imageWithoutTag(app.Spec.Image), digest)
+	app.Status.ResolvedImageSource = app.Spec.Image
+	logger.Info("Resolved image digest", "image", app.Spec.Image, "resolved", app.Status.ResolvedImage)
+}
+
+// lookupDigest asks the registry for the digest of the spec image
+func (r *ApplicationController) lookupDigest(ctx context.Context, app *v1alpha1.Application) (string, error) {
+	registry, _, _ := parseImageReference(app.Spec.Image)
+	creds, err := r.pullCredentials(ctx, app.GetTargetNamespace(), registry)
+	if err != nil {
+		return "", err
+	}
+	return r.imageResolver().ResolveDigest(ctx, app.Spec.Image, creds)
+}
+
+// pullCredentials finds credentials for registry in the image pull secrets of
+// the namespace's default service account, which the application pods use.
+// It returns nil when there are none.
+func (r *ApplicationController) pullCredentials(ctx context.Context, namespace, registry string) (*RegistryCredentials, error) {
+	sa := &corev1.ServiceAccount{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "default"}, sa); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get default service account: %w", err)
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get image pull secret %s: %w", ref.Name, err)
+		}
+		if creds := dockerConfigCredentials(secret, registry); creds != nil {
+			return creds, nil
+		}
+	}
+	return nil, nil
+}
+
+// dockerConfigCredentials reads the entry for registry from a
+// kubernetes.io/dockerconfigjson or dockercfg Secret
+func dockerConfigCredentials(secret *corev1.Secret, registry string) *RegistryCredentials {
+	type entry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	var auths map[string]entry
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+		var config struct {
+			Auths map[string]entry `json:"auths"`
+		}
+		if json.Unmarshal(data, &config) != nil {
+			return nil
+		}
+		auths = config.Auths
+	} else if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+		if json.Unmarshal(data, &auths) != nil {
+			return nil
+		}
+	}
+
+	for server, e := range auths {
+		if registryHost(server) != registryHost(registry) {
+			continue
+		}
+		if e.Username == "" && e.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+			if err != nil {
+				continue
+			}
+			e.Username, e.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		return &RegistryCredentials{Username: e.Username, Password: e.Password}
+	}
+	return nil
+}
+
+// registryHost normalizes a docker config server entry or registry host;
+// Docker Hub goes by several names
+func registryHost(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server, _, _ = strings.Cut(server, "/")
+	switch server {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return server
+}
+
+// imageWithoutTag strips the tag, keeping any registry host and port
+func imageWithoutTag(image string) string {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+	return image
+}
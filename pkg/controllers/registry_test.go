@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// fakeResolver answers digest lookups with a fixed digest or error and records
+// the credentials it was given
+type fakeResolver struct {
+	digest string
+	err    error
+	creds  *RegistryCredentials
+}
+
+func (f *fakeResolver) ResolveDigest(ctx context.Context, image string, creds *RegistryCredentials) (string, error) {
+	f.creds = creds
+	return f.digest, f.err
+}
+
+func TestResolveImageDigest(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.ResolveDigest = true
+	r, _ := newTestController(t, app)
+	r.Registry = &fakeResolver{digest: testDigest}
+
+	r.resolveImageDigest(testCtx, app)
+
+	want := "nginx@" + testDigest
+	if app.Status.ResolvedImage != want || app.Status.ResolvedImageSource != "nginx:1.25" {
+		t.Errorf("resolved %q from %q, want %q from nginx:1.25", app.Status.ResolvedImage, app.Status.ResolvedImageSource, want)
+	}
+	if got := app.GetDeployImage(); got != want {
+		t.Errorf("deployed image = %q, want %q", got, want)
+	}
+
+	// A changed tag is not deployed by the stale digest before it is resolved
+	app.Spec.Image = "nginx:1.26"
+	if got := app.GetDeployImage(); got != "nginx:1.26" {
+		t.Errorf("deployed image after a tag change = %q, want nginx:1.26", got)
+	}
+}
+
+func TestResolveImageDigestRegistryError(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.ResolveDigest = true
+	app.Status.ResolvedImage = "nginx@" + testDigest
+	app.Status.ResolvedImageSource = "nginx:1.24"
+	r, recorder := newTestController(t, app)
+	r.Registry = &fakeResolver{err: errors.New("registry unavailable")}
+
+	r.resolveImageDigest(testCtx, app)
+
+	if app.Status.ResolvedImage != "" {
+		t.Errorf("resolved image = %q after a registry error, want none", app.Status.ResolvedImage)
+	}
+	if got := app.GetDeployImage(); got != "nginx:1.25" {
+		t.Errorf("deployed image = %q, want the tag nginx:1.25", got)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "DigestResolutionFailed") {
+		t.Errorf("events = %v, want DigestResolutionFailed", events)
+	}
+}
+
+func TestResolveImageDigestUsesPullSecret(t *testing.T) {
+	config, err := json.Marshal(map[string]any{
+		"auths": map[string]any{"registry.example.com": map[string]string{"username": "ci", "password": "secret"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: config},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	}
+	app := newTestApplication("shop")
+	app.Spec.Image = "registry.example.com/team/shop:v2"
+	app.Spec.ResolveDigest = true
+	r, _ := newTestController(t, app, sa, pullSecret)
+	resolver := &fakeResolver{digest: testDigest}
+	r.Registry = resolver
+
+	r.resolveImageDigest(testCtx, app)
+
+	if resolver.creds == nil || resolver.creds.Username != "ci" || resolver.creds.Password != "secret" {
+		t.Errorf("credentials = %+v, want those of the pull secret", resolver.creds)
+	}
+	if want := "registry.example.com/team/shop@" + testDigest; app.Status.ResolvedImage != want {
+		t.Errorf("resolved image = %q, want %q", app.Status.ResolvedImage, want)
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/controllers/retention.go
+// Deletion policies for data that outlives the Application's workloads
+
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// managesDatabaseClaim reports whether the Application's database may have a
+// local data volume, which it does not own and so has to release on deletion
+func managesDatabaseClaim(app *v1alpha1.Application) bool {
+	return app.NeedsDatabase() && !app.IsExternalDatabase()
+}
+
+// managesBucket reports whether the controller provisions the Application's
+// bucket, and so has to apply its deletion policy when the Application goes
+func managesBucket(app *v1alpha1.Application) bool {
+	return app.NeedsStorage() && !app.IsExternalS3()
+}
+
+// releaseBucket applies the S3 deletion policy: Delete removes the bucket (the
+// MinIO claim for local buckets), Retain leaves it and records where it is.
+// A local bucket without localStorage lived in the MinIO pod and cannot be
+// retained, and without an AWS client an AWS bucket cannot be deleted; both
+// are reported rather than claimed.
+func (r *ApplicationController) releaseBucket(ctx context.Context, app *v1alpha1.Application) error {
+	bucket := app.Status.S3BucketName
+	if !managesBucket(app) || bucket == "" {
+		return nil
+	}
+	logger := appLogger(ctx, app)
+	local := app.Status.S3Environment == v1alpha1.EnvironmentLocal
+
+	if app.GetS3DeletionPolicy() == v1alpha1.DeletionPolicyRetain {
+		if local && app.Spec.Infrastructure.S3.LocalStorage == "" {
+			logger.Info("S3 bucket had no volume and was not retained", "bucket", bucket)
+			r.recordEvent(app, corev1.EventTypeWarning, "BucketNotRetained",
+				fmt.Sprintf("S3 bucket %s was not retained: without s3.localStorage its objects lived in the MinIO pod", bucket))
+			return nil
+		}
+		message := fmt.Sprintf("Retained S3 bucket %s", bucket)
+		if local {
+			message = fmt.Sprintf("Retained S3 bucket %s in PersistentVolumeClaim %s/%s", bucket, app.GetTargetNamespace(), app.GetS3ClaimName())
+		}
+		logger.Info("Retaining S3 bucket", "bucket", bucket, "environment", app.Status.S3Environment)
+		r.recordEvent(app, corev1.EventTypeNormal, "BucketRetained", message)
+		return nil
+	}
+
+	if local {
+		// Without localStorage the objects lived in the MinIO pod and went with it
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: app.GetS3ClaimName(), Namespace: app.GetTargetNamespace()}}
+		if err := r.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete MinIO PVC: %w", err)
+		}
+	} else if err := r.awsClient().DeleteBucket(ctx, bucket); stderrors.Is(err, errAWSSimulated) {
+		logger.Info("S3 bucket not deleted without an AWS client", "bucket", bucket)
+		r.recordEvent(app, corev1.EventTypeWarning, "BucketNotDeleted",
+			fmt.Sprintf("S3 bucket %s was not deleted: %v; delete it by hand", bucket, err))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to delete S3 bucket %s: %w", bucket, err)
+	}
+	logger.Info("Deleted S3 bucket", "bucket", bucket, "environment", app.Status.S3Environment)
+	r.recordEvent(app, corev1.EventTypeNormal, "BucketDeleted", fmt.Sprintf("Deleted S3 bucket %s", bucket))
+	return nil
+}
+
+// releaseDatabaseClaim applies the PostgreSQL deletion policy to the local data
+// volume. Retain leaves the claim for a later Application with the same
+// identity to adopt; Delete removes it, and the data with it.
+func (r *ApplicationController) releaseDatabaseClaim(ctx context.Context, app *v1alpha1.Application) error {
+	if !managesDatabaseClaim(app) || app.Status.DatabaseEnvironment != v1alpha1.EnvironmentLocal {
+		return nil
+	}
+	logger := componentLogger(ctx, app, componentDatabase)
+	claimName, err := r.databaseClaimName(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	if app.GetDatabaseDeletionPolicy() == v1alpha1.DeletionPolicyRetain {
+		logger.Info("Retaining PostgreSQL PVC", "claim", claimName)
+		r.recordEvent(app, corev1.EventTypeNormal, "VolumeRetained",
+			fmt.Sprintf("Retained PostgreSQL PersistentVolumeClaim %s/%s", app.GetTargetNamespace(), claimName))
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: app.GetTargetNamespace()}}
+	if err := r.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PostgreSQL PVC: %w", err)
+	}
+	logger.Info("Deleted PostgreSQL PVC", "claim", claimName)
+	r.recordEvent(app, corev1.EventTypeNormal, "VolumeDeleted",
+		fmt.Sprintf("Deleted PostgreSQL PersistentVolumeClaim %s/%s", app.GetTargetNamespace(), claimName))
+	return nil
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// bucketApp is an Application whose bucket was provisioned in environment
+func bucketApp(environment v1alpha1.Environment, policy string) *v1alpha1.Application {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.S3 = &v1alpha1.S3Spec{Environment: environment, DeletionPolicy: policy}
+	app.Status.S3BucketName = "shop-data"
+	app.Status.S3Environment = environment
+	return app
+}
+
+// hasEvent reports whether an event with the reason was recorded
+func hasEvent(events []string, reason string) bool {
+	for _, e := range events {
+		if strings.Contains(e, " "+reason+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReleaseBucketAWS(t *testing.T) {
+	for _, policy := range []string{v1alpha1.DeletionPolicyDelete, v1alpha1.DeletionPolicyRetain} {
+		t.Run(policy, func(t *testing.T) {
+			app := bucketApp(v1alpha1.EnvironmentAWS, policy)
+			r, recorder := newTestController(t, app)
+			aws := &fakeAWSClient{}
+			r.AWS = aws
+
+			if err := r.releaseBucket(testCtx, app); err != nil {
+				t.Fatalf("releaseBucket: %v", err)
+			}
+			deleted := len(aws.deletedBuckets) == 1
+			if deleted != (policy == v1alpha1.DeletionPolicyDelete) {
+				t.Errorf("deleted buckets = %v with policy %s", aws.deletedBuckets, policy)
+			}
+			want := map[string]string{v1alpha1.DeletionPolicyDelete: "BucketDeleted", v1alpha1.DeletionPolicyRetain: "BucketRetained"}[policy]
+			if events := drainEvents(recorder); !hasEvent(events, want) {
+				t.Errorf("events = %v, want %s", events, want)
+			}
+		})
+	}
+}
+
+func TestReleaseBucketWithoutAWSClient(t *testing.T) {
+	app := bucketApp(v1alpha1.EnvironmentAWS, v1alpha1.DeletionPolicyDelete)
+	r, recorder := newTestController(t, app)
+
+	if err := r.releaseBucket(testCtx, app); err != nil {
+		t.Fatalf("releaseBucket: %v", err)
+	}
+	events := drainEvents(recorder)
+	if hasEvent(events, "BucketDeleted") || !hasEvent(events, "BucketNotDeleted") {
+		t.Errorf("events = %v, want BucketNotDeleted only", events)
+	}
+}
+
+func TestReleaseLocalBucketWithoutVolumeIsNotRetained(t *testing.T) {
+	app := bucketApp(v1alpha1.EnvironmentLocal, v1alpha1.DeletionPolicyRetain)
+	r, recorder := newTestController(t, app)
+
+	if err := r.releaseBucket(testCtx, app); err != nil {
+		t.Fatalf("releaseBucket: %v", err)
+	}
+	events := drainEvents(recorder)
+	if hasEvent(events, "BucketRetained") || !hasEvent(events, "BucketNotRetained") {
+		t.Errorf("events = %v, want BucketNotRetained only", events)
+	}
+}
+
+func TestReleaseDatabaseClaim(t *testing.T) {
+	for _, policy := range []string{v1alpha1.DeletionPolicyDelete, v1alpha1.DeletionPolicyRetain} {
+		t.Run(policy, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, DeletionPolicy: policy}
+			app.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: app.GetPostgresClaimName(), Namespace: "default"}}
+			r, _ := newTestController(t, app, pvc)
+
+			if err := r.releaseDatabaseClaim(testCtx, app); err != nil {
+				t.Fatalf("releaseDatabaseClaim: %v", err)
+			}
+			err := r.Get(testCtx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+			if gone := errors.IsNotFound(err); gone != (policy == v1alpha1.DeletionPolicyDelete) {
+				t.Errorf("claim deleted = %t with policy %s", gone, policy)
+			}
+		})
+	}
+}
+
+func TestBucketDeletionPolicyDefaultsToRetain(t *testing.T) {
+	app := bucketApp(v1alpha1.EnvironmentAWS, "")
+	if got := app.GetS3DeletionPolicy(); got != v1alpha1.DeletionPolicyRetain {
+		t.Errorf("deletion policy = %q, want %s", got, v1alpha1.DeletionPolicyRetain)
+	}
+}
+
+func TestDatabaseClaimOutlivesApplicationUnderRetain(t *testing.T) {
+	for _, policy := range []string{v1alpha1.DeletionPolicyDelete, v1alpha1.DeletionPolicyRetain} {
+		t.Run(policy, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Finalizers = []string{cleanupFinalizer}
+			app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, DeletionPolicy: policy}
+			r, recorder := newTestController(t, app)
+
+			if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+				t.Fatalf("provisionLocalPostgreSQL: %v", err)
+			}
+			key := client.ObjectKey{Name: app.GetPostgresClaimName(), Namespace: "default"}
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := r.Get(testCtx, key, pvc); err != nil {
+				t.Fatalf("get claim: %v", err)
+			}
+			if len(pvc.OwnerReferences) != 0 {
+				t.Fatalf("claim owner references = %v, garbage collection would delete it", pvc.OwnerReferences)
+			}
+
+			if err := r.Delete(testCtx, app); err != nil {
+				t.Fatalf("delete Application: %v", err)
+			}
+			deleting := &v1alpha1.Application{}
+			if err := r.Get(testCtx, client.ObjectKeyFromObject(app), deleting); err != nil {
+				t.Fatalf("get Application: %v", err)
+			}
+			deleting.Status.DatabaseEnvironment = v1alpha1.EnvironmentLocal
+			if err := r.finalizeApplication(testCtx, deleting); err != nil {
+				t.Fatalf("finalizeApplication: %v", err)
+			}
+
+			if err := r.Get(testCtx, client.ObjectKeyFromObject(app), &v1alpha1.Application{}); !errors.IsNotFound(err) {
+				t.Errorf("Application still present after finalizing: %v", err)
+			}
+			err := r.Get(testCtx, key, &corev1.PersistentVolumeClaim{})
+			if gone := errors.IsNotFound(err); gone != (policy == v1alpha1.DeletionPolicyDelete) {
+				t.Errorf("claim deleted = %t with policy %s", gone, policy)
+			}
+			want := map[string]string{v1alpha1.DeletionPolicyDelete: "VolumeDeleted", v1alpha1.DeletionPolicyRetain: "VolumeRetained"}[policy]
+			if events := drainEvents(recorder); !hasEvent(events, want) {
+				t.Errorf("events = %v, want %s", events, want)
+			}
+		})
+	}
+}
+
+func TestDatabaseDeletionPolicyDefaultsToRetain(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	if got := app.GetDatabaseDeletionPolicy(); got != v1alpha1.DeletionPolicyRetain {
+		t.Errorf("deletion policy = %q, want %s", got, v1alpha1.DeletionPolicyRetain)
+	}
+}
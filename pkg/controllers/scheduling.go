@@ -0,0 +1,125 @@
+// pkg/controllers/scheduling.go
+// Placement of the application and locally provisioned infrastructure pods
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// infraNodeSelector merges a component's node selector over the controller-wide
+// default; nil when neither is set
+func (r *ApplicationController) infraNodeSelector(override map[string]string) map[string]string {
+	if len(r.Config.InfraNodeSelector) == 0 && len(override) == 0 {
+		return nil
+	}
+	selector := make(map[string]string, len(r.Config.InfraNodeSelector)+len(override))
+	for k, v := range r.Config.InfraNodeSelector {
+		selector[k] = v
+	}
+	for k, v := range override {
+		selector[k] = v
+	}
+	return selector
+}
+
+// infraTolerations returns the tolerations for locally provisioned
+// infrastructure pods
+func infraTolerations(app *v1alpha1.Application) []corev1.Toleration {
+	if len(app.Spec.Infrastructure.Tolerations) == 0 {
+		return nil
+	}
+	tolerations := make([]corev1.Toleration, len(app.Spec.Infrastructure.Tolerations))
+	for i := range app.Spec.Infrastructure.Tolerations {
+		app.Spec.Infrastructure.Tolerations[i].DeepCopyInto(&tolerations[i])
+	}
+	return tolerations
+}
+
+// appTolerations returns the tolerations for the application pods
+func appTolerations(app *v1alpha1.Application) []corev1.Toleration {
+	if len(app.Spec.Tolerations) == 0 {
+		return nil
+	}
+	tolerations := make([]corev1.Toleration, len(app.Spec.Tolerations))
+	for i := range app.Spec.Tolerations {
+		app.Spec.Tolerations[i].DeepCopyInto(&tolerations[i])
+	}
+	return tolerations
+}
+
+// ParseSpotNodeLabel splits a "key=value" spot node label
+func ParseSpotNodeLabel(label string) (key, value string, err error) {
+	key, value, ok := strings.Cut(label, "=")
+	if !ok || key == "" || value == "" {
+		return "", "", fmt.Errorf("expected key=value, got %q", label)
+	}
+	return key, value, nil
+}
+
+// spotLabel returns the configured spot node label; ok is false when spot
+// scheduling is off
+func (r *ApplicationController) spotLabel() (key, value string, ok bool) {
+	key, value, err := ParseSpotNodeLabel(r.Config.SpotNodeLabel)
+	return key, value, err == nil
+}
+
+// appAffinity steers preemptible application pods towards spot nodes. It is
+// a preference, so the pods still run when no spot capacity is available.
+func (r *ApplicationController) appAffinity(app *v1alpha1.Application) *corev1.Affinity {
+	key, value, ok := r.spotLabel()
+	if !ok || !app.Spec.Preemptible {
+		return nil
+	}
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{
+					Weight: 100,
+					Preference: corev1.NodeSelectorTerm{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: key, Operator: corev1.NodeSelectorOpIn, Values: []string{value}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// infraAffinity keeps stateful infrastructure off spot nodes, where it could
+// be evicted with little notice, unless the component is marked preemptible
+func (r *ApplicationController) infraAffinity(preemptible bool) *corev1.Affinity {
+	key, value, ok := r.spotLabel()
+	if !ok || preemptible {
+		return nil
+	}
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// withSpotToleration adds a toleration for the spot taint, which providers put
+// on spot nodes under the same key as the label, to pods allowed on spot nodes
+func (r *ApplicationController) withSpotToleration(tolerations []corev1.Toleration, preemptible bool) []corev1.Toleration {
+	key, _, ok := r.spotLabel()
+	if !ok || !preemptible {
+		return tolerations
+	}
+	return append(tolerations, corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists})
+}
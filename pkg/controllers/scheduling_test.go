@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const testSpotLabel = "node.example.com/spot=true"
+
+func spotApp(preemptibleS3 bool) *v1alpha1.Application {
+	app := newTestApplication("web")
+	app.Spec.Preemptible = true
+	app.Spec.Infrastructure.S3 = &v1alpha1.S3Spec{Environment: v1alpha1.EnvironmentLocal, Preemptible: preemptibleS3}
+	return app
+}
+
+func TestSpotSchedulingSplitsAppAndInfrastructure(t *testing.T) {
+	app := spotApp(false)
+	r, _ := newTestController(t, app)
+	r.Config.SpotNodeLabel = testSpotLabel
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appAffinity := template.Spec.Affinity
+	if appAffinity == nil || len(appAffinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Fatalf("app affinity = %+v, want a preference for spot nodes", appAffinity)
+	}
+	if !hasSpotToleration(template.Spec.Tolerations) {
+		t.Errorf("app tolerations = %v, want the spot toleration", template.Spec.Tolerations)
+	}
+
+	if err := r.provisionLocalS3(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	minio := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.GetS3Name()}, minio); err != nil {
+		t.Fatal(err)
+	}
+	spec := minio.Spec.Template.Spec
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		t.Fatalf("MinIO affinity = %+v, want spot nodes excluded", spec.Affinity)
+	}
+	requirement := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+	if requirement.Key != "node.example.com/spot" || requirement.Operator != corev1.NodeSelectorOpNotIn {
+		t.Errorf("MinIO node requirement = %+v, want NotIn the spot label", requirement)
+	}
+	if hasSpotToleration(spec.Tolerations) {
+		t.Errorf("MinIO tolerations = %v, want no spot toleration", spec.Tolerations)
+	}
+}
+
+func TestPreemptibleLocalS3(t *testing.T) {
+	app := spotApp(true)
+	r, _ := newTestController(t, app)
+	r.Config.SpotNodeLabel = testSpotLabel
+
+	if err := r.provisionLocalS3(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	minio := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: app.GetS3Name()}, minio); err != nil {
+		t.Fatal(err)
+	}
+	spec := minio.Spec.Template.Spec
+	if spec.Affinity != nil || !hasSpotToleration(spec.Tolerations) {
+		t.Errorf("MinIO affinity = %+v, tolerations = %v; want spot nodes allowed", spec.Affinity, spec.Tolerations)
+	}
+}
+
+func hasSpotToleration(tolerations []corev1.Toleration) bool {
+	for _, toleration := range tolerations {
+		if toleration.Key == "node.example.com/spot" && toleration.Operator == corev1.TolerationOpExists {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTopologySpreadConstraints(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.TopologySpread = []v1alpha1.TopologySpreadConstraint{{TopologyKey: "kubernetes.io/hostname", MaxSkew: 2}}
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constraints := template.Spec.TopologySpreadConstraints
+	if len(constraints) != 1 {
+		t.Fatalf("constraints = %+v, want the one from the spec", constraints)
+	}
+	c := constraints[0]
+	if c.TopologyKey != "kubernetes.io/hostname" || c.MaxSkew != 2 || c.WhenUnsatisfiable != corev1.DoNotSchedule {
+		t.Errorf("constraint = %+v, want hostname, maxSkew 2, DoNotSchedule", c)
+	}
+	if c.LabelSelector == nil || c.LabelSelector.MatchLabels["app"] != app.Name {
+		t.Errorf("label selector = %+v, want the app's pods", c.LabelSelector)
+	}
+}
+
+func TestDefaultZoneSpread(t *testing.T) {
+	tests := []struct {
+		name        string
+		zoneSpread  bool
+		replicas    int32
+		wantDefault bool
+	}{
+		{"enabled with replicas", true, 3, true},
+		{"enabled with one replica", true, 1, false},
+		{"disabled", false, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.Replicas = tt.replicas
+			r, _ := newTestController(t, app)
+			r.Config.DefaultZoneSpread = tt.zoneSpread
+
+			template, err := r.buildPodTemplate(testCtx, app)
+			if err != nil {
+				t.Fatal(err)
+			}
+			constraints := template.Spec.TopologySpreadConstraints
+			if !tt.wantDefault {
+				if len(constraints) != 0 {
+					t.Errorf("constraints = %+v, want none", constraints)
+				}
+				return
+			}
+			if len(constraints) != 1 || constraints[0].TopologyKey != corev1.LabelTopologyZone ||
+				constraints[0].WhenUnsatisfiable != corev1.ScheduleAnyway {
+				t.Errorf("constraints = %+v, want a best-effort zone spread", constraints)
+			}
+		})
+	}
+}
+
+func TestPriorityClassName(t *testing.T) {
+	app := newTestApplication("web")
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.Spec.PriorityClassName != "" {
+		t.Errorf("priorityClassName = %q without one in the spec", template.Spec.PriorityClassName)
+	}
+
+	app.Spec.PriorityClassName = "business-critical"
+	template, err = r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.Spec.PriorityClassName != "business-critical" {
+		t.Errorf("priorityClassName = %q, want business-critical", template.Spec.PriorityClassName)
+	}
+
+	app.Spec.PriorityClassName = "Business_Critical"
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("invalid priorityClassName accepted")
+	}
+}
+
+func TestInfraNodeSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		override map[string]string
+		want     map[string]string
+	}{
+		{"none", nil, nil, nil},
+		{"default only", map[string]string{"pool": "infra"}, nil, map[string]string{"pool": "infra"}},
+		{"override only", nil, map[string]string{"disk": "ssd"}, map[string]string{"disk": "ssd"}},
+		{
+			"merged, override wins",
+			map[string]string{"pool": "infra", "zone": "a"},
+			map[string]string{"pool": "db", "disk": "ssd"},
+			map[string]string{"pool": "db", "zone": "a", "disk": "ssd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("web")
+			app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal, NodeSelector: tt.override}
+			r, _ := newTestController(t, app)
+			r.Config.InfraNodeSelector = tt.defaults
+
+			if err := r.provisionLocalRedis(testCtx, app); err != nil {
+				t.Fatal(err)
+			}
+			redis := &appsv1.Deployment{}
+			if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: app.GetRedisName()}, redis); err != nil {
+				t.Fatal(err)
+			}
+			if got := redis.Spec.Template.Spec.NodeSelector; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Redis node selector = %v, want %v", got, tt.want)
+			}
+			if pool, ok := tt.defaults["pool"]; ok && pool != "infra" {
+				t.Error("controller default modified by the override")
+			}
+		})
+	}
+}
+
+func TestTolerations(t *testing.T) {
+	gpu := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	dedicated := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule}
+	app := newTestApplication("web")
+	app.Spec.Tolerations = []corev1.Toleration{gpu}
+	app.Spec.Infrastructure.Tolerations = []corev1.Toleration{dedicated}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := template.Spec.Tolerations; !reflect.DeepEqual(got, []corev1.Toleration{gpu}) {
+		t.Errorf("app tolerations = %v, want %v", got, []corev1.Toleration{gpu})
+	}
+
+	if err := r.provisionLocalRedis(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	redis := &appsv1.Deployment{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: app.GetRedisName()}, redis); err != nil {
+		t.Fatal(err)
+	}
+	if got := redis.Spec.Template.Spec.Tolerations; !reflect.DeepEqual(got, []corev1.Toleration{dedicated}) {
+		t.Errorf("Redis tolerations = %v, want %v", got, []corev1.Toleration{dedicated})
+	}
+}
@@ -0,0 +1,93 @@
+// pkg/controllers/secret_watch.go
+// Reconciles Applications when a Secret they reference changes
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// referencedSecretsIndex indexes Applications by the "namespace/name" of every
+// user-supplied Secret their pods or infrastructure read
+const referencedSecretsIndex = ".spec.referencedSecrets"
+
+// referencedSecrets returns the Secrets an Application references but does not
+// create itself, as "namespace/name" keys in its target namespace
+func referencedSecrets(app *v1alpha1.Application) []string {
+	names := map[string]bool{}
+	for _, sv := range app.Spec.SecretVolumes {
+		names[sv.SecretName] = true
+	}
+	for _, c := range app.Spec.InitContainers {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				names[e.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+		for _, from := range c.EnvFrom {
+			if from.SecretRef != nil {
+				names[from.SecretRef.Name] = true
+			}
+		}
+	}
+	if pg := app.Spec.Infrastructure.PostgreSQL; pg != nil && pg.External != nil {
+		names[pg.External.CredentialsSecretName] = true
+	}
+	if app.Spec.TLS != nil {
+		names[app.Spec.TLS.SecretName] = true
+	}
+
+	keys := make([]string, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		if name == "" {
+			continue
+		}
+		keys = append(keys, types.NamespacedName{Namespace: app.GetTargetNamespace(), Name: name}.String())
+	}
+	return keys
+}
+
+// indexReferencedSecrets registers the field index used to map Secrets back to
+// Applications
+func indexReferencedSecrets(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Application{}, referencedSecretsIndex, func(obj client.Object) []string {
+		app, ok := obj.(*v1alpha1.Application)
+		if !ok {
+			return nil
+		}
+		return referencedSecrets(app)
+	})
+}
+
+// UncachedObjects are read straight from the API server rather than through
+// the manager's cache. The controller watches only the metadata of Secrets and
+// only the defaults ConfigMap, so caching them for reads would keep every
+// Secret and ConfigMap in the cluster in memory.
+func UncachedObjects() []client.Object {
+	return []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}}
+}
+
+// applicationsForSecret maps a changed Secret, watched as metadata only, to
+// the Applications that reference it; their reconcile recomputes the config
+// hash and rolls the pods
+func (r *ApplicationController) applicationsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	apps := &v1alpha1.ApplicationList{}
+	if err := r.List(ctx, apps, client.MatchingFields{referencedSecretsIndex: client.ObjectKeyFromObject(secret).String()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Applications referencing secret", "secret", secret.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(apps.Items))
+	for _, app := range apps.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&app)})
+	}
+	return requests
+}
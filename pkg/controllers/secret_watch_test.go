@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestApplicationsForSecretMetadata(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.SecretVolumes = []v1alpha1.SecretVolumeMount{{SecretName: "certs", MountPath: "/certs"}}
+	other := newTestApplication("other")
+
+	scheme := testScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, other).
+		WithIndex(&v1alpha1.Application{}, referencedSecretsIndex, func(obj client.Object) []string {
+			return referencedSecrets(obj.(*v1alpha1.Application))
+		}).
+		Build()
+	r := &ApplicationController{Client: c, Scheme: scheme, Config: DefaultReconcileConfig()}
+
+	// The watch delivers metadata only
+	secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "certs", Namespace: "default"}}
+	secret.SetGroupVersionKind(metav1.SchemeGroupVersion.WithKind("Secret"))
+	requests := r.applicationsForSecret(testCtx, secret)
+	if len(requests) != 1 || requests[0].Name != "web" {
+		t.Errorf("requests = %v, want only web", requests)
+	}
+}
@@ -0,0 +1,39 @@
+// pkg/controllers/security_context.go
+// Pod security contexts for application and infrastructure pods
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// Groups the infrastructure images run as, set as fsGroup so their volumes
+// are writable: postgres (999) in the official image, appuser (1000) in apache/kafka
+const (
+	postgresFSGroup int64 = 999
+	kafkaFSGroup    int64 = 1000
+)
+
+// appPodSecurityContext merges the spec's fsGroup over its podSecurityContext;
+// nil leaves the cluster defaults in charge
+func appPodSecurityContext(app *v1alpha1.Application) *corev1.PodSecurityContext {
+	if app.Spec.PodSecurityContext == nil && app.Spec.FSGroup == nil {
+		return nil
+	}
+	sc := &corev1.PodSecurityContext{}
+	if app.Spec.PodSecurityContext != nil {
+		sc = app.Spec.PodSecurityContext.DeepCopy()
+	}
+	if app.Spec.FSGroup != nil {
+		fsGroup := *app.Spec.FSGroup
+		sc.FSGroup = &fsGroup
+	}
+	return sc
+}
+
+// infraPodSecurityContext gives an infrastructure pod's volumes to fsGroup
+func infraPodSecurityContext(fsGroup int64) *corev1.PodSecurityContext {
+	return &corev1.PodSecurityContext{FSGroup: &fsGroup}
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"strconv"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestAppPodSecurityContext(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	if template.Spec.SecurityContext != nil {
+		t.Errorf("security context = %+v without one in the spec, want none", template.Spec.SecurityContext)
+	}
+
+	nonRoot := true
+	specFSGroup, fsGroup := int64(1), int64(2000)
+	app.Spec.PodSecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: &nonRoot, FSGroup: &specFSGroup}
+	app.Spec.FSGroup = &fsGroup
+	template, err = r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	sc := template.Spec.SecurityContext
+	if sc == nil || sc.FSGroup == nil || *sc.FSGroup != 2000 {
+		t.Fatalf("security context = %+v, want fsGroup 2000", sc)
+	}
+	if sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+		t.Errorf("security context = %+v, want runAsNonRoot from podSecurityContext", sc)
+	}
+	if *app.Spec.PodSecurityContext.FSGroup != 1 {
+		t.Error("podSecurityContext in the spec was modified")
+	}
+}
+
+func TestPostgresPodSecurityContext(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+
+	if err := r.provisionLocalPostgreSQL(testCtx, app); err != nil {
+		t.Fatalf("provisionLocalPostgreSQL: %v", err)
+	}
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetPostgresName(), Namespace: "default"}, sts); err != nil {
+		t.Fatalf("get StatefulSet: %v", err)
+	}
+	sc := sts.Spec.Template.Spec.SecurityContext
+	if sc == nil || sc.FSGroup == nil || *sc.FSGroup != postgresFSGroup {
+		t.Errorf("PostgreSQL security context = %+v, want fsGroup %d", sc, postgresFSGroup)
+	}
+}
+
+func TestContainerWorkingDirAndUser(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+
+	container := r.buildAppContainer(app)
+	if container.WorkingDir != "" || container.SecurityContext != nil {
+		t.Errorf("workingDir = %q, security context = %+v without them in the spec, want neither", container.WorkingDir, container.SecurityContext)
+	}
+
+	uid, gid := int64(1001), int64(2001)
+	app.Spec.WorkingDir = "/srv/app"
+	app.Spec.RunAsUser = &uid
+	app.Spec.RunAsGroup = &gid
+	container = r.buildAppContainer(app)
+	if container.WorkingDir != "/srv/app" {
+		t.Errorf("workingDir = %q, want /srv/app", container.WorkingDir)
+	}
+	sc := container.SecurityContext
+	if sc == nil || sc.RunAsUser == nil || *sc.RunAsUser != uid || sc.RunAsGroup == nil || *sc.RunAsGroup != gid {
+		t.Errorf("security context = %+v, want runAsUser %d and runAsGroup %d", sc, uid, gid)
+	}
+}
+
+func TestAutomountServiceAccountToken(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name           string
+		spec           *bool
+		secureDefaults bool
+		want           *bool
+	}{
+		{"service account decides", nil, false, nil},
+		{"secure defaults", nil, true, &no},
+		{"spec disables", &no, false, &no},
+		{"spec enables under secure defaults", &yes, true, &yes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("shop")
+			app.Spec.AutomountServiceAccountToken = tt.spec
+			r, _ := newTestController(t, app)
+			r.Config.SecureDefaults = tt.secureDefaults
+
+			template, err := r.buildPodTemplate(testCtx, app)
+			if err != nil {
+				t.Fatalf("buildPodTemplate: %v", err)
+			}
+			got := template.Spec.AutomountServiceAccountToken
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("automountServiceAccountToken = %v, want %v", fmtBoolPtr(got), fmtBoolPtr(tt.want))
+			}
+		})
+	}
+}
+
+// fmtBoolPtr renders an optional bool for test failures
+func fmtBoolPtr(b *bool) string {
+	if b == nil {
+		return "unset"
+	}
+	return strconv.FormatBool(*b)
+}
@@ -0,0 +1,72 @@
+// pkg/controllers/selectors.go
+// Label selectors for the application's own pods
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// instanceLabel carries the Application UID, so selectors stay unique to one
+// Application even if another one later reuses its name
+const instanceLabel = "platform.orion.dev/instance"
+
+// appSelectorLabels returns the labels that select the application pods. The
+// UID never changes, so the selector is stable across reconciles; "app" stays
+// for humans and dashboards.
+func appSelectorLabels(app *v1alpha1.Application) map[string]string {
+	labels := map[string]string{"app": app.Name}
+	if app.UID != "" {
+		labels[instanceLabel] = string(app.UID)
+	}
+	return labels
+}
+
+// appSelectorWith returns the application selector plus one extra label
+func appSelectorWith(app *v1alpha1.Application, key, value string) map[string]string {
+	labels := appSelectorLabels(app)
+	labels[key] = value
+	return labels
+}
+
+// podSelectorFor returns the selector a Service should use for the pods of the
+// named workload. Workloads created before the instance label existed run pods
+// without it, so for those the selector falls back to "app" alone.
+func (r *ApplicationController) podSelectorFor(ctx context.Context, app *v1alpha1.Application, workload string) (map[string]string, error) {
+	selector := appSelectorLabels(app)
+	if _, ok := selector[instanceLabel]; !ok {
+		return selector, nil
+	}
+
+	var template *corev1.PodTemplateSpec
+	key := client.ObjectKey{Namespace: app.GetTargetNamespace(), Name: workload}
+	var err error
+	if app.GetKind() == v1alpha1.WorkloadStatefulSet {
+		sts := &appsv1.StatefulSet{}
+		err = r.Get(ctx, key, sts)
+		template = &sts.Spec.Template
+	} else {
+		deployment := &appsv1.Deployment{}
+		err = r.Get(ctx, key, deployment)
+		template = &deployment.Spec.Template
+	}
+	if errors.IsNotFound(err) {
+		return selector, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload %s: %w", workload, err)
+	}
+
+	if template.Labels[instanceLabel] == "" {
+		delete(selector, instanceLabel)
+	}
+	return selector, nil
+}
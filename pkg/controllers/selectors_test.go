@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestAppSelectorStableAndUnique(t *testing.T) {
+	app := newTestApplication("web")
+	r, _ := newTestController(t, app)
+
+	first, err := r.buildAppDeployment(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildAppDeployment: %v", err)
+	}
+	app.Spec.Image = "nginx:1.26"
+	app.Spec.Replicas = 3
+	second, err := r.buildAppDeployment(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildAppDeployment: %v", err)
+	}
+	if !reflect.DeepEqual(first.Spec.Selector, second.Spec.Selector) {
+		t.Errorf("selector changed with the spec: %v -> %v", first.Spec.Selector, second.Spec.Selector)
+	}
+	if first.Spec.Selector.MatchLabels[instanceLabel] != string(app.UID) {
+		t.Errorf("selector = %v, want the Application UID", first.Spec.Selector.MatchLabels)
+	}
+
+	// A new Application with the same name selects none of the old pods
+	recreated := newTestApplication("web")
+	recreated.UID = types.UID("uid-web-2")
+	if reflect.DeepEqual(appSelectorLabels(app), appSelectorLabels(recreated)) {
+		t.Errorf("selectors of two Applications named web are both %v", appSelectorLabels(app))
+	}
+}
+
+func TestPodSelectorForLegacyWorkload(t *testing.T) {
+	app := newTestApplication("web")
+	legacy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}},
+		},
+	}
+	r, _ := newTestController(t, app, legacy)
+
+	selector, err := r.podSelectorFor(testCtx, app, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(selector, map[string]string{"app": "web"}) {
+		t.Errorf("selector = %v for pods without the instance label, want app only", selector)
+	}
+
+	selector, err = r.podSelectorFor(testCtx, app, "web-canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selector[instanceLabel] != string(app.UID) {
+		t.Errorf("selector = %v for a workload not created yet, want the instance label", selector)
+	}
+}
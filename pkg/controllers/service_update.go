@@ -0,0 +1,149 @@
+// pkg/controllers/service_update.go
+// Brings an existing application Service in line with the desired spec
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// usesNodePorts reports whether a Service type allocates node ports
+func usesNodePorts(t corev1.ServiceType) bool {
+	return t == corev1.ServiceTypeNodePort || t == corev1.ServiceTypeLoadBalancer
+}
+
+// mergeServiceSpec copies the fields the controller owns from desired onto
+// existing and reports whether anything changed. ClusterIP is left alone, and
+// allocated node ports and API-defaulted affinity settings are carried over so
+// they do not show up as a change on every reconcile.
+func mergeServiceSpec(existing, desired *corev1.Service) bool {
+	ports := make([]corev1.ServicePort, len(desired.Spec.Ports))
+	copy(ports, desired.Spec.Ports)
+	if usesNodePorts(existing.Spec.Type) && usesNodePorts(desired.Spec.Type) {
+		for i := range ports {
+			for _, old := range existing.Spec.Ports {
+				if ports[i].NodePort == 0 && old.Port == ports[i].Port && old.Protocol == ports[i].Protocol {
+					ports[i].NodePort = old.NodePort
+				}
+			}
+		}
+	}
+
+	affinityConfig := desired.Spec.SessionAffinityConfig
+	if affinityConfig == nil && desired.Spec.SessionAffinity == corev1.ServiceAffinityClientIP &&
+		existing.Spec.SessionAffinity == corev1.ServiceAffinityClientIP {
+		affinityConfig = existing.Spec.SessionAffinityConfig
+	}
+
+	changed := !equality.Semantic.DeepEqual(existing.Spec.Ports, ports) ||
+		existing.Spec.Type != desired.Spec.Type ||
+		!equality.Semantic.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) ||
+		existing.Spec.SessionAffinity != desired.Spec.SessionAffinity ||
+		!equality.Semantic.DeepEqual(existing.Spec.SessionAffinityConfig, affinityConfig)
+
+	existing.Spec.Ports = ports
+	existing.Spec.Type = desired.Spec.Type
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.SessionAffinity = desired.Spec.SessionAffinity
+	existing.Spec.SessionAffinityConfig = affinityConfig
+
+	return mergeScrapeAnnotations(existing, desired) || changed
+}
+
+// mergeScrapeAnnotations copies the Prometheus scrape annotations from desired
+// onto existing, removing those desired no longer has, and reports whether
+// anything changed. Other annotations are not ours.
+func mergeScrapeAnnotations(existing, desired *corev1.Service) bool {
+	changed := false
+	for _, key := range []string{prometheusScrapeAnnotation, prometheusPathAnnotation, prometheusPortAnnotation} {
+		want, ok := desired.Annotations[key]
+		if have, exists := existing.Annotations[key]; exists != ok || have != want {
+			changed = true
+		}
+		if ok {
+			existing.Annotations = mergeAnnotations(existing.Annotations, map[string]string{key: want})
+		} else {
+			delete(existing.Annotations, key)
+		}
+	}
+	return changed
+}
+
+// reconcileServices brings the running application's Services in line with
+// the spec, creating or removing the headless Service as it asks. Under
+// blue/green the live selector is kept: only a color switch moves it, once the
+// new color has rolled out.
+func (r *ApplicationController) reconcileServices(ctx context.Context, app *v1alpha1.Application) error {
+	if app.GetKind() == v1alpha1.WorkloadJob || app.GetKind() == v1alpha1.WorkloadCronJob {
+		return nil
+	}
+	desired, err := r.buildAppService(ctx, app)
+	if err != nil {
+		return err
+	}
+	live := &corev1.Service{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	switch {
+	case errors.IsNotFound(err):
+		err = r.createOrUpdateService(ctx, app)
+	case err != nil:
+		return fmt.Errorf("failed to get service %s: %w", desired.Name, err)
+	default:
+		if app.UsesBlueGreen() {
+			desired.Spec.Selector = live.Spec.Selector
+		}
+		err = r.updateService(ctx, app, desired)
+	}
+	if err != nil {
+		return err
+	}
+
+	if wantsHeadlessService(app) {
+		return r.createOrUpdateHeadlessService(ctx, app)
+	}
+	return r.deleteHeadlessService(ctx, app)
+}
+
+// updateService reconciles an existing Service towards desired. An update the
+// API rejects as invalid (an immutable field) falls back to recreating it.
+func (r *ApplicationController) updateService(ctx context.Context, app *v1alpha1.Application, desired *corev1.Service) error {
+	logger := appLogger(ctx, app)
+
+	existing := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return fmt.Errorf("failed to get service %s: %w", desired.Name, err)
+	}
+	if err := r.claimExisting(ctx, app, existing); err != nil {
+		return err
+	}
+	if !mergeServiceSpec(existing, desired) {
+		return nil
+	}
+
+	err := r.Update(ctx, existing)
+	if err == nil {
+		logger.Info("Updated Kubernetes Service", "name", desired.Name, "type", desired.Spec.Type)
+		return nil
+	}
+	if !errors.IsInvalid(err) {
+		return fmt.Errorf("failed to update service %s: %w", desired.Name, err)
+	}
+
+	logger.Info("Service change needs a new object - recreating", "name", desired.Name, "reason", err.Error())
+	if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete service %s: %w", desired.Name, err)
+	}
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		return fmt.Errorf("failed to recreate service %s: %w", desired.Name, err)
+	}
+	r.recordEvent(app, corev1.EventTypeNormal, "ServiceRecreated", fmt.Sprintf("Service %s recreated to apply an immutable change", desired.Name))
+	return nil
+}
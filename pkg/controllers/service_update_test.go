@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestReconcileServicesAppliesPortAndTypeChanges(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.Port = 8080
+	r, _ := newTestController(t, app)
+	if err := r.createOrUpdateService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Spec.Port = 9090
+	app.Spec.Protocol = string(corev1.ProtocolUDP)
+	app.Spec.ServiceType = string(corev1.ServiceTypeNodePort)
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	service := &corev1.Service{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: "web"}, service); err != nil {
+		t.Fatal(err)
+	}
+	if service.Spec.Type != corev1.ServiceTypeNodePort {
+		t.Errorf("service type = %s, want NodePort", service.Spec.Type)
+	}
+	if p := service.Spec.Ports[0]; p.TargetPort.IntVal != 9090 || p.Protocol != corev1.ProtocolUDP {
+		t.Errorf("service port = %+v, want target 9090/UDP", p)
+	}
+}
+
+func TestReconcileServicesKeepsBlueGreenSelector(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.Strategy = v1alpha1.StrategyBlueGreen
+	app.Status.ActiveColor = v1alpha1.ColorGreen
+	r, _ := newTestController(t, app)
+	if err := r.createOrUpdateService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	// A color switch pending in status must not move the Service
+	app.Status.ActiveColor = v1alpha1.ColorBlue
+	app.Spec.Port = 9090
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	live := &corev1.Service{}
+	if err := r.Get(testCtx, client.ObjectKey{Namespace: "default", Name: "web"}, live); err != nil {
+		t.Fatal(err)
+	}
+	if live.Spec.Selector[colorLabel] != v1alpha1.ColorGreen {
+		t.Errorf("service selector = %v, want the live green color kept", live.Spec.Selector)
+	}
+	if live.Spec.Ports[0].TargetPort.IntVal != 9090 {
+		t.Errorf("service port = %+v, want target 9090", live.Spec.Ports[0])
+	}
+}
+
+func TestReconcileServicesFollowsHeadlessSetting(t *testing.T) {
+	app := newTestApplication("web")
+	app.Spec.Image = "nginx:1.25"
+	app.Spec.HeadlessService = true
+	r, _ := newTestController(t, app)
+	if err := r.createOrUpdateService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKey{Namespace: "default", Name: app.GetHeadlessServiceName()}
+
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	headless := &corev1.Service{}
+	if err := r.Get(testCtx, key, headless); err != nil {
+		t.Fatalf("headless Service not created on a running application: %v", err)
+	}
+	if headless.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("clusterIP = %q, want None", headless.Spec.ClusterIP)
+	}
+
+	app.Spec.Port = 9090
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, headless); err != nil {
+		t.Fatal(err)
+	}
+	if headless.Spec.Ports[0].Port != 9090 || headless.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("headless Service = %+v, want port 9090 and clusterIP None", headless.Spec)
+	}
+
+	app.Spec.HeadlessService = false
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, headless); err == nil {
+		t.Error("headless Service kept after it was disabled")
+	}
+}
+
+func TestSessionAffinity(t *testing.T) {
+	app := newTestApplication("web")
+	r, _ := newTestController(t, app)
+	if err := r.createOrUpdateService(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	service := &corev1.Service{}
+	key := client.ObjectKey{Namespace: "default", Name: "web"}
+	if err := r.Get(testCtx, key, service); err != nil {
+		t.Fatal(err)
+	}
+	if service.Spec.SessionAffinity != corev1.ServiceAffinityNone || service.Spec.SessionAffinityConfig != nil {
+		t.Errorf("session affinity = %s (%+v), want None by default", service.Spec.SessionAffinity, service.Spec.SessionAffinityConfig)
+	}
+
+	timeout := int32(600)
+	app.Spec.SessionAffinity = string(corev1.ServiceAffinityClientIP)
+	app.Spec.SessionAffinityTimeoutSeconds = &timeout
+	if err := r.reconcileServices(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(testCtx, key, service); err != nil {
+		t.Fatal(err)
+	}
+	if service.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		t.Errorf("session affinity = %s, want ClientIP", service.Spec.SessionAffinity)
+	}
+	config := service.Spec.SessionAffinityConfig
+	if config == nil || config.ClientIP == nil || config.ClientIP.TimeoutSeconds == nil || *config.ClientIP.TimeoutSeconds != 600 {
+		t.Errorf("session affinity config = %+v, want a 600s timeout", config)
+	}
+
+	app.Spec.SessionAffinity = ""
+	if err := app.ValidateSpec(); err == nil {
+		t.Error("sessionAffinityTimeoutSeconds accepted without ClientIP affinity")
+	}
+}
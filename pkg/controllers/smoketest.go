@@ -0,0 +1,90 @@
+// pkg/controllers/smoketest.go
+// Post-deploy HTTP check gating the Ready phase
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	// smokeTestTimeout bounds each smoke test request
+	smokeTestTimeout = 10 * time.Second
+	// smokeTestBodyLimit is how much of a failing response is quoted in status
+	smokeTestBodyLimit = 256
+)
+
+// smokeTestURL is the in-cluster address of the application Service
+func smokeTestURL(app *v1alpha1.Application) string {
+	return fmt.Sprintf("http://%s.%s.svc:80%s", app.Name, app.GetTargetNamespace(), app.Spec.SmokeTest.GetPath())
+}
+
+// runSmokeTest sends the smoke test request and returns an error describing
+// the response when it does not match the expected status
+func (r *ApplicationController) runSmokeTest(ctx context.Context, app *v1alpha1.Application) error {
+	smoke := app.Spec.SmokeTest
+	url := smokeTestURL(app)
+
+	ctx, cancel := context.WithTimeout(ctx, smokeTestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build smoke test request: %w", err)
+	}
+	client := r.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: smokeTestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == smoke.GetExpectedStatus() {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, smokeTestBodyLimit))
+	detail := strings.TrimSpace(string(body))
+	if detail == "" {
+		return fmt.Errorf("GET %s returned %s, expected %d", url, resp.Status, smoke.GetExpectedStatus())
+	}
+	return fmt.Errorf("GET %s returned %s, expected %d: %s", url, resp.Status, smoke.GetExpectedStatus(), detail)
+}
+
+// checkSmokeTest runs the smoke test of a rollout whose replicas are ready. It
+// reports whether the Application may become Ready; after failureThreshold
+// consecutive failures it marks the Application Failed instead.
+func (r *ApplicationController) checkSmokeTest(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	if app.Spec.SmokeTest == nil {
+		return true, nil
+	}
+	logger := appLogger(ctx, app)
+
+	err := r.runSmokeTest(ctx, app)
+	if err == nil {
+		logger.Info("Smoke test passed", "url", smokeTestURL(app))
+		app.Status.SmokeTestFailures = 0
+		return true, nil
+	}
+
+	app.Status.SmokeTestFailures++
+	threshold := app.Spec.SmokeTest.GetFailureThreshold()
+	logger.Info("Smoke test failed", "error", err.Error(), "failures", app.Status.SmokeTestFailures, "threshold", threshold)
+	if app.Status.SmokeTestFailures >= threshold {
+		r.recordEvent(app, corev1.EventTypeWarning, "SmokeTestFailed", err.Error())
+		app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Smoke test failed: %v", err))
+		return false, nil
+	}
+	app.Status.Message = fmt.Sprintf("Smoke test failed (%d/%d): %v", app.Status.SmokeTestFailures, threshold, err)
+	return false, r.updateApplicationStatusOnly(ctx, app)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// smokeResponder answers every request with a fixed status and body and
+// records the URLs it was asked for
+type smokeResponder struct {
+	status int
+	body   string
+	urls   []string
+}
+
+func (s *smokeResponder) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.urls = append(s.urls, req.URL.String())
+	return &http.Response{
+		StatusCode: s.status,
+		Status:     http.StatusText(s.status),
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func newSmokeTest(t *testing.T, threshold int32) (*ApplicationController, *record.FakeRecorder, *v1alpha1.Application, *smokeResponder) {
+	t.Helper()
+	app := newTestApplication("shop")
+	app.Spec.SmokeTest = &v1alpha1.SmokeTestSpec{Path: "/healthz", FailureThreshold: threshold}
+	app.Status.Phase = v1alpha1.PhaseDeploying
+	r, recorder := newTestController(t, app)
+	responder := &smokeResponder{status: http.StatusOK}
+	r.HTTPClient = &http.Client{Transport: responder}
+	return r, recorder, app, responder
+}
+
+func TestSmokeTestPasses(t *testing.T) {
+	r, _, app, responder := newSmokeTest(t, 3)
+	app.Status.SmokeTestFailures = 2
+
+	ready, err := r.checkSmokeTest(testCtx, app)
+	if err != nil || !ready {
+		t.Fatalf("checkSmokeTest = %t, %v; want ready", ready, err)
+	}
+	if app.Status.SmokeTestFailures != 0 {
+		t.Errorf("failures = %d after a pass, want them reset", app.Status.SmokeTestFailures)
+	}
+	if want := "http://shop.default.svc:80/healthz"; len(responder.urls) != 1 || responder.urls[0] != want {
+		t.Errorf("requested %v, want %s", responder.urls, want)
+	}
+}
+
+func TestSmokeTestFailureGatesReady(t *testing.T) {
+	r, recorder, app, responder := newSmokeTest(t, 2)
+	responder.status, responder.body = http.StatusServiceUnavailable, "database unreachable"
+
+	ready, err := r.checkSmokeTest(testCtx, app)
+	if err != nil || ready {
+		t.Fatalf("checkSmokeTest = %t, %v; want not ready", ready, err)
+	}
+	if app.Status.Phase != v1alpha1.PhaseDeploying || !strings.Contains(app.Status.Message, "(1/2)") {
+		t.Errorf("phase = %s (%s), want Deploying with the first failure counted", app.Status.Phase, app.Status.Message)
+	}
+
+	// The threshold turns the rollout Failed, quoting the response
+	if ready, err := r.checkSmokeTest(testCtx, app); err != nil || ready {
+		t.Fatalf("checkSmokeTest = %t, %v; want not ready", ready, err)
+	}
+	if app.Status.Phase != v1alpha1.PhaseFailed || !strings.Contains(app.Status.Message, "database unreachable") {
+		t.Errorf("phase = %s (%s), want Failed quoting the response body", app.Status.Phase, app.Status.Message)
+	}
+	if events := drainEvents(recorder); !hasEvent(events, "SmokeTestFailed") {
+		t.Errorf("events = %v, want SmokeTestFailed", events)
+	}
+}
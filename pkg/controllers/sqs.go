@@ -0,0 +1,145 @@
+// pkg/controllers/sqs.go
+// SQS queues, backed by ElasticMQ outside AWS
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// sqsQueue converts the spec into the queue definition
+func sqsQueue(app *v1alpha1.Application) SQSQueue {
+	spec := app.Spec.Infrastructure.SQS
+	return SQSQueue{
+		Name:                     app.GetSQSQueueName(),
+		VisibilityTimeoutSeconds: spec.VisibilityTimeoutSeconds,
+		MessageRetentionSeconds:  spec.MessageRetentionSeconds,
+	}
+}
+
+// createQueueCommand renders the aws CLI invocation that creates the queue;
+// create-queue is idempotent for unchanged attributes
+func createQueueCommand(queue SQSQueue, endpoint string) string {
+	cmd := fmt.Sprintf("aws sqs create-queue --endpoint-url %s --queue-name %s", endpoint, queue.Name)
+
+	var attrs []string
+	if queue.VisibilityTimeoutSeconds > 0 {
+		attrs = append(attrs, fmt.Sprintf("VisibilityTimeout=%d", queue.VisibilityTimeoutSeconds))
+	}
+	if queue.MessageRetentionSeconds > 0 {
+		attrs = append(attrs, fmt.Sprintf("MessageRetentionPeriod=%d", queue.MessageRetentionSeconds))
+	}
+	if len(attrs) > 0 {
+		cmd += " --attributes " + strings.Join(attrs, ",")
+	}
+	return cmd
+}
+
+// provisionLocalSQS runs ElasticMQ and creates the queue in it
+func (r *ApplicationController) provisionLocalSQS(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentQueue)
+	logger.Info("Creating local SQS (ElasticMQ)")
+
+	labels := map[string]string{"app": app.Name, "component": componentQueue, "managed-by": "orion-platform"}
+	selector := map[string]string{"app": app.Name, "component": componentQueue}
+
+	elasticMQ := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetSQSName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &[]int32{1}[0],
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					NodeSelector: r.infraNodeSelector(app.Spec.Infrastructure.SQS.NodeSelector),
+					Tolerations:  infraTolerations(app),
+					Containers: []corev1.Container{
+						{
+							Name:  "elasticmq",
+							Image: app.InfraImage(v1alpha1.ElasticMQImage),
+							Ports: []corev1.ContainerPort{{ContainerPort: 9324}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, elasticMQ); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create ElasticMQ Deployment: %w", err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetSQSName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       9324,
+					TargetPort: intstr.FromInt(9324),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create ElasticMQ Service: %w", err)
+	}
+
+	// Create the queue once ElasticMQ accepts connections
+	queue := sqsQueue(app)
+	endpoint := fmt.Sprintf("http://%s:9324", app.GetSQSName())
+	script := fmt.Sprintf("until aws sqs list-queues --endpoint-url %s >/dev/null; do sleep 2; done && %s",
+		endpoint, createQueueCommand(queue, endpoint))
+
+	queueJob := localAWSCLIJob(app, app.GetSQSQueueJobName(), componentQueue, script, nil, r.infraNodeSelector(app.Spec.Infrastructure.SQS.NodeSelector))
+
+	if err := r.createOwned(ctx, app, queueJob); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create SQS queue Job: %w", err)
+	}
+
+	app.Status.SQSQueueName = queue.Name
+	app.Status.SQSQueueURL = fmt.Sprintf("%s/%s/%s", endpoint, v1alpha1.LocalSQSAccountID, queue.Name)
+	app.Status.SQSEnvironment = v1alpha1.EnvironmentLocal
+
+	logger.Info("Local SQS (ElasticMQ) created", "queueURL", app.Status.SQSQueueURL)
+	return nil
+}
+
+// provisionAWSSQS creates the queue through the AWS client
+func (r *ApplicationController) provisionAWSSQS(ctx context.Context, app *v1alpha1.Application) error {
+	logger := componentLogger(ctx, app, componentQueue)
+	logger.Info("Provisioning AWS SQS queue")
+
+	queue := sqsQueue(app)
+	url, err := r.awsClient().CreateSQSQueue(ctx, queue)
+	if err != nil {
+		return fmt.Errorf("failed to create SQS queue %s: %w", queue.Name, err)
+	}
+
+	app.Status.SQSQueueName = queue.Name
+	app.Status.SQSQueueURL = url
+	app.Status.SQSEnvironment = v1alpha1.EnvironmentAWS
+
+	logger.Info("AWS SQS queue ready", "queueURL", url)
+	return nil
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestLocalSQS(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.SQS = &v1alpha1.SQSSpec{Environment: v1alpha1.EnvironmentLocal, VisibilityTimeoutSeconds: 60}
+	r, _ := newTestController(t, app)
+	aws := &fakeAWSClient{}
+	r.AWS = aws
+
+	if err := r.provisionQueue(testCtx, app); err != nil {
+		t.Fatalf("provisionQueue: %v", err)
+	}
+	if len(aws.queues) != 0 {
+		t.Errorf("AWS queues created for a local queue: %+v", aws.queues)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetSQSName(), Namespace: "default"}, &appsv1.Deployment{}); err != nil {
+		t.Errorf("ElasticMQ Deployment: %v", err)
+	}
+	job := &batchv1.Job{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetSQSQueueJobName(), Namespace: "default"}, job); err != nil {
+		t.Fatalf("queue Job: %v", err)
+	}
+	if script := strings.Join(job.Spec.Template.Spec.Containers[0].Command, " "); !strings.Contains(script, "--queue-name shop --attributes VisibilityTimeout=60") {
+		t.Errorf("queue Job command = %q, want the queue created with its attributes", script)
+	}
+
+	endpoint := "http://" + app.GetSQSName() + ":9324"
+	env := r.buildEnvironmentVariables(app)
+	for name, value := range map[string]string{
+		"SQS_QUEUE_NAME": "shop",
+		"SQS_QUEUE_URL":  endpoint + "/" + v1alpha1.LocalSQSAccountID + "/shop",
+		"SQS_ENDPOINT":   endpoint,
+	} {
+		if !hasEnv(env, name, value) {
+			t.Errorf("app env = %v, want %s=%s", env, name, value)
+		}
+	}
+}
+
+func TestAWSSQS(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.SQS = &v1alpha1.SQSSpec{Environment: v1alpha1.EnvironmentAWS, QueueName: "orders", MessageRetentionSeconds: 3600}
+	r, _ := newTestController(t, app)
+	aws := &fakeAWSClient{simulatedAWSClient: simulatedAWSClient{region: "eu-west-1"}}
+	r.AWS = aws
+
+	if err := r.provisionQueue(testCtx, app); err != nil {
+		t.Fatalf("provisionQueue: %v", err)
+	}
+	if len(aws.queues) != 1 || aws.queues[0].Name != "orders" || aws.queues[0].MessageRetentionSeconds != 3600 {
+		t.Fatalf("queues created = %+v, want orders with its retention", aws.queues)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetSQSName(), Namespace: "default"}, &appsv1.Deployment{}); err == nil {
+		t.Error("ElasticMQ provisioned for an AWS queue")
+	}
+
+	env := r.buildEnvironmentVariables(app)
+	if !hasEnv(env, "SQS_QUEUE_URL", "https://sqs.eu-west-1.amazonaws.com/123456789012/orders") {
+		t.Errorf("app env = %v, want the AWS queue URL", env)
+	}
+	for _, e := range env {
+		if e.Name == "SQS_ENDPOINT" {
+			t.Errorf("SQS_ENDPOINT = %q for an AWS queue, want none", e.Value)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// pkg/controllers/status_configmap.go
+// Mirrors key status fields into a ConfigMap readable without access to the CR
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// statusConfigMapData renders the phase and the endpoints set on the status.
+// Credentials are never included; unset endpoints are left out.
+func statusConfigMapData(app *v1alpha1.Application) map[string]string {
+	status := app.Status
+	data := map[string]string{
+		"phase":               string(status.Phase),
+		"message":             status.Message,
+		"readyReplicas":       fmt.Sprintf("%d", status.ReadyReplicas),
+		"infrastructureReady": fmt.Sprintf("%t", status.InfrastructureReady),
+		"serviceEndpoint":     fmt.Sprintf("%s.%s.svc:80", app.Name, app.GetTargetNamespace()),
+	}
+	for key, value := range statusEndpoints(status) {
+		data[key] = value
+	}
+	return data
+}
+
+// statusEndpoints returns the infrastructure endpoints set on the status
+func statusEndpoints(status v1alpha1.ApplicationStatus) map[string]string {
+	fields := map[string]string{
+		"databaseEndpoint":       status.DatabaseEndpoint,
+		"databaseReadEndpoint":   status.DatabaseReadEndpoint,
+		"databasePoolerEndpoint": status.DatabasePoolerEndpoint,
+		"redisEndpoint":          status.RedisEndpoint,
+		"s3BucketName":           status.S3BucketName,
+		"s3Endpoint":             status.S3Endpoint,
+		"dynamodbTableName":      status.DynamoDBTableName,
+		"dynamodbEndpoint":       status.DynamoDBEndpoint,
+		"sqsQueueName":           status.SQSQueueName,
+		"sqsQueueURL":            status.SQSQueueURL,
+		"kafkaBrokers":           status.KafkaBrokers,
+	}
+	endpoints := map[string]string{}
+	for key, value := range fields {
+		if value != "" {
+			endpoints[key] = value
+		}
+	}
+	return endpoints
+}
+
+// reconcileStatusConfigMap keeps the <app>-status ConfigMap in step with the
+// status when statusConfigMap is enabled, and removes it once disabled
+func (r *ApplicationController) reconcileStatusConfigMap(ctx context.Context, app *v1alpha1.Application) error {
+	key := client.ObjectKey{Name: app.GetStatusConfigMapName(), Namespace: app.GetTargetNamespace()}
+
+	if !app.Spec.StatusConfigMap {
+		existing := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !managedBy(app, existing) {
+			return nil
+		}
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete status ConfigMap: %w", err)
+		}
+		return nil
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Data: statusConfigMapData(app),
+	}
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create status ConfigMap: %w", err)
+		}
+		existing := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return fmt.Errorf("failed to get status ConfigMap: %w", err)
+		}
+		if err := r.claimExisting(ctx, app, existing); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(existing.Data, desired.Data) {
+			return nil
+		}
+		existing.Data = desired.Data
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update status ConfigMap: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestStatusConfigMapTracksStatus(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.StatusConfigMap = true
+	app.Status.Phase = v1alpha1.PhaseProvisioningInfra
+	r, _ := newTestController(t, app)
+	key := client.ObjectKey{Name: app.GetStatusConfigMapName(), Namespace: "default"}
+
+	if err := r.reconcileStatusConfigMap(testCtx, app); err != nil {
+		t.Fatalf("reconcileStatusConfigMap: %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(testCtx, key, cm); err != nil {
+		t.Fatalf("get status ConfigMap: %v", err)
+	}
+	if cm.Data["phase"] != string(v1alpha1.PhaseProvisioningInfra) {
+		t.Errorf("phase = %q, want ProvisioningInfrastructure", cm.Data["phase"])
+	}
+	if _, ok := cm.Data["redisEndpoint"]; ok {
+		t.Error("unset redisEndpoint written to the ConfigMap")
+	}
+
+	app.Status.Phase = v1alpha1.PhaseReady
+	app.Status.ReadyReplicas = 2
+	app.Status.RedisEndpoint = "shop-redis.default.svc:6379"
+	if err := r.reconcileStatusConfigMap(testCtx, app); err != nil {
+		t.Fatalf("reconcileStatusConfigMap: %v", err)
+	}
+	if err := r.Get(testCtx, key, cm); err != nil {
+		t.Fatal(err)
+	}
+	for field, want := range map[string]string{
+		"phase":         string(v1alpha1.PhaseReady),
+		"readyReplicas": "2",
+		"redisEndpoint": "shop-redis.default.svc:6379",
+	} {
+		if cm.Data[field] != want {
+			t.Errorf("%s = %q after the status changed, want %q", field, cm.Data[field], want)
+		}
+	}
+
+	// Disabling it removes the ConfigMap
+	app.Spec.StatusConfigMap = false
+	if err := r.reconcileStatusConfigMap(testCtx, app); err != nil {
+		t.Fatalf("reconcileStatusConfigMap: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("status ConfigMap still present after disabling: %v", err)
+	}
+}
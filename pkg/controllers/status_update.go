@@ -0,0 +1,62 @@
+// pkg/controllers/status_update.go
+// Writes Application status without failing on resourceVersion conflicts
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// writeStatus persists app.Status. A conflict only means the object changed
+// since it was read (usually a spec edit); the status computed by this
+// reconcile is still the one to record, so it is copied onto the latest
+// object and written again. app itself keeps the resourceVersion it was read
+// with, so a later spec or finalizer Update still detects the newer object.
+// A successful write records the phase for withPersistedPhase.
+// The update response carries the stored spec; app keeps its in-memory spec,
+// with the platform and image defaults applied by this reconcile.
+func (r *ApplicationController) writeStatus(ctx context.Context, app *v1alpha1.Application) error {
+	spec := v1alpha1.ApplicationSpec{}
+	app.Spec.DeepCopyInto(&spec)
+	defer func() { app.Spec = spec }()
+	phase := app.Status.Phase
+
+	err := r.Status().Update(ctx, app)
+	if errors.IsConflict(err) {
+		appLogger(ctx, app).V(1).Info("Status update conflicted, retrying on the latest version")
+
+		status := &v1alpha1.ApplicationStatus{}
+		app.Status.DeepCopyInto(status)
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest := &v1alpha1.Application{}
+			if err := r.Get(ctx, client.ObjectKeyFromObject(app), latest); err != nil {
+				return err
+			}
+			status.DeepCopyInto(&latest.Status)
+			return r.Status().Update(ctx, latest)
+		})
+	}
+	if err == nil {
+		if persisted, ok := ctx.Value(persistedPhaseKey{}).(*v1alpha1.ApplicationPhase); ok {
+			*persisted = phase
+		}
+	}
+	return err
+}
+
+// persistedPhaseKey carries the phase last written by the reconcile
+type persistedPhaseKey struct{}
+
+// withPersistedPhase tracks the phase stored on the API server through a
+// reconcile, starting from the phase the Application was read with, so phase
+// transitions are only acted on once they are recorded
+func withPersistedPhase(ctx context.Context, phase v1alpha1.ApplicationPhase) (context.Context, *v1alpha1.ApplicationPhase) {
+	persisted := &phase
+	return context.WithValue(ctx, persistedPhaseKey{}, persisted), persisted
+}
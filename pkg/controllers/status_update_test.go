@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// conflictingStatus makes the next conflicts status writes fail with a
+// conflict and counts every status write
+func conflictingStatus(r *ApplicationController, conflicts int) *int {
+	calls := 0
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			calls++
+			if calls <= conflicts {
+				return apierrors.NewConflict(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "applications"},
+					obj.GetName(), nil)
+			}
+			return c.SubResource(subResource).Update(ctx, obj, opts...)
+		},
+	})
+	return &calls
+}
+
+func TestWriteStatusRetriesOnConflict(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+	calls := conflictingStatus(r, 2)
+
+	app.Spec.Replicas = 3 // defaults applied in memory, never written
+	app.Status.Phase = v1alpha1.PhaseReady
+	app.Status.Message = "Application is running"
+	if err := r.writeStatus(testCtx, app); err != nil {
+		t.Fatalf("writeStatus: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("status written %d times, want the two conflicts retried once each", *calls)
+	}
+	stored := &v1alpha1.Application{}
+	if err := r.Get(testCtx, client.ObjectKeyFromObject(app), stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Phase != v1alpha1.PhaseReady || stored.Status.Message != "Application is running" {
+		t.Errorf("stored status = %s (%s), want Ready", stored.Status.Phase, stored.Status.Message)
+	}
+	if app.Spec.Replicas != 3 {
+		t.Errorf("in-memory replicas = %d after the write, want the defaulted 3 kept", app.Spec.Replicas)
+	}
+}
+
+func TestWriteStatusPersistentConflict(t *testing.T) {
+	app := newTestApplication("shop")
+	r, _ := newTestController(t, app)
+	conflictingStatus(r, 100)
+
+	app.Status.Phase = v1alpha1.PhaseReady
+	if err := r.writeStatus(testCtx, app); !apierrors.IsConflict(err) {
+		t.Errorf("writeStatus = %v, want the conflict returned once retries run out", err)
+	}
+}
@@ -0,0 +1,257 @@
+// pkg/controllers/tls.go
+// HTTPS termination through an Ingress or an nginx sidecar
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+const (
+	tlsProxyImage     = "nginx:1.25-alpine"
+	tlsProxyContainer = "tls-proxy"
+	tlsProxyConfigKey = "default.conf"
+	tlsPortName       = "https"
+	tlsCertDir        = "/etc/nginx/tls"
+)
+
+// tlsProxyConfig terminates TLS on TLSProxyPort and forwards plain HTTP to the
+// application container over localhost
+func tlsProxyConfig(app *v1alpha1.Application) string {
+	return fmt.Sprintf(`server {
+    listen %d ssl;
+    ssl_certificate     %s/tls.crt;
+    ssl_certificate_key %s/tls.key;
+
+    location / {
+        proxy_pass http://127.0.0.1:%d;
+        proxy_set_header Host $host;
+        proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+        proxy_set_header X-Forwarded-Proto https;
+    }
+}
+`, v1alpha1.TLSProxyPort, tlsCertDir, tlsCertDir, app.GetPort())
+}
+
+// checkTLSSecret verifies the certificate Secret exists in the target namespace
+// and carries a certificate and key
+func (r *ApplicationController) checkTLSSecret(ctx context.Context, app *v1alpha1.Application) error {
+	name := app.Spec.TLS.SecretName
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: app.GetTargetNamespace()}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tls secret %s not found in namespace %s", name, app.GetTargetNamespace())
+		}
+		return fmt.Errorf("failed to get tls secret %s: %w", name, err)
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("tls secret %s has no %s", name, key)
+		}
+	}
+	return nil
+}
+
+// reconcileTLS validates the certificate and, in sidecar mode, keeps the nginx
+// config current. It runs before the workload so the pod finds both in place.
+// The config is removed once the spec no longer asks for a sidecar.
+func (r *ApplicationController) reconcileTLS(ctx context.Context, app *v1alpha1.Application) error {
+	if app.Spec.TLS != nil {
+		if err := r.checkTLSSecret(ctx, app); err != nil {
+			return err
+		}
+	}
+	if !app.UsesTLSSidecar() {
+		return r.deleteTLSProxyConfig(ctx, app)
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetTLSProxyName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Data: map[string]string{tlsProxyConfigKey: tlsProxyConfig(app)},
+	}
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create TLS proxy ConfigMap: %w", err)
+		}
+		existing := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+			return fmt.Errorf("failed to get TLS proxy ConfigMap: %w", err)
+		}
+		if existing.Data[tlsProxyConfigKey] != desired.Data[tlsProxyConfigKey] {
+			existing.Data = desired.Data
+			if err := r.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update TLS proxy ConfigMap: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteTLSProxyConfig removes the sidecar's nginx config after TLS was turned
+// off or moved to an Ingress
+func (r *ApplicationController) deleteTLSProxyConfig(ctx context.Context, app *v1alpha1.Application) error {
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.GetTLSProxyName(), Namespace: app.GetTargetNamespace()}, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !managedBy(app, existing) {
+		return nil
+	}
+	if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete TLS proxy ConfigMap: %w", err)
+	}
+	app.Status.RemoveManagedResource(r.managedResourceRef(existing))
+	appLogger(ctx, app).Info("Deleted TLS proxy ConfigMap no longer in spec")
+	return nil
+}
+
+// tlsSidecar returns the nginx container terminating TLS in front of the
+// application, with the volumes for its config and certificate
+func tlsSidecar(app *v1alpha1.Application) (corev1.Container, []corev1.Volume) {
+	volumes := []corev1.Volume{
+		{
+			Name: "tls-proxy-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: app.GetTLSProxyName()},
+				},
+			},
+		},
+		{
+			Name: "tls-proxy-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: app.Spec.TLS.SecretName},
+			},
+		},
+	}
+	container := corev1.Container{
+		Name:  tlsProxyContainer,
+		Image: app.InfraImage(tlsProxyImage),
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          tlsPortName,
+				ContainerPort: v1alpha1.TLSProxyPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "tls-proxy-config", MountPath: "/etc/nginx/conf.d", ReadOnly: true},
+			{Name: "tls-proxy-cert", MountPath: tlsCertDir, ReadOnly: true},
+		},
+	}
+	return container, volumes
+}
+
+// tlsServicePort exposes the sidecar's HTTPS port on the application Service
+func tlsServicePort(app *v1alpha1.Application) []corev1.ServicePort {
+	if !app.UsesTLSSidecar() {
+		return nil
+	}
+	return []corev1.ServicePort{{
+		Name:       tlsPortName,
+		Port:       v1alpha1.TLSProxyPort,
+		TargetPort: intstr.FromString(tlsPortName),
+		Protocol:   corev1.ProtocolTCP,
+	}}
+}
+
+// createOrUpdateIngress serves the application Service over HTTPS in Ingress
+// mode, and removes the Ingress when the spec no longer asks for it
+func (r *ApplicationController) createOrUpdateIngress(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+	key := client.ObjectKey{Name: app.Name, Namespace: app.GetTargetNamespace()}
+
+	if !app.UsesTLSIngress() {
+		existing := &networkingv1.Ingress{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !managedBy(app, existing) {
+			return nil
+		}
+		if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ingress: %w", err)
+		}
+		app.Status.RemoveManagedResource(r.managedResourceRef(existing))
+		logger.Info("Deleted Ingress no longer in spec")
+		return nil
+	}
+
+	tls := app.Spec.TLS
+	pathType := networkingv1.PathTypePrefix
+	desired := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{tls.Host}, SecretName: tls.SecretName},
+			},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: tls.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: app.Name,
+											Port: networkingv1.ServiceBackendPort{Number: 80},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if tls.IngressClassName != "" {
+		desired.Spec.IngressClassName = &tls.IngressClassName
+	}
+
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create ingress: %w", err)
+		}
+		existing := &networkingv1.Ingress{}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return fmt.Errorf("failed to get ingress: %w", err)
+		}
+		if err := r.claimExisting(ctx, app, existing); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+			existing.Spec = desired.Spec
+			if err := r.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update ingress: %w", err)
+			}
+			logger.Info("Updated Ingress", "host", tls.Host)
+		}
+		return nil
+	}
+
+	logger.Info("Created Ingress", "host", tls.Host, "secret", tls.SecretName)
+	return nil
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func tlsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop-tls", Namespace: "default"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+}
+
+func TestTLSProxyConfigRemovedWithSidecar(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.TLS = &v1alpha1.AppTLSSpec{Mode: v1alpha1.TLSModeSidecar, SecretName: "shop-tls"}
+	r, _ := newTestController(t, app, tlsSecret())
+	key := client.ObjectKey{Name: app.GetTLSProxyName(), Namespace: "default"}
+
+	if err := r.reconcileTLS(testCtx, app); err != nil {
+		t.Fatalf("reconcileTLS: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.ConfigMap{}); err != nil {
+		t.Fatalf("TLS proxy ConfigMap not created: %v", err)
+	}
+
+	app.Spec.TLS = nil
+	if err := r.reconcileTLS(testCtx, app); err != nil {
+		t.Fatalf("reconcileTLS: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.ConfigMap{}); !errors.IsNotFound(err) {
+		t.Errorf("TLS proxy ConfigMap left behind: %v", err)
+	}
+}
+
+func TestIngressIgnoresDefaultedFields(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.TLS = &v1alpha1.AppTLSSpec{SecretName: "shop-tls", Host: "shop.example.com"}
+	r, _ := newTestController(t, app, tlsSecret())
+	key := client.ObjectKey{Name: "shop", Namespace: "default"}
+
+	if err := r.createOrUpdateIngress(testCtx, app); err != nil {
+		t.Fatalf("createOrUpdateIngress: %v", err)
+	}
+	// An ingress controller fills in its class after creation
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(testCtx, key, ingress); err != nil {
+		t.Fatal(err)
+	}
+	class := "nginx"
+	ingress.Spec.IngressClassName = &class
+	if err := r.Update(testCtx, ingress); err != nil {
+		t.Fatal(err)
+	}
+	version := ingress.ResourceVersion
+
+	if err := r.createOrUpdateIngress(testCtx, app); err != nil {
+		t.Fatalf("createOrUpdateIngress: %v", err)
+	}
+	if err := r.Get(testCtx, key, ingress); err != nil {
+		t.Fatal(err)
+	}
+	if ingress.ResourceVersion != version {
+		t.Error("Ingress rewritten although the spec did not change")
+	}
+
+	app.Spec.TLS.Host = "store.example.com"
+	if err := r.createOrUpdateIngress(testCtx, app); err != nil {
+		t.Fatalf("createOrUpdateIngress: %v", err)
+	}
+	if err := r.Get(testCtx, key, ingress); err != nil {
+		t.Fatal(err)
+	}
+	if ingress.Spec.Rules[0].Host != "store.example.com" {
+		t.Errorf("host = %s, want the new host", ingress.Spec.Rules[0].Host)
+	}
+}
@@ -0,0 +1,65 @@
+// pkg/controllers/tracing.go
+// OpenTelemetry spans around reconcile phases and provisioning steps
+
+package controllers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// TracerName identifies the controller's spans; the global tracer provider is
+// a no-op unless the operator configures an exporter
+const TracerName = "github.com/virtual457/orion-platform/controllers"
+
+// Span attribute keys, mirroring the standard log keys
+const (
+	attrApplication = attribute.Key("orion.application")
+	attrNamespace   = attribute.Key("orion.namespace")
+	attrPhase       = attribute.Key("orion.phase")
+	attrComponent   = attribute.Key("orion.component")
+)
+
+// startReconcileSpan starts the root span of a reconcile, before the
+// Application has been fetched
+func startReconcileSpan(ctx context.Context, req ctrl.Request) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, "Reconcile", trace.WithAttributes(
+		attrApplication.String(req.Name),
+		attrNamespace.String(req.Namespace),
+	))
+}
+
+// startSpan starts a child span carrying the Application's standard attributes
+func startSpan(ctx context.Context, app *v1alpha1.Application, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attrApplication.String(app.Name),
+		attrNamespace.String(app.Namespace),
+		attrPhase.String(string(app.Status.Phase)),
+	)
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on the span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceStep runs one provisioning or deployment step in its own span
+func (r *ApplicationController) traceStep(ctx context.Context, app *v1alpha1.Application, name string,
+	step func(context.Context, *v1alpha1.Application) error, attrs ...attribute.KeyValue) error {
+	ctx, span := startSpan(ctx, app, name, attrs...)
+	err := step(ctx, app)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestReconcileSpanHierarchy(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	app := newTestApplication("shop")
+	app.Finalizers = []string{cleanupFinalizer}
+	app.Spec.Infrastructure.Redis = &v1alpha1.RedisSpec{Environment: v1alpha1.EnvironmentLocal}
+	r, _ := newTestController(t, app)
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)}
+	for i := 0; i < 3; i++ {
+		if _, err := r.Reconcile(testCtx, req); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+	}
+
+	spans := exporter.GetSpans()
+	byID := map[string]tracetest.SpanStub{}
+	var provision *tracetest.SpanStub
+	for i, s := range spans {
+		byID[s.SpanContext.SpanID().String()] = s
+		if s.Name == "provision/"+componentCache {
+			provision = &spans[i]
+		}
+	}
+	if provision == nil {
+		t.Fatalf("no provision/%s span among %d spans", componentCache, len(spans))
+	}
+	found := false
+	for _, attr := range provision.Attributes {
+		if attr.Key == attrComponent && attr.Value.AsString() == componentCache {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("provision span attributes = %v, want %s=%s", provision.Attributes, attrComponent, componentCache)
+	}
+
+	// provision/cache -> provisionInfrastructure -> reconcileApplication -> Reconcile
+	var chain []string
+	for s, ok := *provision, true; ok; s, ok = byID[s.Parent.SpanID().String()] {
+		chain = append(chain, s.Name)
+	}
+	want := []string{"provision/" + componentCache, "provisionInfrastructure", "reconcileApplication", "Reconcile"}
+	if len(chain) != len(want) {
+		t.Fatalf("span chain = %v, want %v", chain, want)
+	}
+	for i := range want {
+		if chain[i] != want[i] {
+			t.Errorf("span chain = %v, want %v", chain, want)
+			break
+		}
+	}
+}
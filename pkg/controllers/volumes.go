@@ -0,0 +1,49 @@
+// pkg/controllers/volumes.go
+// Secrets mounted into the application container as files
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// DatabaseCredentialsMountPath is where the database credentials Secret is
+// mounted when MountDatabaseCredentials is enabled
+const DatabaseCredentialsMountPath = "/etc/secrets/db"
+
+// buildSecretVolumes returns the pod volumes and app container mounts for the
+// spec's secretVolumes, plus the database credentials when configured
+func (r *ApplicationController) buildSecretVolumes(app *v1alpha1.Application) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+
+	add := func(name, secretName, mountPath string) {
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	for i, sv := range app.Spec.SecretVolumes {
+		add(fmt.Sprintf("secret-%d", i), sv.SecretName, sv.MountPath)
+	}
+
+	if r.Config.MountDatabaseCredentials {
+		if conn := app.GetConnectionInfo().Database; conn != nil && conn.CredentialsSecretName != "" && !app.HasSecretMountAt(DatabaseCredentialsMountPath) {
+			add("db-credentials", conn.CredentialsSecretName, DatabaseCredentialsMountPath)
+		}
+	}
+
+	return volumes, mounts
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// externalDatabaseApp returns an Application whose external database has
+// been recorded in status, as provisioning leaves it
+func externalDatabaseApp() *v1alpha1.Application {
+	app := newTestApplication("shop")
+	app.Spec.Infrastructure.PostgreSQL = &v1alpha1.PostgreSQLSpec{
+		External: &v1alpha1.ExternalDatabaseSpec{Endpoint: "db.example.com:5432", CredentialsSecretName: "shop-db"},
+	}
+	app.Status.DatabaseEndpoint = "db.example.com:5432"
+	app.Status.DatabaseEnvironment = v1alpha1.EnvironmentExternal
+	return app
+}
+
+func mountAt(mounts []corev1.VolumeMount, path string) *corev1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].MountPath == path {
+			return &mounts[i]
+		}
+	}
+	return nil
+}
+
+func TestSecretVolumes(t *testing.T) {
+	app := newTestApplication("shop")
+	app.Spec.SecretVolumes = []v1alpha1.SecretVolumeMount{{SecretName: "tls-certs", MountPath: "/etc/certs"}}
+	r, _ := newTestController(t, app)
+
+	template, err := r.buildPodTemplate(testCtx, app)
+	if err != nil {
+		t.Fatalf("buildPodTemplate: %v", err)
+	}
+	mount := mountAt(template.Spec.Containers[0].VolumeMounts, "/etc/certs")
+	if mount == nil || !mount.ReadOnly {
+		t.Fatalf("mounts = %+v, want a read-only mount at /etc/certs", template.Spec.Containers[0].VolumeMounts)
+	}
+	var source *corev1.SecretVolumeSource
+	for _, v := range template.Spec.Volumes {
+		if v.Name == mount.Name {
+			source = v.Secret
+		}
+	}
+	if source == nil || source.SecretName != "tls-certs" {
+		t.Errorf("volume %s = %+v, want Secret tls-certs", mount.Name, source)
+	}
+}
+
+func TestDatabaseCredentialsMount(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		userMount bool
+		wantAuto  bool
+	}{
+		{"enabled", true, false, true},
+		{"disabled", false, false, false},
+		{"user mount at the same path", true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := externalDatabaseApp()
+			if tt.userMount {
+				app.Spec.SecretVolumes = []v1alpha1.SecretVolumeMount{{SecretName: "custom", MountPath: DatabaseCredentialsMountPath}}
+			}
+			r, _ := newTestController(t, app)
+			r.Config.MountDatabaseCredentials = tt.enabled
+
+			volumes, mounts := r.buildSecretVolumes(app)
+			var auto *corev1.Volume
+			for i := range volumes {
+				if volumes[i].Name == "db-credentials" {
+					auto = &volumes[i]
+				}
+			}
+			if (auto != nil) != tt.wantAuto {
+				t.Fatalf("volumes = %+v, want the credentials volume %t", volumes, tt.wantAuto)
+			}
+			if tt.wantAuto && auto.Secret.SecretName != "shop-db" {
+				t.Errorf("credentials volume = %+v, want Secret shop-db", auto.Secret)
+			}
+			if (mountAt(mounts, DatabaseCredentialsMountPath) != nil) != (tt.wantAuto || tt.userMount) {
+				t.Errorf("mounts = %+v", mounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,407 @@
+// pkg/controllers/workloads.go
+// Non-Deployment workload kinds for the application itself
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// buildAppCronJob renders the batch/v1 CronJob running the application on its schedule
+func (r *ApplicationController) buildAppCronJob(ctx context.Context, app *v1alpha1.Application) (*batchv1.CronJob, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.Name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: app.Spec.Schedule,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": app.Name},
+				},
+				Spec: batchv1.JobSpec{
+					Template: template,
+				},
+			},
+		},
+	}
+	return cronJob, nil
+}
+
+// createOrUpdateCronJob creates the application CronJob, or brings an
+// existing one in line with the spec so later runs use the current template
+func (r *ApplicationController) createOrUpdateCronJob(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	cronJob, err := r.buildAppCronJob(ctx, app)
+	if err != nil {
+		return err
+	}
+	if err := r.createOwned(ctx, app, cronJob); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create cronjob: %w", err)
+		}
+		changes, err := r.updateCronJob(ctx, cronJob)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			logger.Info("Updated Kubernetes CronJob", "changes", strings.Join(changes, "; "))
+		} else {
+			logger.Info("CronJob already exists")
+		}
+		return nil
+	}
+
+	logger.Info("Created Kubernetes CronJob", "schedule", app.Spec.Schedule)
+	return nil
+}
+
+// checkCronJobReady reports a CronJob as ready once it exists; individual runs
+// come and go on the schedule
+func (r *ApplicationController) checkCronJobReady(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	cronJob := &batchv1.CronJob{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: app.GetTargetNamespace()}, cronJob); err != nil {
+		return false, err
+	}
+	app.Status.ReadyReplicas = int32(len(cronJob.Status.Active))
+	return true, nil
+}
+
+// updateCronJob applies the desired schedule and job template to the live
+// CronJob and returns what changed
+func (r *ApplicationController) updateCronJob(ctx context.Context, desired *batchv1.CronJob) ([]string, error) {
+	live := &batchv1.CronJob{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		return nil, fmt.Errorf("failed to get cronjob: %w", err)
+	}
+	changes := templateDrift(&desired.Spec.JobTemplate.Spec.Template, &live.Spec.JobTemplate.Spec.Template)
+	if live.Spec.Schedule != desired.Spec.Schedule {
+		changes = append(changes, fmt.Sprintf("schedule %q -> %q", live.Spec.Schedule, desired.Spec.Schedule))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	live.Spec.Schedule = desired.Spec.Schedule
+	live.Spec.JobTemplate = desired.Spec.JobTemplate
+	if err := r.Update(ctx, live); err != nil {
+		return nil, fmt.Errorf("failed to update cronjob: %w", err)
+	}
+	return changes, nil
+}
+
+// buildAppJob renders the batch/v1 Job running the application once to completion
+func (r *ApplicationController) buildAppJob(ctx context.Context, app *v1alpha1.Application) (*batchv1.Job, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.Name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: app.Spec.BackoffLimit,
+			Template:     template,
+		},
+	}
+	return job, nil
+}
+
+// createOrUpdateJob creates the application Job. A Job's pod template cannot
+// be changed, so an existing Job built from other configuration is replaced.
+func (r *ApplicationController) createOrUpdateJob(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	job, err := r.buildAppJob(ctx, app)
+	if err != nil {
+		return err
+	}
+	if err := r.createOwned(ctx, app, job); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create job: %w", err)
+		}
+		replaced, err := r.replaceChangedJob(ctx, app, job)
+		if err != nil {
+			return err
+		}
+		if !replaced {
+			logger.Info("Job already exists")
+		}
+		return nil
+	}
+
+	logger.Info("Created Kubernetes Job")
+	return nil
+}
+
+// replaceChangedJob deletes the live Job when its config hash differs from
+// the desired one and creates the desired Job in its place, re-running the
+// task with the new configuration. It reports whether the Job was replaced.
+func (r *ApplicationController) replaceChangedJob(ctx context.Context, app *v1alpha1.Application, desired *batchv1.Job) (bool, error) {
+	live := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		return false, fmt.Errorf("failed to get job: %w", err)
+	}
+	hash := desired.Spec.Template.Annotations[v1alpha1.ConfigHashAnnotation]
+	if live.Spec.Template.Annotations[v1alpha1.ConfigHashAnnotation] == hash {
+		return false, nil
+	}
+	if err := r.Delete(ctx, live, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete job: %w", err)
+	}
+	// Until the old Job is gone the create conflicts and the deploy is retried
+	if err := r.createOwned(ctx, app, desired); err != nil {
+		return false, fmt.Errorf("failed to recreate job: %w", err)
+	}
+	appLogger(ctx, app).Info("Replaced Job built from changed configuration")
+	r.recordEvent(app, corev1.EventTypeNormal, "JobReplaced", "Configuration changed; re-running the Job")
+	return true, nil
+}
+
+// reconcileJobCompletion maps the Job's terminal conditions onto the Application phase
+func (r *ApplicationController) reconcileJobCompletion(ctx context.Context, app *v1alpha1.Application) (ctrl.Result, error) {
+	logger := appLogger(ctx, app)
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: app.GetTargetNamespace()}, job); err != nil {
+		logger.Error(err, "Failed to get job")
+		return ctrl.Result{RequeueAfter: r.Config.ReadinessErrorRequeue}, nil
+	}
+
+	app.Status.ReadyReplicas = job.Status.Active
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			logger.Info("Job completed", "succeeded", job.Status.Succeeded)
+			app.UpdateStatus(v1alpha1.PhaseReady, "Job completed successfully")
+			return r.updateApplicationStatus(ctx, app)
+		case batchv1.JobFailed:
+			logger.Info("Job failed", "reason", cond.Reason, "failed", job.Status.Failed)
+			app.UpdateStatus(v1alpha1.PhaseFailed, fmt.Sprintf("Job failed: %s: %s", cond.Reason, cond.Message))
+			return r.updateApplicationStatus(ctx, app)
+		}
+	}
+
+	logger.Info("Job still running...", "active", job.Status.Active, "failed", job.Status.Failed)
+	if err := r.updateApplicationStatusOnly(ctx, app); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.Config.DeployRequeue}, nil
+}
+
+// buildAppStatefulSet renders the application StatefulSet with a per-replica
+// volume claim mounted at /data
+func (r *ApplicationController) buildAppStatefulSet(ctx context.Context, app *v1alpha1.Application) (*appsv1.StatefulSet, error) {
+	template, err := r.buildPodTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.Containers[0].VolumeMounts = append(template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "data",
+		MountPath: "/data",
+	})
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.Name,
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &[]int32{app.GetReplicas()}[0],
+			ServiceName: app.GetHeadlessServiceName(),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: appSelectorLabels(app),
+			},
+			Template: template,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "data",
+						Labels: map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse(app.GetAppStorage()),
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// createOrUpdateAppStatefulSet creates a StatefulSet for the application
+func (r *ApplicationController) createOrUpdateAppStatefulSet(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	statefulSet, err := r.buildAppStatefulSet(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	if err := r.createOwned(ctx, app, statefulSet); err != nil {
+		if errors.IsAlreadyExists(err) {
+			existing := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: app.Name, Namespace: app.GetTargetNamespace()}}
+			if err := r.claimByKey(ctx, app, existing); err != nil {
+				return err
+			}
+			logger.Info("StatefulSet already exists")
+			return nil
+		}
+		return fmt.Errorf("failed to create statefulset: %w", err)
+	}
+
+	logger.Info("Created Kubernetes StatefulSet", "replicas", app.GetReplicas(), "storage", app.GetAppStorage())
+	return nil
+}
+
+// reconcileAppStorage expands the application volumes and records a failure
+// in status.expansionError. The ExpansionFailed event is only recorded when
+// the failure changes, not on every periodic check; the returned error is
+// from the status write.
+func (r *ApplicationController) reconcileAppStorage(ctx context.Context, app *v1alpha1.Application) error {
+	expansionError := ""
+	if err := r.expandAppStorage(ctx, app); err != nil {
+		appLogger(ctx, app).Error(err, "Failed to expand application volumes")
+		expansionError = err.Error()
+	}
+	if expansionError == app.Status.ExpansionError {
+		return nil
+	}
+	if expansionError != "" {
+		r.recordEvent(app, corev1.EventTypeWarning, "ExpansionFailed", expansionError)
+	}
+	app.Status.ExpansionError = expansionError
+	return r.updateApplicationStatusOnly(ctx, app)
+}
+
+// expandAppStorage grows the per-replica volumes of a StatefulSet application
+// when appStorage was raised; the claim template itself is immutable
+func (r *ApplicationController) expandAppStorage(ctx context.Context, app *v1alpha1.Application) error {
+	if app.GetKind() != v1alpha1.WorkloadStatefulSet {
+		return nil
+	}
+	live := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: app.GetTargetNamespace()}, live); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	desired, err := r.buildAppStatefulSet(ctx, app)
+	if err != nil {
+		return err
+	}
+	return r.expandClaimTemplatePVCs(ctx, live, desired.Spec.VolumeClaimTemplates[0])
+}
+
+// createOrUpdateHeadlessService creates a ClusterIP: None Service giving each
+// app pod a stable DNS name
+func (r *ApplicationController) createOrUpdateHeadlessService(ctx context.Context, app *v1alpha1.Application) error {
+	logger := appLogger(ctx, app)
+
+	selector, err := r.podSelectorFor(ctx, app, app.Name)
+	if err != nil {
+		return err
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetHeadlessServiceName(),
+			Namespace: app.GetTargetNamespace(),
+			Labels:    map[string]string{"app": app.Name, "managed-by": "orion-platform"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:            corev1.ServiceTypeClusterIP,
+			ClusterIP:       corev1.ClusterIPNone,
+			SessionAffinity: corev1.ServiceAffinityNone,
+			Selector:        selector,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       app.GetPort(),
+					TargetPort: intstr.FromInt32(app.GetPort()),
+					Protocol:   app.GetProtocol(),
+				},
+			},
+			PublishNotReadyAddresses: true,
+		},
+	}
+	if extraPorts := additionalServicePorts(app); len(extraPorts) > 0 {
+		service.Spec.Ports[0].Name = mainPortName(app)
+		service.Spec.Ports = append(service.Spec.Ports, extraPorts...)
+	}
+
+	if err := r.createOwned(ctx, app, service); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return r.updateService(ctx, app, service)
+		}
+		return fmt.Errorf("failed to create headless service: %w", err)
+	}
+
+	logger.Info("Created headless Service", "name", service.Name)
+	return nil
+}
+
+// wantsHeadlessService reports whether the application gets a headless
+// Service: StatefulSets always need one, other workloads ask for it
+func wantsHeadlessService(app *v1alpha1.Application) bool {
+	return app.GetKind() == v1alpha1.WorkloadStatefulSet || app.Spec.HeadlessService
+}
+
+// deleteHeadlessService removes a headless Service the spec no longer asks for
+func (r *ApplicationController) deleteHeadlessService(ctx context.Context, app *v1alpha1.Application) error {
+	service := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.GetHeadlessServiceName(), Namespace: app.GetTargetNamespace()}, service); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !managedBy(app, service) {
+		return nil
+	}
+	if err := r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete headless service: %w", err)
+	}
+	app.Status.RemoveManagedResource(v1alpha1.ManagedResourceRef{Kind: "Service", Name: service.Name, Namespace: service.Namespace})
+	appLogger(ctx, app).Info("Deleted headless Service", "name", service.Name)
+	return nil
+}
+
+func (r *ApplicationController) checkAppStatefulSetReady(ctx context.Context, app *v1alpha1.Application) (bool, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: app.GetTargetNamespace()}, statefulSet); err != nil {
+		return false, err
+	}
+
+	app.Status.ReadyReplicas = statefulSet.Status.ReadyReplicas
+	return statefulSet.Status.ReadyReplicas == app.GetReplicas(), nil
+}
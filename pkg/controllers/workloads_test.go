@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestExpansionFailureReportedOnce(t *testing.T) {
+	app := newTestApplication("store")
+	app.Spec.Kind = v1alpha1.WorkloadStatefulSet
+	app.Spec.AppStorage = "5Gi"
+	class := "fixed"
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: app.Name, Namespace: app.GetTargetNamespace()},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &[]int32{1}[0]},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-store-0", Namespace: app.GetTargetNamespace()},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &class,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: class},
+		Provisioner:          "example.com/disk",
+		AllowVolumeExpansion: &[]bool{false}[0],
+	}
+	r, recorder := newTestController(t, app, sts, pvc, storageClass)
+
+	for i := 0; i < 3; i++ {
+		if err := r.reconcileAppStorage(testCtx, app); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if app.Status.ExpansionError == "" {
+		t.Error("expansionError is empty, want the failure recorded")
+	}
+	events := drainEvents(recorder)
+	if len(events) != 1 || !hasEvent(events, "ExpansionFailed") {
+		t.Errorf("events = %v, want a single ExpansionFailed", events)
+	}
+
+	// Once expansion is allowed the failure is cleared
+	storageClass.AllowVolumeExpansion = &[]bool{true}[0]
+	if err := r.Update(testCtx, storageClass); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileAppStorage(testCtx, app); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status.ExpansionError != "" {
+		t.Errorf("expansionError = %q after a successful expansion", app.Status.ExpansionError)
+	}
+}
+
+func TestCronJobWorkload(t *testing.T) {
+	app := newTestApplication("report")
+	app.Spec.Kind = v1alpha1.WorkloadCronJob
+	app.Spec.Schedule = "0 2 * * *"
+	r, _ := newTestController(t, app)
+
+	reconcileUntil(t, r, app, v1alpha1.PhaseDeploying)
+
+	key := client.ObjectKey{Name: "report", Namespace: "default"}
+	cronJob := &batchv1.CronJob{}
+	if err := r.Get(testCtx, key, cronJob); err != nil {
+		t.Fatalf("CronJob not created: %v", err)
+	}
+	if cronJob.Spec.Schedule != "0 2 * * *" {
+		t.Errorf("schedule = %q, want 0 2 * * *", cronJob.Spec.Schedule)
+	}
+	if image := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image; image != "nginx:1.25" {
+		t.Errorf("image = %q, want nginx:1.25", image)
+	}
+	if err := r.Get(testCtx, key, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Deployment created for a CronJob: %v", err)
+	}
+	if err := r.Get(testCtx, key, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Service created for a CronJob: %v", err)
+	}
+}
+
+func TestJobCompletion(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition batchv1.JobConditionType
+		wantPhase v1alpha1.ApplicationPhase
+	}{
+		{"succeeded", batchv1.JobComplete, v1alpha1.PhaseReady},
+		{"backoff exhausted", batchv1.JobFailed, v1alpha1.PhaseFailed},
+		{"running", "", v1alpha1.PhaseDeploying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication("migrate")
+			app.Spec.Kind = v1alpha1.WorkloadJob
+			r, _ := newTestController(t, app)
+			reconcileUntil(t, r, app, v1alpha1.PhaseDeploying)
+
+			job := &batchv1.Job{}
+			if err := r.Get(testCtx, client.ObjectKey{Name: "migrate", Namespace: "default"}, job); err != nil {
+				t.Fatalf("Job not created: %v", err)
+			}
+			job.Status.Active = 1
+			if tt.condition != "" {
+				job.Status.Active = 0
+				job.Status.Conditions = []batchv1.JobCondition{{Type: tt.condition, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+			}
+			if err := r.Status().Update(testCtx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			stored := reconcileUntil(t, r, app, tt.wantPhase)
+			if tt.wantPhase == v1alpha1.PhaseFailed && stored.Status.Message != "Job failed: BackoffLimitExceeded: " {
+				t.Errorf("message = %q, want the Job's failure reason", stored.Status.Message)
+			}
+			if tt.wantPhase == v1alpha1.PhaseDeploying && stored.Status.ReadyReplicas != 1 {
+				t.Errorf("readyReplicas = %d, want the active pod count", stored.Status.ReadyReplicas)
+			}
+		})
+	}
+}
+
+func TestStatefulSetWorkload(t *testing.T) {
+	app := newTestApplication("store")
+	app.Spec.Kind = v1alpha1.WorkloadStatefulSet
+	app.Spec.Replicas = 3
+	app.Spec.Port = 8080
+	app.Spec.AppStorage = "5Gi"
+	r, _ := newTestController(t, app)
+
+	reconcileUntil(t, r, app, v1alpha1.PhaseDeploying)
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: "store", Namespace: "default"}, sts); err != nil {
+		t.Fatalf("StatefulSet not created: %v", err)
+	}
+	if *sts.Spec.Replicas != 3 || sts.Spec.ServiceName != app.GetHeadlessServiceName() {
+		t.Errorf("replicas %d, serviceName %q; want 3 governed by %s", *sts.Spec.Replicas, sts.Spec.ServiceName, app.GetHeadlessServiceName())
+	}
+	if len(sts.Spec.VolumeClaimTemplates) != 1 || sts.Spec.VolumeClaimTemplates[0].Name != "data" {
+		t.Fatalf("volumeClaimTemplates = %v, want one named data", sts.Spec.VolumeClaimTemplates)
+	}
+	if size := sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "5Gi" {
+		t.Errorf("claim size = %s, want 5Gi", size.String())
+	}
+	mounted := false
+	for _, m := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || (m.Name == "data" && m.MountPath == "/data")
+	}
+	if !mounted {
+		t.Errorf("volume mounts = %v, want data at /data", sts.Spec.Template.Spec.Containers[0].VolumeMounts)
+	}
+	if err := r.Get(testCtx, client.ObjectKey{Name: "store", Namespace: "default"}, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Deployment created for a StatefulSet: %v", err)
+	}
+
+	headless := &corev1.Service{}
+	if err := r.Get(testCtx, client.ObjectKey{Name: app.GetHeadlessServiceName(), Namespace: "default"}, headless); err != nil {
+		t.Fatalf("headless Service not created: %v", err)
+	}
+	if headless.Spec.ClusterIP != corev1.ClusterIPNone || !headless.Spec.PublishNotReadyAddresses {
+		t.Errorf("headless Service clusterIP %q, publishNotReadyAddresses %t; want None and true", headless.Spec.ClusterIP, headless.Spec.PublishNotReadyAddresses)
+	}
+	if !hasServicePort(headless, 8080) {
+		t.Errorf("headless Service ports = %v, want 8080", headless.Spec.Ports)
+	}
+	for k, v := range sts.Spec.Selector.MatchLabels {
+		if headless.Spec.Selector[k] != v {
+			t.Errorf("headless Service selector %v does not select the StatefulSet pods %v", headless.Spec.Selector, sts.Spec.Selector.MatchLabels)
+			break
+		}
+	}
+}
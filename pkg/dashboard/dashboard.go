@@ -0,0 +1,163 @@
+// pkg/dashboard/dashboard.go
+// Grafana dashboard for the controller's orion_* metrics
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/virtual457/orion-platform/pkg/controllers"
+)
+
+// DefaultTitle is used when no title is given
+const DefaultTitle = "Orion Platform"
+
+// Dashboard is the subset of the Grafana dashboard model the generator uses
+type Dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	Timezone      string     `json:"timezone"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the dashboard's default time range
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the dashboard variables
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable; only the datasource picker is used
+type Variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+// Panel is one visualization
+type Panel struct {
+	ID         int        `json:"id"`
+	Title      string     `json:"title"`
+	Type       string     `json:"type"`
+	GridPos    GridPos    `json:"gridPos"`
+	Datasource Datasource `json:"datasource"`
+	Targets    []Target   `json:"targets"`
+}
+
+// GridPos places a panel on the 24-column grid
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Datasource points panels at the selected Prometheus datasource
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// Target is one PromQL query of a panel
+type Target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+// prometheus is the datasource every panel queries, chosen by the variable
+var prometheus = Datasource{Type: "prometheus", UID: "${datasource}"}
+
+// Build returns the dashboard with the given title
+func Build(title string) Dashboard {
+	if title == "" {
+		title = DefaultTitle
+	}
+	panels := []Panel{
+		{
+			Title: "Reconcile rate",
+			Type:  "timeseries",
+			Targets: []Target{{
+				Expr:         fmt.Sprintf("sum by (result) (rate(%s[5m]))", controllers.MetricReconcileTotal),
+				LegendFormat: "{{result}}",
+			}},
+		},
+		{
+			Title: "Reconcile error ratio",
+			Type:  "stat",
+			Targets: []Target{{
+				Expr: fmt.Sprintf(`sum(rate(%[1]s{result="error"}[5m])) / clamp_min(sum(rate(%[1]s[5m])), 1e-9)`,
+					controllers.MetricReconcileTotal),
+			}},
+		},
+		{
+			Title: "Provisioning duration (p95)",
+			Type:  "timeseries",
+			Targets: []Target{{
+				Expr: fmt.Sprintf("histogram_quantile(0.95, sum by (le, component) (rate(%s_bucket[5m])))",
+					controllers.MetricProvisioningDuration),
+				LegendFormat: "{{component}}",
+			}},
+		},
+		{
+			Title: "Provisioning failures",
+			Type:  "timeseries",
+			Targets: []Target{{
+				Expr: fmt.Sprintf(`sum by (component) (rate(%s_count{result="error"}[5m]))`,
+					controllers.MetricProvisioningDuration),
+				LegendFormat: "{{component}}",
+			}},
+		},
+		{
+			Title: "Applications by phase",
+			Type:  "bargauge",
+			Targets: []Target{{
+				Expr:         fmt.Sprintf("sum by (phase) (%s)", controllers.MetricApplications),
+				LegendFormat: "{{phase}}",
+			}},
+		},
+	}
+
+	// Two panels per row, 12 columns by 8 rows each
+	for i := range panels {
+		panels[i].ID = i + 1
+		panels[i].Datasource = prometheus
+		panels[i].GridPos = GridPos{X: (i % 2) * 12, Y: (i / 2) * 8, W: 12, H: 8}
+		for j := range panels[i].Targets {
+			panels[i].Targets[j].RefID = string(rune('A' + j))
+		}
+	}
+
+	return Dashboard{
+		UID:           "orion-platform",
+		Title:         title,
+		Tags:          []string{"orion", "kubernetes"},
+		Timezone:      "browser",
+		SchemaVersion: 38,
+		Refresh:       "30s",
+		Time:          TimeRange{From: "now-6h", To: "now"},
+		Templating: Templating{List: []Variable{{
+			Name:  "datasource",
+			Label: "Datasource",
+			Type:  "datasource",
+			Query: "prometheus",
+		}}},
+		Panels: panels,
+	}
+}
+
+// JSON renders the dashboard as indented JSON ready to import into Grafana
+func (d Dashboard) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
@@ -0,0 +1,53 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDashboardJSON(t *testing.T) {
+	data, err := Build("").JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	// Grafana imports it as a plain JSON object
+	var model map[string]any
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+	if model["title"] != DefaultTitle {
+		t.Errorf("title = %v, want %q", model["title"], DefaultTitle)
+	}
+
+	var d Dashboard
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	var exprs []string
+	for _, p := range d.Panels {
+		if p.Datasource.UID != "${datasource}" {
+			t.Errorf("panel %q datasource = %q, want the datasource variable", p.Title, p.Datasource.UID)
+		}
+		for _, target := range p.Targets {
+			exprs = append(exprs, target.Expr)
+		}
+	}
+	all := strings.Join(exprs, "\n")
+	for _, metric := range []string{
+		"orion_reconcile_total",
+		"orion_provisioning_duration_seconds_bucket",
+		"orion_applications",
+	} {
+		if !strings.Contains(all, metric) {
+			t.Errorf("no panel queries %s; queries:\n%s", metric, all)
+		}
+	}
+}
+
+func TestDashboardTitle(t *testing.T) {
+	if got := Build("Payments").Title; got != "Payments" {
+		t.Errorf("title = %q, want Payments", got)
+	}
+}
@@ -0,0 +1,112 @@
+// pkg/webhooks/application_webhook.go
+// Admission validation for Application resources
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+// ApplicationValidator rejects invalid Applications at admission time, before
+// the controller ever sees them
+type ApplicationValidator struct {
+	// ImmutableFields are spec field paths that cannot change after creation
+	ImmutableFields []string
+	// Strict rejects specs that only have warnings
+	Strict bool
+	// Limits caps the infrastructure a single Application may request
+	Limits v1alpha1.InfrastructureLimits
+	// Environment is what "auto" infrastructure resolves to before the checks
+	// that depend on where a component runs; empty leaves "auto" unresolved
+	Environment v1alpha1.Environment
+}
+
+var _ admission.CustomValidator = &ApplicationValidator{}
+
+// SetupWithManager registers the validating webhook with the manager's webhook server
+func (v *ApplicationValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Application{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate runs the spec validation on new Applications
+func (v *ApplicationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	app, err := v.toApplication(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := app.ValidateSpec(); err != nil {
+		return nil, err
+	}
+	if err := app.ValidateLimits(v.Limits); err != nil {
+		return nil, err
+	}
+	return v.warnings(app)
+}
+
+// ValidateUpdate runs the spec validation and protects immutable fields.
+// Updates that leave the spec alone (finalizer removal, labels, a deletion in
+// progress) are allowed, so an Application that no longer validates can still
+// be deleted.
+func (v *ApplicationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldApp, err := v.toApplication(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newApp, err := v.toApplication(newObj)
+	if err != nil {
+		return nil, err
+	}
+	if !newApp.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(oldApp.Spec, newApp.Spec) {
+		return nil, nil
+	}
+	if err := newApp.ValidateSpec(); err != nil {
+		return nil, err
+	}
+	if err := newApp.ValidateUpdate(oldApp, v.ImmutableFields); err != nil {
+		return nil, err
+	}
+	if err := newApp.ValidateLimits(v.Limits); err != nil {
+		return nil, err
+	}
+	return v.warnings(newApp)
+}
+
+// ValidateDelete allows all deletions
+func (v *ApplicationValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// warnings returns the spec warnings, or rejects them in strict mode
+func (v *ApplicationValidator) warnings(app *v1alpha1.Application) (admission.Warnings, error) {
+	warnings := app.SpecWarnings()
+	if v.Strict && len(warnings) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(warnings, "; "))
+	}
+	return warnings, nil
+}
+
+// toApplication returns a copy of obj with "auto" infrastructure resolved as
+// the controller will resolve it
+func (v *ApplicationValidator) toApplication(obj runtime.Object) (*v1alpha1.Application, error) {
+	app, ok := obj.(*v1alpha1.Application)
+	if !ok {
+		return nil, fmt.Errorf("expected an Application but got %T", obj)
+	}
+	app = app.DeepCopy()
+	if v.Environment != "" {
+		app.Spec.Infrastructure.ResolveAutoEnvironment(v.Environment)
+	}
+	return app, nil
+}
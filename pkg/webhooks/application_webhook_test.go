@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/virtual457/orion-platform/pkg/apis/platform/v1alpha1"
+)
+
+func TestValidateUpdateSkipsMetadataOnlyChanges(t *testing.T) {
+	v := &ApplicationValidator{ImmutableFields: v1alpha1.DefaultImmutableFields}
+	// No image: the spec no longer validates, as after a validation change
+	old := &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{
+		Name:       "shop",
+		Finalizers: []string{"platform.orion.dev/cleanup"},
+	}}
+
+	removed := old.DeepCopy()
+	removed.Finalizers = nil
+	if _, err := v.ValidateUpdate(context.Background(), old, removed); err != nil {
+		t.Errorf("finalizer removal rejected: %v", err)
+	}
+
+	deleting := old.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Spec.Replicas = 2
+	if _, err := v.ValidateUpdate(context.Background(), old, deleting); err != nil {
+		t.Errorf("update during deletion rejected: %v", err)
+	}
+
+	changed := old.DeepCopy()
+	changed.Spec.Replicas = 2
+	if _, err := v.ValidateUpdate(context.Background(), old, changed); err == nil {
+		t.Error("spec change on an invalid Application was allowed")
+	}
+}
+
+func TestValidateCreateResolvesAutoEnvironment(t *testing.T) {
+	app := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+		Spec: v1alpha1.ApplicationSpec{
+			Image:          "example/shop:1.0",
+			Infrastructure: v1alpha1.InfrastructureSpec{Redis: &v1alpha1.RedisSpec{Exporter: true}},
+		},
+	}
+	for _, tt := range []struct {
+		environment v1alpha1.Environment
+		wantWarning bool
+	}{
+		{v1alpha1.EnvironmentLocal, false},
+		{v1alpha1.EnvironmentAWS, true},
+	} {
+		v := &ApplicationValidator{Environment: tt.environment}
+		warnings, err := v.ValidateCreate(context.Background(), app)
+		if err != nil {
+			t.Fatalf("%s: ValidateCreate: %v", tt.environment, err)
+		}
+		if warned := len(warnings) > 0; warned != tt.wantWarning {
+			t.Errorf("%s: warnings = %v, want exporter warning %t", tt.environment, warnings, tt.wantWarning)
+		}
+	}
+	if app.Spec.Infrastructure.Environment != "" {
+		t.Error("validation resolved the environment on the admitted object")
+	}
+}
+
+func TestValidateUpdateStorageGrowth(t *testing.T) {
+	v := &ApplicationValidator{}
+	old := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+		Spec: v1alpha1.ApplicationSpec{
+			Image: "example/shop:1.0",
+			Infrastructure: v1alpha1.InfrastructureSpec{
+				PostgreSQL: &v1alpha1.PostgreSQLSpec{Environment: v1alpha1.EnvironmentLocal, LocalStorage: "5Gi"},
+			},
+		},
+	}
+
+	shrunk := old.DeepCopy()
+	shrunk.Spec.Infrastructure.PostgreSQL.LocalStorage = "2Gi"
+	_, err := v.ValidateUpdate(context.Background(), old, shrunk)
+	if err == nil || !strings.Contains(err.Error(), "infrastructure.postgresql.localStorage (5Gi -> 2Gi)") {
+		t.Errorf("ValidateUpdate = %v, want the shrink rejected", err)
+	}
+
+	grown := old.DeepCopy()
+	grown.Spec.Infrastructure.PostgreSQL.LocalStorage = "10Gi"
+	if _, err := v.ValidateUpdate(context.Background(), old, grown); err != nil {
+		t.Errorf("storage growth rejected: %v", err)
+	}
+}